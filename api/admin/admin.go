@@ -14,19 +14,40 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/vechain/thor/v2/api/admin/apilogs"
+	"github.com/vechain/thor/v2/api/admin/backup"
 	"github.com/vechain/thor/v2/api/admin/loglevel"
+	"github.com/vechain/thor/v2/api/admin/peers"
+	"github.com/vechain/thor/v2/api/admin/scheduled"
 	"github.com/vechain/thor/v2/cmd/thor/node"
+	"github.com/vechain/thor/v2/txpool"
 
 	healthAPI "github.com/vechain/thor/v2/api/admin/health"
 )
 
-func NewHTTPHandler(logLevel *slog.LevelVar, health *healthAPI.Health, apiLogsToggle *atomic.Bool, master *node.Master) http.HandlerFunc {
+func NewHTTPHandler(
+	logLevel *slog.LevelVar,
+	health *healthAPI.Health,
+	apiLogsToggle *atomic.Bool,
+	master *node.Master,
+	peerManager peers.Manager,
+	backupService backup.Service,
+	scheduler *txpool.Scheduler,
+) http.HandlerFunc {
 	router := mux.NewRouter()
 	subRouter := router.PathPrefix("/admin").Subrouter()
 
 	loglevel.New(logLevel).Mount(subRouter, "/loglevel")
 	healthAPI.NewAPI(health, master).Mount(subRouter, "/health")
 	apilogs.New(apiLogsToggle).Mount(subRouter, "/apilogs")
+	if peerManager != nil {
+		peers.New(peerManager).Mount(subRouter, "/peers")
+	}
+	if backupService != nil {
+		backup.New(backupService).Mount(subRouter, "/backup")
+	}
+	if scheduler != nil {
+		scheduled.New(scheduler).Mount(subRouter, "/scheduled")
+	}
 
 	handler := handlers.CompressHandler(router)
 	return handler.ServeHTTP
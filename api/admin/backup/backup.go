@@ -0,0 +1,87 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package backup
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+	"github.com/vechain/thor/v2/cmd/thor/backup"
+)
+
+// Service defines the backup functions used by the backup API.
+type Service interface {
+	Start(dir string, compress bool) error
+	Status() backup.Status
+}
+
+type Backup struct {
+	service Service
+}
+
+func New(service Service) *Backup {
+	return &Backup{
+		service: service,
+	}
+}
+
+func (b *Backup) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("").
+		Methods(http.MethodGet).
+		Name("get-backup-status").
+		HandlerFunc(restutil.WrapHandlerFunc(b.handleGetStatus))
+	sub.Path("").
+		Methods(http.MethodPost).
+		Name("post-backup").
+		HandlerFunc(restutil.WrapHandlerFunc(b.handleStart))
+}
+
+func (b *Backup) handleGetStatus(w http.ResponseWriter, _ *http.Request) error {
+	return restutil.WriteJSON(w, convertStatus(b.service.Status()))
+}
+
+func (b *Backup) handleStart(w http.ResponseWriter, req *http.Request) error {
+	var body api.BackupRequest
+	if err := restutil.ParseJSON(req.Body, &body); err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if body.Dir == "" {
+		return restutil.BadRequest(errors.New("dir: required"))
+	}
+	if err := b.service.Start(body.Dir, body.Compress); err != nil {
+		if err == backup.ErrRunning {
+			return restutil.HTTPError(err, http.StatusConflict)
+		}
+		return err
+	}
+	return restutil.WriteJSON(w, convertStatus(b.service.Status()))
+}
+
+func convertStatus(s backup.Status) *api.BackupStatus {
+	status := &api.BackupStatus{
+		Running: s.Running,
+		Dir:     s.Dir,
+		Stage:   s.Progress.Stage,
+		Copied:  s.Progress.Copied,
+		Total:   s.Progress.Total,
+		Error:   s.Error,
+	}
+	if m := s.Manifest; m != nil {
+		status.Manifest = &api.BackupManifest{
+			GenesisID:    m.GenesisID,
+			BestBlockID:  m.BestBlockID,
+			BestBlockNum: m.BestBlockNum,
+			Timestamp:    m.Timestamp,
+			Compressed:   m.Compressed,
+		}
+	}
+	return status
+}
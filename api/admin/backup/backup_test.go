@@ -0,0 +1,84 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/cmd/thor/backup"
+	"github.com/vechain/thor/v2/thor"
+)
+
+type mockService struct {
+	status backup.Status
+}
+
+func (m *mockService) Start(dir string, compress bool) error {
+	if m.status.Running {
+		return backup.ErrRunning
+	}
+	m.status = backup.Status{Running: true, Dir: dir, Progress: backup.Progress{Stage: backup.StageMainDB}}
+	return nil
+}
+
+func (m *mockService) Status() backup.Status {
+	return m.status
+}
+
+func TestBackup(t *testing.T) {
+	service := &mockService{}
+	router := mux.NewRouter()
+	New(service).Mount(router, "/admin/backup")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	post := func(body any) (*http.Response, []byte) {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		res, err := http.Post(ts.URL+"/admin/backup", "application/json", bytes.NewReader(data))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		buf := new(bytes.Buffer)
+		_, err = buf.ReadFrom(res.Body)
+		require.NoError(t, err)
+		return res, buf.Bytes()
+	}
+
+	res, _ := post(&api.BackupRequest{})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, data := post(&api.BackupRequest{Dir: "/backup", Compress: true})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var status api.BackupStatus
+	require.NoError(t, json.Unmarshal(data, &status))
+	assert.True(t, status.Running)
+	assert.Equal(t, "/backup", status.Dir)
+	assert.Equal(t, backup.StageMainDB, status.Stage)
+
+	res, _ = post(&api.BackupRequest{Dir: "/backup"})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	service.status = backup.Status{
+		Dir:      "/backup",
+		Manifest: &backup.Manifest{BestBlockID: thor.Bytes32{1}, BestBlockNum: 1},
+	}
+	res, err := http.Get(ts.URL + "/admin/backup")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&status))
+	assert.False(t, status.Running)
+	require.NotNil(t, status.Manifest)
+	assert.Equal(t, thor.Bytes32{1}, status.Manifest.BestBlockID)
+}
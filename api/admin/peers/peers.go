@@ -0,0 +1,192 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package peers
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/p2psrv"
+)
+
+// Manager defines the peer management functions used by the peers API.
+type Manager interface {
+	PeerRecords() []*p2psrv.PeerRecord
+	StaticNodes() p2psrv.Nodes
+	AddStatic(node *discover.Node) error
+	RemoveStatic(node *discover.Node) error
+	TrustedNodes() p2psrv.Nodes
+	AddTrusted(node *discover.Node) error
+	RemoveTrusted(node *discover.Node) error
+	Disconnect(id discover.NodeID) bool
+}
+
+type Peers struct {
+	manager Manager
+}
+
+func New(manager Manager) *Peers {
+	return &Peers{
+		manager: manager,
+	}
+}
+
+func (p *Peers) handleGetStore(w http.ResponseWriter, _ *http.Request) error {
+	records := p.manager.PeerRecords()
+	result := make([]*api.PeerRecord, 0, len(records))
+	for _, r := range records {
+		result = append(result, &api.PeerRecord{
+			Enode:       r.Node().String(),
+			LastSeen:    r.LastSeen,
+			LastDialed:  r.LastDialed,
+			Latency:     r.Latency,
+			Failures:    r.Failures,
+			Connections: r.Connections,
+		})
+	}
+	return restutil.WriteJSON(w, result)
+}
+
+func (p *Peers) handleGetStatic(w http.ResponseWriter, _ *http.Request) error {
+	return restutil.WriteJSON(w, convertNodes(p.manager.StaticNodes()))
+}
+
+func (p *Peers) handleAddStatic(w http.ResponseWriter, req *http.Request) error {
+	node, err := parseEnodeRequest(req)
+	if err != nil {
+		return err
+	}
+	if err := p.manager.AddStatic(node); err != nil {
+		return errors.WithMessage(err, "add static peer")
+	}
+	log.Info("static peer added", "pkg", "peers", "node", node)
+	return restutil.WriteJSON(w, convertNodes(p.manager.StaticNodes()))
+}
+
+func (p *Peers) handleRemoveStatic(w http.ResponseWriter, req *http.Request) error {
+	id, err := discover.HexID(mux.Vars(req)["id"])
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "id"))
+	}
+	if err := p.manager.RemoveStatic(&discover.Node{ID: id}); err != nil {
+		return errors.WithMessage(err, "remove static peer")
+	}
+	log.Info("static peer removed", "pkg", "peers", "id", id)
+	return restutil.WriteJSON(w, convertNodes(p.manager.StaticNodes()))
+}
+
+func (p *Peers) handleGetTrusted(w http.ResponseWriter, _ *http.Request) error {
+	return restutil.WriteJSON(w, convertNodes(p.manager.TrustedNodes()))
+}
+
+func (p *Peers) handleAddTrusted(w http.ResponseWriter, req *http.Request) error {
+	node, err := parseEnodeRequest(req)
+	if err != nil {
+		return err
+	}
+	if err := p.manager.AddTrusted(node); err != nil {
+		return errors.WithMessage(err, "add trusted peer")
+	}
+	log.Info("trusted peer added", "pkg", "peers", "node", node)
+	return restutil.WriteJSON(w, convertNodes(p.manager.TrustedNodes()))
+}
+
+func (p *Peers) handleRemoveTrusted(w http.ResponseWriter, req *http.Request) error {
+	id, err := discover.HexID(mux.Vars(req)["id"])
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "id"))
+	}
+	if err := p.manager.RemoveTrusted(&discover.Node{ID: id}); err != nil {
+		return errors.WithMessage(err, "remove trusted peer")
+	}
+	log.Info("trusted peer removed", "pkg", "peers", "id", id)
+	return restutil.WriteJSON(w, convertNodes(p.manager.TrustedNodes()))
+}
+
+func (p *Peers) handleDisconnect(w http.ResponseWriter, req *http.Request) error {
+	var body api.DisconnectPeerRequest
+	if err := restutil.ParseJSON(req.Body, &body); err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "body"))
+	}
+	id, err := discover.HexID(body.PeerID)
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "peerID"))
+	}
+	if !p.manager.Disconnect(id) {
+		return restutil.HTTPError(errors.New("peer not connected"), http.StatusNotFound)
+	}
+	log.Info("peer disconnected", "pkg", "peers", "id", id)
+	return restutil.WriteJSON(w, &api.DisconnectPeerRequest{PeerID: body.PeerID})
+}
+
+func (p *Peers) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/store").
+		Methods(http.MethodGet).
+		Name("get-peers-store").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleGetStore))
+
+	sub.Path("/static").
+		Methods(http.MethodGet).
+		Name("get-static-peers").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleGetStatic))
+	sub.Path("/static").
+		Methods(http.MethodPost).
+		Name("post-static-peer").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleAddStatic))
+	sub.Path("/static/{id}").
+		Methods(http.MethodDelete).
+		Name("delete-static-peer").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleRemoveStatic))
+
+	sub.Path("/trusted").
+		Methods(http.MethodGet).
+		Name("get-trusted-peers").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleGetTrusted))
+	sub.Path("/trusted").
+		Methods(http.MethodPost).
+		Name("post-trusted-peer").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleAddTrusted))
+	sub.Path("/trusted/{id}").
+		Methods(http.MethodDelete).
+		Name("delete-trusted-peer").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleRemoveTrusted))
+
+	sub.Path("/disconnect").
+		Methods(http.MethodPost).
+		Name("post-disconnect-peer").
+		HandlerFunc(restutil.WrapHandlerFunc(p.handleDisconnect))
+}
+
+func parseEnodeRequest(req *http.Request) (*discover.Node, error) {
+	var body api.EnodeRequest
+	if err := restutil.ParseJSON(req.Body, &body); err != nil {
+		return nil, restutil.BadRequest(errors.WithMessage(err, "body"))
+	}
+	node, err := discover.ParseNode(body.Enode)
+	if err != nil {
+		return nil, restutil.BadRequest(errors.WithMessage(err, "enode"))
+	}
+	if node.Incomplete() {
+		return nil, restutil.BadRequest(errors.New("enode: missing IP address"))
+	}
+	return node, nil
+}
+
+func convertNodes(nodes p2psrv.Nodes) []string {
+	enodes := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		enodes = append(enodes, node.String())
+	}
+	return enodes
+}
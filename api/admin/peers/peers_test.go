@@ -0,0 +1,185 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package peers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/p2psrv"
+)
+
+const testEnode = "enode://1234cf28ab5f0255a3923ac094d0168ce884a9fa5f3998b1844986b4a2b1eac52fcccd8f2916be9b8b0f7798147ee5592ec3c83518925fac50f812577515d6ad@10.3.58.6:30303"
+
+type mockManager struct {
+	records   []*p2psrv.PeerRecord
+	static    map[discover.NodeID]*discover.Node
+	trusted   map[discover.NodeID]*discover.Node
+	connected map[discover.NodeID]bool
+}
+
+func newMockManager() *mockManager {
+	return &mockManager{
+		static:    make(map[discover.NodeID]*discover.Node),
+		trusted:   make(map[discover.NodeID]*discover.Node),
+		connected: make(map[discover.NodeID]bool),
+	}
+}
+
+func (m *mockManager) PeerRecords() []*p2psrv.PeerRecord { return m.records }
+
+func (m *mockManager) StaticNodes() p2psrv.Nodes { return toNodes(m.static) }
+
+func (m *mockManager) AddStatic(node *discover.Node) error {
+	m.static[node.ID] = node
+	return nil
+}
+
+func (m *mockManager) RemoveStatic(node *discover.Node) error {
+	delete(m.static, node.ID)
+	return nil
+}
+
+func (m *mockManager) TrustedNodes() p2psrv.Nodes { return toNodes(m.trusted) }
+
+func (m *mockManager) AddTrusted(node *discover.Node) error {
+	m.trusted[node.ID] = node
+	return nil
+}
+
+func (m *mockManager) RemoveTrusted(node *discover.Node) error {
+	delete(m.trusted, node.ID)
+	return nil
+}
+
+func (m *mockManager) Disconnect(id discover.NodeID) bool {
+	if m.connected[id] {
+		delete(m.connected, id)
+		return true
+	}
+	return false
+}
+
+func toNodes(m map[discover.NodeID]*discover.Node) p2psrv.Nodes {
+	nodes := make(p2psrv.Nodes, 0, len(m))
+	for _, node := range m {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func serve(t *testing.T, manager Manager, method, path string, body any) *httptest.ResponseRecorder {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	router := mux.NewRouter()
+	New(manager).Mount(router, "/admin/peers")
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(reqBody))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestGetStore(t *testing.T) {
+	record := &p2psrv.PeerRecord{
+		ID:          discover.NodeID{1},
+		IP:          net.ParseIP("10.0.0.1"),
+		UDP:         11235,
+		TCP:         11235,
+		LastSeen:    100,
+		LastDialed:  90,
+		Latency:     20,
+		Failures:    1,
+		Connections: 3,
+	}
+	manager := newMockManager()
+	manager.records = []*p2psrv.PeerRecord{record}
+
+	rr := serve(t, manager, http.MethodGet, "/admin/peers/store", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var result []*api.PeerRecord
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	assert.Equal(t, []*api.PeerRecord{{
+		Enode:       record.Node().String(),
+		LastSeen:    100,
+		LastDialed:  90,
+		Latency:     20,
+		Failures:    1,
+		Connections: 3,
+	}}, result)
+}
+
+func TestGetStoreEmpty(t *testing.T) {
+	rr := serve(t, newMockManager(), http.MethodGet, "/admin/peers/store", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "[]\n", rr.Body.String())
+}
+
+func TestStaticAndTrustedPeers(t *testing.T) {
+	node := discover.MustParseNode(testEnode)
+
+	for _, class := range []string{"static", "trusted"} {
+		t.Run(class, func(t *testing.T) {
+			manager := newMockManager()
+			path := "/admin/peers/" + class
+
+			rr := serve(t, manager, http.MethodPost, path, &api.EnodeRequest{Enode: testEnode})
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var enodes []string
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &enodes))
+			assert.Equal(t, []string{node.String()}, enodes)
+
+			rr = serve(t, manager, http.MethodGet, path, nil)
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &enodes))
+			assert.Equal(t, []string{node.String()}, enodes)
+
+			rr = serve(t, manager, http.MethodDelete, path+"/"+node.ID.String(), nil)
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "[]\n", rr.Body.String())
+
+			// bad requests
+			rr = serve(t, manager, http.MethodPost, path, &api.EnodeRequest{Enode: "invalid"})
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			rr = serve(t, manager, http.MethodPost, path, &api.EnodeRequest{Enode: "enode://" + node.ID.String()})
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			rr = serve(t, manager, http.MethodDelete, path+"/invalid", nil)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	manager := newMockManager()
+	id := discover.NodeID{1}
+	manager.connected[id] = true
+
+	rr := serve(t, manager, http.MethodPost, "/admin/peers/disconnect", &api.DisconnectPeerRequest{PeerID: id.String()})
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, manager.connected[id])
+
+	rr = serve(t, manager, http.MethodPost, "/admin/peers/disconnect", &api.DisconnectPeerRequest{PeerID: id.String()})
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serve(t, manager, http.MethodPost, "/admin/peers/disconnect", &api.DisconnectPeerRequest{PeerID: "invalid"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
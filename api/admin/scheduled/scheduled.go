@@ -0,0 +1,125 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package scheduled
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+type Scheduled struct {
+	scheduler *txpool.Scheduler
+}
+
+func New(scheduler *txpool.Scheduler) *Scheduled {
+	return &Scheduled{
+		scheduler,
+	}
+}
+
+func convertScheduledTx(stx *txpool.ScheduledTx) (*api.ScheduledTx, error) {
+	raw, err := stx.Tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	origin, err := stx.Tx.Origin()
+	if err != nil {
+		return nil, err
+	}
+	return &api.ScheduledTx{
+		ID:           stx.Tx.ID(),
+		Origin:       origin,
+		RawTx:        api.RawTx{Raw: hexutil.Encode(raw)},
+		ReleaseBlock: stx.ReleaseBlock,
+		ReleaseTime:  stx.ReleaseTime,
+		TimeAdded:    stx.TimeAdded,
+		Rejection:    stx.Rejection,
+	}, nil
+}
+
+func (s *Scheduled) handleScheduleTransaction(w http.ResponseWriter, req *http.Request) error {
+	var scheduleTx *api.ScheduleTxRequest
+	if err := restutil.ParseJSON(req.Body, &scheduleTx); err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if scheduleTx == nil {
+		return restutil.BadRequest(errors.New("body"))
+	}
+	tx, err := scheduleTx.Decode()
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "raw"))
+	}
+
+	if err := s.scheduler.Schedule(tx, scheduleTx.ReleaseBlock, scheduleTx.ReleaseTime); err != nil {
+		if txpool.IsBadTx(err) {
+			return restutil.BadRequest(err)
+		}
+		if txpool.IsTxRejected(err) {
+			return restutil.Forbidden(err)
+		}
+		return err
+	}
+	txID := tx.ID()
+	return restutil.WriteJSON(w, &api.SendTxResult{ID: &txID})
+}
+
+func (s *Scheduled) handleGetScheduledTransactions(w http.ResponseWriter, _ *http.Request) error {
+	list := s.scheduler.List()
+	txs := make([]*api.ScheduledTx, 0, len(list))
+	for _, stx := range list {
+		converted, err := convertScheduledTx(stx)
+		if err != nil {
+			return err
+		}
+		txs = append(txs, converted)
+	}
+	return restutil.WriteJSON(w, txs)
+}
+
+func (s *Scheduled) handleCancelScheduledTransaction(w http.ResponseWriter, req *http.Request) error {
+	id, err := thor.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "id"))
+	}
+
+	cancelled, err := s.scheduler.Cancel(id)
+	if err != nil {
+		return err
+	}
+	if cancelled == nil {
+		return restutil.HTTPError(errors.New("scheduled tx not found"), http.StatusNotFound)
+	}
+	converted, err := convertScheduledTx(cancelled)
+	if err != nil {
+		return err
+	}
+	return restutil.WriteJSON(w, converted)
+}
+
+func (s *Scheduled) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").
+		Methods(http.MethodPost).
+		Name("post-scheduled-tx").
+		HandlerFunc(restutil.WrapHandlerFunc(s.handleScheduleTransaction))
+	sub.Path("").
+		Methods(http.MethodGet).
+		Name("get-scheduled-txs").
+		HandlerFunc(restutil.WrapHandlerFunc(s.handleGetScheduledTransactions))
+	sub.Path("/{id}").
+		Methods(http.MethodDelete).
+		Name("delete-scheduled-tx").
+		HandlerFunc(restutil.WrapHandlerFunc(s.handleCancelScheduledTransaction))
+}
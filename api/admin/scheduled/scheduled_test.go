@@ -0,0 +1,113 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package scheduled
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestScheduled(t *testing.T) {
+	thorChain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	repo := thorChain.Repo()
+	pool := txpool.New(repo, thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	}, thorChain.GetForkConfig())
+	defer pool.Close()
+
+	scheduler, err := txpool.NewScheduler(repo, pool, t.TempDir(), 16, nil)
+	require.NoError(t, err)
+	defer scheduler.Stop()
+
+	router := mux.NewRouter()
+	New(scheduler).Mount(router, "/admin/scheduled")
+	serve := func(method, path string, body any) (int, string) {
+		var reqBody []byte
+		if body != nil {
+			reqBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, path, bytes.NewReader(reqBody)))
+		return rr.Code, strings.TrimSpace(rr.Body.String())
+	}
+
+	trx := tx.MustSign(
+		tx.NewBuilder(tx.TypeLegacy).ChainTag(repo.ChainTag()).Gas(21000).Expiration(100).Nonce(1).Build(),
+		genesis.DevAccounts()[0].PrivateKey,
+	)
+	raw, err := trx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := api.RawTx{Raw: hexutil.Encode(raw)}
+
+	code, body := serve(http.MethodPost, "/admin/scheduled", &api.ScheduleTxRequest{RawTx: rawTx})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "bad tx: no release condition", body)
+
+	code, body = serve(http.MethodPost, "/admin/scheduled", &api.ScheduleTxRequest{RawTx: rawTx, ReleaseBlock: 1000})
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Equal(t, "tx rejected: expires before release", body)
+
+	code, body = serve(http.MethodPost, "/admin/scheduled", &api.ScheduleTxRequest{RawTx: api.RawTx{Raw: "0x00"}, ReleaseBlock: 10})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.True(t, strings.HasPrefix(body, "raw:"))
+
+	code, body = serve(http.MethodPost, "/admin/scheduled", &api.ScheduleTxRequest{RawTx: rawTx, ReleaseBlock: 10})
+	require.Equal(t, http.StatusOK, code)
+	var result api.SendTxResult
+	require.NoError(t, json.Unmarshal([]byte(body), &result))
+	assert.Equal(t, trx.ID(), *result.ID)
+
+	expected := api.ScheduledTx{
+		ID:           trx.ID(),
+		Origin:       genesis.DevAccounts()[0].Address,
+		RawTx:        rawTx,
+		ReleaseBlock: 10,
+		TimeAdded:    scheduler.List()[0].TimeAdded,
+	}
+	code, body = serve(http.MethodGet, "/admin/scheduled", nil)
+	require.Equal(t, http.StatusOK, code)
+	var list []api.ScheduledTx
+	require.NoError(t, json.Unmarshal([]byte(body), &list))
+	assert.Equal(t, []api.ScheduledTx{expected}, list)
+
+	code, _ = serve(http.MethodDelete, "/admin/scheduled/0x1234", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body = serve(http.MethodDelete, "/admin/scheduled/"+trx.ID().String(), nil)
+	require.Equal(t, http.StatusOK, code)
+	var cancelled api.ScheduledTx
+	require.NoError(t, json.Unmarshal([]byte(body), &cancelled))
+	assert.Equal(t, expected, cancelled)
+
+	code, body = serve(http.MethodDelete, "/admin/scheduled/"+trx.ID().String(), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "scheduled tx not found", body)
+
+	code, body = serve(http.MethodGet, "/admin/scheduled", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "[]", body)
+}
@@ -7,6 +7,8 @@ package api
 
 import (
 	"time"
+
+	"github.com/vechain/thor/v2/thor"
 )
 
 type LogStatus struct {
@@ -29,3 +31,61 @@ type LogLevelRequest struct {
 type LogLevelResponse struct {
 	CurrentLevel string `json:"currentLevel"`
 }
+
+type PeerRecord struct {
+	Enode       string `json:"enode"`
+	LastSeen    uint64 `json:"lastSeen"`
+	LastDialed  uint64 `json:"lastDialed"`
+	Latency     uint64 `json:"latency"`
+	Failures    uint32 `json:"failures"`
+	Connections uint32 `json:"connections"`
+}
+
+type EnodeRequest struct {
+	Enode string `json:"enode"`
+}
+
+type DisconnectPeerRequest struct {
+	PeerID string `json:"peerID"`
+}
+
+type BackupRequest struct {
+	Dir      string `json:"dir"`
+	Compress bool   `json:"compress"`
+}
+
+type BackupManifest struct {
+	GenesisID    thor.Bytes32 `json:"genesisID"`
+	BestBlockID  thor.Bytes32 `json:"bestBlockID"`
+	BestBlockNum uint32       `json:"bestBlockNum"`
+	Timestamp    uint64       `json:"timestamp"`
+	Compressed   bool         `json:"compressed"`
+}
+
+type BackupStatus struct {
+	Running  bool            `json:"running"`
+	Dir      string          `json:"dir"`
+	Stage    string          `json:"stage"`
+	Copied   uint64          `json:"copied"`
+	Total    uint64          `json:"total"`
+	Manifest *BackupManifest `json:"manifest"`
+	Error    string          `json:"error"`
+}
+
+// ScheduleTxRequest is the raw tx to hold until the best block reaches the release block number and timestamp.
+type ScheduleTxRequest struct {
+	RawTx
+	ReleaseBlock uint32 `json:"releaseBlock"`
+	ReleaseTime  uint64 `json:"releaseTime"`
+}
+
+// ScheduledTx is a tx held by the scheduler, either not yet released into the pool, or rejected on release.
+type ScheduledTx struct {
+	ID     thor.Bytes32 `json:"id"`
+	Origin thor.Address `json:"origin"`
+	RawTx
+	ReleaseBlock uint32 `json:"releaseBlock"`
+	ReleaseTime  uint64 `json:"releaseTime"`
+	TimeAdded    uint64 `json:"timeAdded"`
+	Rejection    string `json:"rejection,omitempty"`
+}
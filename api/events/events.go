@@ -19,13 +19,19 @@ import (
 	"github.com/vechain/thor/v2/logdb"
 )
 
+// LogDB is the source of events, either the log db or the log scanner.
+type LogDB interface {
+	FilterEvents(ctx context.Context, filter *logdb.EventFilter) ([]*logdb.Event, error)
+	OldestBlockNum() (uint32, error)
+}
+
 type Events struct {
 	repo  *chain.Repository
-	db    *logdb.LogDB
+	db    LogDB
 	limit uint64
 }
 
-func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64) *Events {
+func New(repo *chain.Repository, db LogDB, logsLimit uint64) *Events {
 	return &Events{
 		repo,
 		db,
@@ -40,6 +46,13 @@ func (e *Events) filter(ctx context.Context, ef *api.EventFilter) ([]*api.Filter
 	if err != nil {
 		return nil, err
 	}
+	oldest, err := e.db.OldestBlockNum()
+	if err != nil {
+		return nil, err
+	}
+	if err := api.CheckRangeRetained(ef.Range, filter.Range, oldest); err != nil {
+		return nil, restutil.BadRequest(err)
+	}
 	events, err := e.db.FilterEvents(ctx, filter)
 	if err != nil {
 		return nil, err
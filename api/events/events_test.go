@@ -234,6 +234,49 @@ func TestNullCriteriaSet(t *testing.T) {
 	assert.Equal(t, "criteriaSet[1]: null not allowed\n", string(res), "null criteriaSet")
 }
 
+func TestPrunedRange(t *testing.T) {
+	thorChain := initEventServer(t, defaultLogLimit)
+	defer ts.Close()
+	insertBlocks(t, thorChain, 5)
+
+	w := thorChain.LogDB().NewWriter()
+	for {
+		n, err := w.Prune(3, 100)
+		require.NoError(t, err)
+		require.NoError(t, w.Commit())
+		if n == 0 {
+			break
+		}
+	}
+
+	tclient = thorclient.New(ts.URL)
+	from := uint64(2)
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPPost("/logs/event", api.EventFilter{
+		Range: &api.Range{From: &from},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, "filter.Range.From: logs before block 3 have been pruned\n", string(res))
+
+	// from the oldest block
+	from = 3
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPPost("/logs/event", api.EventFilter{
+		Range: &api.Range{From: &from},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	var tLogs []*api.FilteredEvent
+	require.NoError(t, json.Unmarshal(res, &tLogs))
+	assert.Len(t, tLogs, 3)
+
+	// range not specified
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPPost("/logs/event", api.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	require.NoError(t, json.Unmarshal(res, &tLogs))
+	assert.Len(t, tLogs, 3)
+}
+
 // Test functions
 func testEventsBadRequest(t *testing.T) {
 	badBody := []byte{0x00, 0x01, 0x02}
@@ -159,6 +159,15 @@ func (r *Range) Validate() error {
 	return nil
 }
 
+// CheckRangeRetained returns an error if the range explicitly starts before the oldest block whose
+// logs are retained, rather than let the query return incomplete results.
+func CheckRangeRetained(r *Range, rng *logdb.Range, oldest uint32) error {
+	if oldest == 0 || r == nil || r.From == nil || rng.From >= oldest {
+		return nil
+	}
+	return fmt.Errorf("filter.Range.From: logs before block %d have been pruned", oldest)
+}
+
 var emptyRange = logdb.Range{
 	From: logdb.MaxBlockNumber,
 	To:   logdb.MaxBlockNumber,
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logs
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+)
+
+// LogDB is the source of logs, either the log db or the log scanner.
+type LogDB interface {
+	OldestBlockNum() (uint32, error)
+}
+
+type Logs struct {
+	db LogDB
+}
+
+func New(db LogDB) *Logs {
+	return &Logs{db}
+}
+
+func (l *Logs) handleGetRetention(w http.ResponseWriter, _ *http.Request) error {
+	oldest, err := l.db.OldestBlockNum()
+	if err != nil {
+		return err
+	}
+	return restutil.WriteJSON(w, &api.LogsRetention{OldestBlockNumber: oldest})
+}
+
+func (l *Logs) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/retention").
+		Methods(http.MethodGet).
+		Name("GET /logs/retention").
+		HandlerFunc(restutil.WrapHandlerFunc(l.handleGetRetention))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thorclient"
+)
+
+func TestRetention(t *testing.T) {
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	router := mux.NewRouter()
+	New(db).Mount(router, "/logs")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	tclient := thorclient.New(ts.URL)
+
+	get := func() *api.LogsRetention {
+		res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/logs/retention")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, statusCode)
+		var retention api.LogsRetention
+		require.NoError(t, json.Unmarshal(res, &retention))
+		return &retention
+	}
+
+	assert.Equal(t, uint32(0), get().OldestBlockNumber)
+
+	w := db.NewWriter()
+	_, err = w.Prune(100, 1)
+	require.NoError(t, err)
+	require.NoError(t, w.Commit())
+	assert.Equal(t, uint32(100), get().OldestBlockNumber)
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+// LogsRetention describes the range of retained logs.
+type LogsRetention struct {
+	OldestBlockNumber uint32 `json:"oldestBlockNumber"`
+}
@@ -86,6 +86,29 @@ func (n *Node) handleGetTxpoolStatus(w http.ResponseWriter, req *http.Request) e
 	return restutil.WriteJSON(w, status)
 }
 
+func (n *Node) handleGetTxDependencies(w http.ResponseWriter, req *http.Request) error {
+	id, err := thor.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "id"))
+	}
+
+	deps := n.pool.GetDependencies(id)
+	if deps == nil {
+		return restutil.WriteJSON(w, nil)
+	}
+	dependents := deps.Dependents
+	if dependents == nil {
+		dependents = []thor.Bytes32{}
+	}
+	return restutil.WriteJSON(w, &api.TxDeps{
+		ID:            id,
+		DependsOn:     deps.DependsOn,
+		DepPending:    deps.DepPending,
+		DepExecutable: deps.DepExecutable,
+		Dependents:    dependents,
+	})
+}
+
 func (n *Node) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
@@ -103,6 +126,10 @@ func (n *Node) Mount(root *mux.Router, pathPrefix string) {
 			Methods(http.MethodGet).
 			Name("GET /node/txpool/status").
 			HandlerFunc(restutil.WrapHandlerFunc(n.handleGetTxpoolStatus))
+		sub.Path("/txpool/dependencies/{id}").
+			Methods(http.MethodGet).
+			Name("GET /node/txpool/dependencies/{id}").
+			HandlerFunc(restutil.WrapHandlerFunc(n.handleGetTxDependencies))
 	}
 }
 
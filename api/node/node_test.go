@@ -7,6 +7,7 @@ package node
 import (
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/comm"
 	"github.com/vechain/thor/v2/genesis"
@@ -29,6 +31,8 @@ var (
 	ts      *httptest.Server
 	tclient *thorclient.Client
 	pool    *txpool.TxPool
+	firstTx *tx.Transaction
+	depTx   *tx.Transaction // depends on the first tx
 )
 
 func TestNode(t *testing.T) {
@@ -44,6 +48,9 @@ func TestNode(t *testing.T) {
 	t.Run("getTransactionsWithOrigin", testGetTransactionsWithOrigin)
 	t.Run("getTransactionsWithBadExpanded", testGetTransactionsWithBadExpanded)
 	t.Run("getTransactionsWithBadOrigin", testGetTransactionsWithBadOrigin)
+	t.Run("getTxDependencies", testGetTxDependencies)
+	t.Run("getTxDependenciesOfUnknownTx", testGetTxDependenciesOfUnknownTx)
+	t.Run("getTxDependenciesWithBadID", testGetTxDependenciesWithBadID)
 }
 
 func initCommServer(t *testing.T) {
@@ -69,8 +76,21 @@ func initCommServer(t *testing.T) {
 		transaction = tx.MustSign(transaction, genesis.DevAccounts()[0].PrivateKey)
 		err := pool.Add(transaction)
 		require.NoError(t, err)
+		if i == 0 {
+			firstTx = transaction
+		}
 	}
 
+	firstID := firstTx.ID()
+	depTx = new(tx.Builder).
+		ChainTag(chainTag).
+		Expiration(10).
+		Gas(21000).
+		DependsOn(&firstID).
+		Build()
+	depTx = tx.MustSign(depTx, genesis.DevAccounts()[2].PrivateKey)
+	require.NoError(t, pool.Add(depTx))
+
 	communicator := comm.New(
 		thorChain.Repo(),
 		txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
@@ -128,3 +148,24 @@ func testGetTransactionsWithBadExpanded(t *testing.T) {
 func testGetTransactionsWithBadOrigin(t *testing.T) {
 	httpGetAndCheckResponseStatus(t, "/node/txpool?origin=0xinvalid", 400)
 }
+
+func testGetTxDependencies(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/node/txpool/dependencies/"+firstTx.ID().String(), 200)
+	var deps api.TxDeps
+	require.NoError(t, json.Unmarshal(res, &deps))
+	assert.Equal(t, api.TxDeps{ID: firstTx.ID(), Dependents: []thor.Bytes32{depTx.ID()}}, deps)
+
+	res = httpGetAndCheckResponseStatus(t, "/node/txpool/dependencies/"+depTx.ID().String(), 200)
+	require.NoError(t, json.Unmarshal(res, &deps))
+	firstID := firstTx.ID()
+	assert.Equal(t, api.TxDeps{ID: depTx.ID(), DependsOn: &firstID, DepPending: true, Dependents: []thor.Bytes32{}}, deps)
+}
+
+func testGetTxDependenciesOfUnknownTx(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/node/txpool/dependencies/"+thor.Bytes32{1}.String(), 200)
+	assert.Equal(t, "null", strings.TrimSpace(string(res)))
+}
+
+func testGetTxDependenciesWithBadID(t *testing.T) {
+	httpGetAndCheckResponseStatus(t, "/node/txpool/dependencies/0x1234", 400)
+}
@@ -18,6 +18,15 @@ type Status struct {
 	Amount uint `json:"amount"`
 }
 
+// TxDeps is the place of a pending tx in the dependency graph of the pool, built by the dependsOn field of txs.
+type TxDeps struct {
+	ID            thor.Bytes32   `json:"id"`
+	DependsOn     *thor.Bytes32  `json:"dependsOn"`
+	DepPending    bool           `json:"depPending"`
+	DepExecutable bool           `json:"depExecutable"`
+	Dependents    []thor.Bytes32 `json:"dependents"`
+}
+
 type PeerStats struct {
 	Name        string       `json:"name"`
 	BestBlockID thor.Bytes32 `json:"bestBlockID"`
@@ -26,6 +35,7 @@ type PeerStats struct {
 	NetAddr     string       `json:"netAddr"`
 	Inbound     bool         `json:"inbound"`
 	Duration    uint64       `json:"duration"`
+	Score       int          `json:"score"`
 }
 
 func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
@@ -42,6 +52,7 @@ func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
 			NetAddr:     peerStats.NetAddr,
 			Inbound:     peerStats.Inbound,
 			Duration:    peerStats.Duration,
+			Score:       peerStats.Score,
 		}
 	}
 	return peersStats
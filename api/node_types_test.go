@@ -31,6 +31,7 @@ func TestConvertPeersStats(t *testing.T) {
 			NetAddr:     "netAddr1",
 			Inbound:     true,
 			Duration:    10,
+			Score:       100,
 		},
 		{
 			Name:        "peer2",
@@ -40,6 +41,7 @@ func TestConvertPeersStats(t *testing.T) {
 			NetAddr:     "netAddr2",
 			Inbound:     false,
 			Duration:    20,
+			Score:       -5,
 		},
 	}
 	expected = []*PeerStats{
@@ -51,6 +53,7 @@ func TestConvertPeersStats(t *testing.T) {
 			NetAddr:     "netAddr1",
 			Inbound:     true,
 			Duration:    10,
+			Score:       100,
 		},
 		{
 			Name:        "peer2",
@@ -60,6 +63,7 @@ func TestConvertPeersStats(t *testing.T) {
 			NetAddr:     "netAddr2",
 			Inbound:     false,
 			Duration:    20,
+			Score:       -5,
 		},
 	}
 	assert.Equal(t, expected, ConvertPeersStats(ss))
@@ -5,6 +5,7 @@
 package subscriptions
 
 import (
+	"bytes"
 	"sync"
 	"time"
 
@@ -16,16 +17,72 @@ import (
 	"github.com/vechain/thor/v2/txpool"
 )
 
+// PendingTxMessage is the full pending tx, with the state transition it's delivered for.
+type PendingTxMessage struct {
+	*transactions.Transaction
+	Status     txpool.TxStatus `json:"status"`
+	Executable *bool           `json:"executable,omitempty"` // whether it's executable, absent if dropped or packed
+	Reason     string          `json:"reason,omitempty"`     // reason of being dropped
+	ReplacedBy *thor.Bytes32   `json:"replacedBy,omitempty"` // tx replaced by, if dropped due to replacement
+	BlockID    *thor.Bytes32   `json:"blockID,omitempty"`    // block packed into
+}
+
+func newPendingTxMessage(ev *txpool.TxEvent) *PendingTxMessage {
+	return &PendingTxMessage{
+		Transaction: transactions.ConvertTransaction(ev.Tx, nil),
+		Status:      ev.Status,
+		Executable:  ev.Executable,
+		Reason:      ev.Reason,
+		ReplacedBy:  ev.ReplacedBy,
+		BlockID:     ev.BlockID,
+	}
+}
+
+// pendingTxFilter filters pending txs on the server side. Empty fields match any tx.
+type pendingTxFilter struct {
+	origin    *thor.Address
+	delegator *thor.Address
+	to        *thor.Address // to of any clause
+	selector  []byte        // prefix of the data of any clause, which is to the address above if given
+}
+
+func (f *pendingTxFilter) match(trx *tx.Transaction) bool {
+	if f.origin != nil {
+		if origin, err := trx.Origin(); err != nil || origin != *f.origin {
+			return false
+		}
+	}
+	if f.delegator != nil {
+		if delegator, err := trx.Delegator(); err != nil || delegator == nil || *delegator != *f.delegator {
+			return false
+		}
+	}
+	if f.to == nil && len(f.selector) == 0 {
+		return true
+	}
+	for _, clause := range trx.Clauses() {
+		if f.to != nil && (clause.To() == nil || *clause.To() != *f.to) {
+			continue
+		}
+		if bytes.HasPrefix(clause.Data(), f.selector) {
+			return true
+		}
+	}
+	return false
+}
+
 type pendingTx struct {
-	txPool    transactions.Pool
-	listeners map[chan *tx.Transaction]struct{}
-	mu        sync.Mutex
+	txPool         transactions.Pool
+	listeners      map[chan *tx.Transaction]struct{}
+	eventListeners map[chan *txpool.TxEvent]struct{} // to receive txs both executable and not, dropped or packed
+	mu             sync.Mutex
 }
 
 func newPendingTx(txPool transactions.Pool) *pendingTx {
 	p := &pendingTx{
-		txPool:    txPool,
-		listeners: make(map[chan *tx.Transaction]struct{}),
+		txPool:         txPool,
+		listeners:      make(map[chan *tx.Transaction]struct{}),
+		eventListeners: make(map[chan *txpool.TxEvent]struct{}),
 	}
 
 	return p
@@ -45,20 +102,53 @@ func (p *pendingTx) Unsubscribe(ch chan *tx.Transaction) {
 	delete(p.listeners, ch)
 }
 
+func (p *pendingTx) SubscribeEvents(ch chan *txpool.TxEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.eventListeners[ch] = struct{}{}
+}
+
+func (p *pendingTx) UnsubscribeEvents(ch chan *txpool.TxEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.eventListeners, ch)
+}
+
 func (p *pendingTx) DispatchLoop(done <-chan struct{}) {
 	txCh := make(chan *txpool.TxEvent)
 	sub := p.txPool.SubscribeTxEvent(txCh)
 	defer sub.Unsubscribe()
 
+	type seenEvent struct {
+		executable bool
+		time       int64
+	}
 	knownTx, _ := simplelru.NewLRU(2000, nil)
+	knownEvents, _ := simplelru.NewLRU(2000, nil)
 
 	for {
 		select {
 		case txEv := <-txCh:
-			if txEv.Executable == nil || !*txEv.Executable {
+			if txEv.Executable == nil {
+				// txs left the pool, either dropped, replaced or packed
+				if txEv.Status == txpool.TxStatusDropped || txEv.Status == txpool.TxStatusPacked {
+					p.dispatchEvent(txEv, done)
+				}
 				continue
 			}
 			now := time.Now().Unix()
+			// ignored if seen as the same executable or not within half block interval
+			if seen, ok := knownEvents.Get(txEv.Tx.ID()); !ok || seen.(seenEvent).executable != *txEv.Executable ||
+				now-seen.(seenEvent).time > int64(thor.BlockInterval()/2) {
+				knownEvents.Add(txEv.Tx.ID(), seenEvent{*txEv.Executable, now})
+				p.dispatchEvent(txEv, done)
+			}
+
+			if !*txEv.Executable {
+				continue
+			}
 			// ignored if seen within half block interval
 			if seen, ok := knownTx.Get(txEv.Tx.ID()); ok && now-seen.(int64) <= int64(thor.BlockInterval()/2) {
 				continue
@@ -85,3 +175,17 @@ func (p *pendingTx) dispatch(tx *tx.Transaction, done <-chan struct{}) {
 		}
 	}
 }
+
+func (p *pendingTx) dispatchEvent(ev *txpool.TxEvent, done <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for lsn := range p.eventListeners {
+		select {
+		case lsn <- ev:
+		case <-done:
+			return
+		default: // the same as dispatch, in a non-blocking manner
+		}
+	}
+}
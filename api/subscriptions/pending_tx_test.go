@@ -6,9 +6,11 @@
 package subscriptions
 
 import (
+	"io"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -223,6 +225,183 @@ func TestPendingTx_UnsubscribeOnWebSocketClose(t *testing.T) {
 	sub.pendingTx.mu.Unlock()
 }
 
+func TestPendingTx_SubscribeExpanded(t *testing.T) {
+	thorChain := initChain(t)
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	}, &thor.NoFork)
+
+	sub := New(thorChain.Repo(), []string{"*"}, 100, txPool, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		restutil.WrapHandlerFunc(sub.handlePendingTransactions)(w, r)
+	}))
+	defer server.Close()
+
+	for query, expected := range map[string]string{
+		"expanded=yes":          "expanded: should be boolean",
+		"origin=0x12":           "origin: invalid length",
+		"selector=0x0102030405": "selector: longer than 4 bytes",
+	} {
+		res, err := http.Get(server.URL + "/txpool?" + query)
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, expected, strings.TrimSpace(string(body)))
+	}
+
+	origin := genesis.DevAccounts()[1].Address
+	url := "ws" + server.URL[4:] + "/txpool?expanded=true&to=" + thor.BytesToAddress([]byte("to")).String() + "&origin=" + origin.String()
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer ws.Close()
+	require.Eventually(t, func() bool {
+		sub.pendingTx.mu.Lock()
+		defer sub.pendingTx.mu.Unlock()
+		return len(sub.pendingTx.eventListeners) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// filtered out by origin
+	require.NoError(t, txPool.AddLocal(createTx(thorChain.Repo(), 0, tx.TypeLegacy)))
+	trx := createTx(thorChain.Repo(), 1, tx.TypeLegacy)
+	require.NoError(t, txPool.AddLocal(trx))
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg PendingTxMessage
+	require.NoError(t, ws.ReadJSON(&msg))
+	assert.Equal(t, trx.ID(), msg.ID)
+	assert.Equal(t, origin, msg.Origin)
+	assert.Len(t, msg.Clauses, 1)
+	assert.Equal(t, txpool.TxStatusAdded, msg.Status)
+	require.NotNil(t, msg.Executable)
+	assert.True(t, *msg.Executable)
+}
+
+func TestPendingTx_SubscribeStatus(t *testing.T) {
+	thorChain := initChain(t)
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	}, &thor.NoFork)
+	defer txPool.Close()
+
+	sub := New(thorChain.Repo(), []string{"*"}, 100, txPool, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		restutil.WrapHandlerFunc(sub.handlePendingTransactions)(w, r)
+	}))
+	defer server.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+server.URL[4:]+"/txpool?expanded=true", nil)
+	require.NoError(t, err)
+	defer ws.Close()
+	require.Eventually(t, func() bool {
+		sub.pendingTx.mu.Lock()
+		defer sub.pendingTx.mu.Unlock()
+		return len(sub.pendingTx.eventListeners) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// reads messages until the one of the tx with the status
+	readStatus := func(id thor.Bytes32, status txpool.TxStatus) *PendingTxMessage {
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+		for {
+			var msg PendingTxMessage
+			require.NoError(t, ws.ReadJSON(&msg))
+			if msg.ID == id && msg.Status == status {
+				return &msg
+			}
+		}
+	}
+
+	removed := createTx(thorChain.Repo(), 0, tx.TypeLegacy)
+	require.NoError(t, txPool.AddLocal(removed))
+	readStatus(removed.ID(), txpool.TxStatusAdded)
+	txPool.Remove(removed.Hash(), removed.ID())
+	msg := readStatus(removed.ID(), txpool.TxStatusDropped)
+	assert.Equal(t, txpool.DropReasonRemoved, msg.Reason)
+	assert.Nil(t, msg.Executable)
+
+	to := thor.BytesToAddress([]byte("to"))
+	// not executable for a while, so not announced to peers and replaceable
+	blockRef := tx.NewBlockRef(thorChain.Repo().BestBlockSummary().Header.Number() + 10)
+	newLegacyTx := func(coef uint8) *tx.Transaction {
+		return tx.MustSign(
+			tx.NewBuilder(tx.TypeLegacy).
+				ChainTag(thorChain.Repo().ChainTag()).
+				BlockRef(blockRef).
+				GasPriceCoef(coef).
+				Expiration(1000).
+				Gas(21000).
+				Nonce(42).
+				Clause(tx.NewClause(&to)).
+				Build(),
+			genesis.DevAccounts()[1].PrivateKey,
+		)
+	}
+	replaced, replacement := newLegacyTx(10), newLegacyTx(100)
+	require.NoError(t, txPool.AddLocal(replaced))
+	require.NoError(t, txPool.ReplaceLocal(replacement, replaced.ID()))
+	msg = readStatus(replaced.ID(), txpool.TxStatusDropped)
+	assert.Equal(t, txpool.DropReasonReplaced, msg.Reason)
+	assert.Equal(t, replacement.ID(), *msg.ReplacedBy)
+
+	packed := createTx(thorChain.Repo(), 2, tx.TypeLegacy)
+	require.NoError(t, txPool.AddLocal(packed))
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], packed))
+	msg = readStatus(packed.ID(), txpool.TxStatusPacked)
+	assert.Equal(t, thorChain.Repo().BestBlockSummary().Header.ID(), *msg.BlockID)
+}
+
+func TestPendingTxFilter(t *testing.T) {
+	to := thor.BytesToAddress([]byte("to"))
+	other := thor.BytesToAddress([]byte("other"))
+	selector := []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	plain := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Clause(tx.NewClause(&to)).Build(), genesis.DevAccounts()[0].PrivateKey)
+	call := tx.MustSign(
+		tx.NewBuilder(tx.TypeLegacy).
+			Clause(tx.NewClause(&other)).
+			Clause(tx.NewClause(&to).WithData([]byte{0xa9, 0x05, 0x9c, 0xbb, 1, 2, 3})).
+			Build(),
+		genesis.DevAccounts()[1].PrivateKey,
+	)
+	var features tx.Features
+	features.SetDelegated(true)
+	delegated := tx.MustSignDelegated(
+		tx.NewBuilder(tx.TypeLegacy).Clause(tx.NewClause(&to)).Features(features).Build(),
+		genesis.DevAccounts()[0].PrivateKey,
+		genesis.DevAccounts()[2].PrivateKey,
+	)
+
+	tests := []struct {
+		name     string
+		filter   pendingTxFilter
+		expected []*tx.Transaction
+	}{
+		{"none", pendingTxFilter{}, []*tx.Transaction{plain, call, delegated}},
+		{"origin", pendingTxFilter{origin: &genesis.DevAccounts()[0].Address}, []*tx.Transaction{plain, delegated}},
+		{"delegator", pendingTxFilter{delegator: &genesis.DevAccounts()[2].Address}, []*tx.Transaction{delegated}},
+		{"to", pendingTxFilter{to: &other}, []*tx.Transaction{call}},
+		{"selector", pendingTxFilter{selector: selector[:2]}, []*tx.Transaction{call}},
+		{"to and selector", pendingTxFilter{to: &other, selector: selector}, nil},
+		{"to and selector of the same clause", pendingTxFilter{to: &to, selector: selector}, []*tx.Transaction{call}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var matched []*tx.Transaction
+			for _, trx := range []*tx.Transaction{plain, call, delegated} {
+				if tt.filter.match(trx) {
+					matched = append(matched, trx)
+				}
+			}
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
 func createTx(repo *chain.Repository, addressNumber uint, txType tx.Type) *tx.Transaction {
 	addr := thor.BytesToAddress([]byte("to"))
 	cla := tx.NewClause(&addr).WithValue(big.NewInt(10000))
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
@@ -23,6 +24,7 @@ import (
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
 )
 
 const txQueueSize = 20
@@ -176,10 +178,44 @@ func (s *Subscriptions) handleBeat2Reader(_ http.ResponseWriter, req *http.Reque
 	return newBeat2Reader(s.repo, position, s.beat2Cache), nil
 }
 
+func parsePendingTxFilter(req *http.Request) (*pendingTxFilter, error) {
+	var (
+		filter pendingTxFilter
+		err    error
+	)
+	if filter.origin, err = parseAddress(req.URL.Query().Get("origin")); err != nil {
+		return nil, restutil.BadRequest(errors.WithMessage(err, "origin"))
+	}
+	if filter.delegator, err = parseAddress(req.URL.Query().Get("delegator")); err != nil {
+		return nil, restutil.BadRequest(errors.WithMessage(err, "delegator"))
+	}
+	if filter.to, err = parseAddress(req.URL.Query().Get("to")); err != nil {
+		return nil, restutil.BadRequest(errors.WithMessage(err, "to"))
+	}
+	if selector := req.URL.Query().Get("selector"); selector != "" {
+		if filter.selector, err = hexutil.Decode(selector); err != nil {
+			return nil, restutil.BadRequest(errors.WithMessage(err, "selector"))
+		}
+		if len(filter.selector) > 4 {
+			return nil, restutil.BadRequest(errors.New("selector: longer than 4 bytes"))
+		}
+	}
+	return &filter, nil
+}
+
 func (s *Subscriptions) handlePendingTransactions(w http.ResponseWriter, req *http.Request) error {
 	s.wg.Add(1)
 	defer s.wg.Done()
 
+	expanded, err := restutil.StringToBoolean(req.URL.Query().Get("expanded"), false)
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "expanded"))
+	}
+	filter, err := parsePendingTxFilter(req)
+	if err != nil {
+		return err
+	}
+
 	conn, closed, err := s.setupConn(w, req)
 	// since the conn is hijacked here, no error should be returned in lines below
 	if err != nil {
@@ -192,20 +228,46 @@ func (s *Subscriptions) handlePendingTransactions(w http.ResponseWriter, req *ht
 	pingTicker := time.NewTicker(pingPeriod)
 	defer pingTicker.Stop()
 
-	txCh := make(chan *tx.Transaction, txQueueSize)
-	s.pendingTx.Subscribe(txCh)
-	defer func() {
-		s.pendingTx.Unsubscribe(txCh)
-		close(txCh)
-	}()
+	// either of the channels is subscribed, the other one is nil and never ready
+	var (
+		txCh chan *tx.Transaction
+		evCh chan *txpool.TxEvent
+	)
+	if expanded {
+		// full txs are delivered executable or not, and once dropped or packed
+		evCh = make(chan *txpool.TxEvent, txQueueSize)
+		s.pendingTx.SubscribeEvents(evCh)
+		defer func() {
+			s.pendingTx.UnsubscribeEvents(evCh)
+			close(evCh)
+		}()
+	} else {
+		txCh = make(chan *tx.Transaction, txQueueSize)
+		s.pendingTx.Subscribe(txCh)
+		defer func() {
+			s.pendingTx.Unsubscribe(txCh)
+			close(txCh)
+		}()
+	}
 
 	for {
 		select {
 		case tx := <-txCh:
+			if !filter.match(tx) {
+				continue
+			}
 			if err = conn.WriteJSON(&api.PendingTxIDMessage{ID: tx.ID()}); err != nil {
 				// likely conn has failed
 				return nil
 			}
+		case ev := <-evCh:
+			if !filter.match(ev.Tx) {
+				continue
+			}
+			if err = conn.WriteJSON(newPendingTxMessage(ev)); err != nil {
+				// likely conn has failed
+				return nil
+			}
 		case <-s.done:
 			return nil
 		case <-closed:
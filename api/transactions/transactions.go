@@ -6,6 +6,7 @@
 package transactions
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -24,9 +25,14 @@ import (
 type Pool interface {
 	Get(txID thor.Bytes32) *tx.Transaction
 	AddLocal(tx *tx.Transaction) error
+	ReplaceLocal(tx *tx.Transaction, replaces thor.Bytes32) error
 	Dump() tx.Transactions
 	Len() int
 	SubscribeTxEvent(chan *txpool.TxEvent) event.Subscription
+	GetStatus(txID thor.Bytes32) []txpool.TxTransition
+	AddBundle(txs tx.Transactions) (thor.Bytes32, error)
+	GetBundleStatus(id thor.Bytes32) []txpool.TxTransition
+	GetDependencies(txID thor.Bytes32) *txpool.TxDeps
 }
 
 type Transactions struct {
@@ -126,20 +132,74 @@ func (t *Transactions) getTransactionReceiptByID(txID thor.Bytes32, head thor.By
 	return api.ConvertReceipt(receipt, header, tx)
 }
 
+// getTransactionStatus returns the status of tx recorded by the pool, with the status of being packed into the
+// best chain, which is more reliable than the pool's record.
+func convertTransitions(poolTransitions []txpool.TxTransition) []*api.TxStatusTransition {
+	var transitions []*api.TxStatusTransition
+	for _, tr := range poolTransitions {
+		transitions = append(transitions, &api.TxStatusTransition{
+			Status:     string(tr.Status),
+			Reason:     tr.Reason,
+			ReplacedBy: tr.ReplacedBy,
+			BlockID:    tr.BlockID,
+			Timestamp:  tr.Timestamp,
+		})
+	}
+	return transitions
+}
+
+func (t *Transactions) getTransactionStatus(txID thor.Bytes32) (*api.TxStatus, error) {
+	transitions := convertTransitions(t.pool.GetStatus(txID))
+
+	chain := t.repo.NewBestChain()
+	meta, err := chain.GetTransactionMeta(txID)
+	if err != nil {
+		if !t.repo.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		header, err := chain.GetBlockHeader(meta.BlockNum)
+		if err != nil {
+			return nil, err
+		}
+		blockID := header.ID()
+		if n := len(transitions); n == 0 || transitions[n-1].BlockID == nil || *transitions[n-1].BlockID != blockID {
+			transitions = append(transitions, &api.TxStatusTransition{
+				Status:    string(txpool.TxStatusPacked),
+				BlockID:   &blockID,
+				Timestamp: int64(header.Timestamp()),
+			})
+		}
+	}
+
+	if len(transitions) == 0 {
+		return nil, nil
+	}
+	return &api.TxStatus{
+		TxStatusTransition: transitions[len(transitions)-1],
+		Transitions:        transitions,
+	}, nil
+}
+
 func (t *Transactions) handleSendTransaction(w http.ResponseWriter, req *http.Request) error {
-	var rawTx *api.RawTx
-	if err := restutil.ParseJSON(req.Body, &rawTx); err != nil {
+	var sendTx *api.SendTxRequest
+	if err := restutil.ParseJSON(req.Body, &sendTx); err != nil {
 		return restutil.BadRequest(errors.WithMessage(err, "body"))
 	}
-	if rawTx == nil {
+	if sendTx == nil {
 		return restutil.BadRequest(errors.New("body"))
 	}
-	tx, err := rawTx.Decode()
+	tx, err := sendTx.Decode()
 	if err != nil {
 		return restutil.BadRequest(errors.WithMessage(err, "raw"))
 	}
 
-	if err := t.pool.AddLocal(tx); err != nil {
+	if sendTx.Replaces != nil {
+		err = t.pool.ReplaceLocal(tx, *sendTx.Replaces)
+	} else {
+		err = t.pool.AddLocal(tx)
+	}
+	if err != nil {
 		if txpool.IsBadTx(err) {
 			return restutil.BadRequest(err)
 		}
@@ -152,6 +212,58 @@ func (t *Transactions) handleSendTransaction(w http.ResponseWriter, req *http.Re
 	return restutil.WriteJSON(w, &api.SendTxResult{ID: &txID})
 }
 
+func (t *Transactions) handleSendBundle(w http.ResponseWriter, req *http.Request) error {
+	var sendBundle *api.SendBundleRequest
+	if err := restutil.ParseJSON(req.Body, &sendBundle); err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if sendBundle == nil {
+		return restutil.BadRequest(errors.New("body"))
+	}
+
+	txs := make(tx.Transactions, 0, len(sendBundle.Txs))
+	txIDs := make([]thor.Bytes32, 0, len(sendBundle.Txs))
+	for i, rawTx := range sendBundle.Txs {
+		if rawTx == nil {
+			return restutil.BadRequest(fmt.Errorf("txs[%d]: null", i))
+		}
+		trx, err := rawTx.Decode()
+		if err != nil {
+			return restutil.BadRequest(errors.WithMessage(err, fmt.Sprintf("txs[%d]: raw", i)))
+		}
+		txs = append(txs, trx)
+		txIDs = append(txIDs, trx.ID())
+	}
+
+	id, err := t.pool.AddBundle(txs)
+	if err != nil {
+		if txpool.IsBadTx(err) {
+			return restutil.BadRequest(err)
+		}
+		if txpool.IsTxRejected(err) {
+			return restutil.Forbidden(err)
+		}
+		return err
+	}
+	return restutil.WriteJSON(w, &api.SendBundleResult{ID: &id, Txs: txIDs})
+}
+
+func (t *Transactions) handleGetBundleStatusByID(w http.ResponseWriter, req *http.Request) error {
+	id, err := thor.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "id"))
+	}
+
+	transitions := convertTransitions(t.pool.GetBundleStatus(id))
+	if len(transitions) == 0 {
+		return restutil.WriteJSON(w, nil)
+	}
+	return restutil.WriteJSON(w, &api.TxStatus{
+		TxStatusTransition: transitions[len(transitions)-1],
+		Transitions:        transitions,
+	})
+}
+
 func (t *Transactions) handleGetTransactionByID(w http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["id"]
 	txID, err := thor.ParseBytes32(id)
@@ -217,6 +329,19 @@ func (t *Transactions) handleGetTransactionReceiptByID(w http.ResponseWriter, re
 	return restutil.WriteJSON(w, receipt)
 }
 
+func (t *Transactions) handleGetTransactionStatusByID(w http.ResponseWriter, req *http.Request) error {
+	txID, err := thor.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "id"))
+	}
+
+	status, err := t.getTransactionStatus(txID)
+	if err != nil {
+		return err
+	}
+	return restutil.WriteJSON(w, status)
+}
+
 func (t *Transactions) parseHead(head string) (thor.Bytes32, error) {
 	if head == "" {
 		return t.repo.BestBlockSummary().Header.ID(), nil
@@ -235,6 +360,14 @@ func (t *Transactions) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodPost).
 		Name("POST /transactions").
 		HandlerFunc(restutil.WrapHandlerFunc(t.handleSendTransaction))
+	sub.Path("/bundles").
+		Methods(http.MethodPost).
+		Name("POST /transactions/bundles").
+		HandlerFunc(restutil.WrapHandlerFunc(t.handleSendBundle))
+	sub.Path("/bundles/{id}").
+		Methods(http.MethodGet).
+		Name("GET /transactions/bundles/{id}").
+		HandlerFunc(restutil.WrapHandlerFunc(t.handleGetBundleStatusByID))
 	sub.Path("/{id}").
 		Methods(http.MethodGet).
 		Name("GET /transactions/{id}").
@@ -243,4 +376,8 @@ func (t *Transactions) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodGet).
 		Name("GET /transactions/{id}/receipt").
 		HandlerFunc(restutil.WrapHandlerFunc(t.handleGetTransactionReceiptByID))
+	sub.Path("/{id}/status").
+		Methods(http.MethodGet).
+		Name("GET /transactions/{id}/status").
+		HandlerFunc(restutil.WrapHandlerFunc(t.handleGetTransactionStatusByID))
 }
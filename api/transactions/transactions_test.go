@@ -54,6 +54,7 @@ func TestTransaction(t *testing.T) {
 		"sendTxThatCannotBeAcceptedInLocalMempool": sendTxThatCannotBeAcceptedInLocalMempool,
 		"sendDynamicFeeTx":                         sendDynamicFeeTx,
 		"sendNullTx":                               sendNullTx,
+		"sendReplacementTx":                        sendReplacementTx,
 	} {
 		t.Run(name, tt)
 	}
@@ -84,6 +85,27 @@ func TestTransaction(t *testing.T) {
 	} {
 		t.Run(name, tt)
 	}
+
+	// Get tx status
+	for name, tt := range map[string]func(*testing.T){
+		"getPackedTxStatus":    getPackedTxStatus,
+		"getPendingTxStatus":   getPendingTxStatus,
+		"getUnknownTxStatus":   getUnknownTxStatus,
+		"getTxStatusWithBadID": getTxStatusWithBadID,
+	} {
+		t.Run(name, tt)
+	}
+
+	// Send bundle and get bundle status
+	for name, tt := range map[string]func(*testing.T){
+		"sendBundle":               sendBundle,
+		"sendBundleWithBadFormat":  sendBundleWithBadFormat,
+		"sendBundleWithBadMember":  sendBundleWithBadMember,
+		"getUnknownBundleStatus":   getUnknownBundleStatus,
+		"getBundleStatusWithBadID": getBundleStatusWithBadID,
+	} {
+		t.Run(name, tt)
+	}
 }
 
 func getLegacyTx(t *testing.T) {
@@ -143,6 +165,42 @@ func getTxReceipt(t *testing.T) {
 	assert.Equal(t, receipt.Type, dynFeeTx.Type())
 }
 
+func getPackedTxStatus(t *testing.T) {
+	r := httpGetAndCheckResponseStatus(t, "/transactions/"+legacyTx.ID().String()+"/status", 200)
+	var status *api.TxStatus
+	require.NoError(t, json.Unmarshal(r, &status))
+
+	meta, err := thorChain.Repo().NewBestChain().GetTransactionMeta(legacyTx.ID())
+	require.NoError(t, err)
+	header, err := thorChain.Repo().NewBestChain().GetBlockHeader(meta.BlockNum)
+	require.NoError(t, err)
+
+	require.Len(t, status.Transitions, 1)
+	assert.Equal(t, string(txpool.TxStatusPacked), status.Status)
+	assert.Equal(t, header.ID(), *status.BlockID)
+	assert.Equal(t, int64(header.Timestamp()), status.Timestamp)
+}
+
+func getPendingTxStatus(t *testing.T) {
+	r := httpGetAndCheckResponseStatus(t, "/transactions/"+mempoolTx.ID().String()+"/status", 200)
+	var status *api.TxStatus
+	require.NoError(t, json.Unmarshal(r, &status))
+
+	require.Len(t, status.Transitions, 2)
+	assert.Equal(t, string(txpool.TxStatusAdded), status.Transitions[0].Status)
+	assert.Equal(t, string(txpool.TxStatusExecutable), status.Status)
+	assert.Nil(t, status.BlockID)
+}
+
+func getUnknownTxStatus(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/transactions/"+thor.Bytes32{}.String()+"/status", 200)
+	assert.Equal(t, "null\n", string(res))
+}
+
+func getTxStatusWithBadID(t *testing.T) {
+	httpGetAndCheckResponseStatus(t, "/transactions/0x123/status", 400)
+}
+
 func sendLegacyTx(t *testing.T) {
 	blockRef := tx.NewBlockRef(0)
 	expiration := uint32(10)
@@ -172,6 +230,122 @@ func sendLegacyTx(t *testing.T) {
 	assert.Equal(t, trx.ID().String(), txObj["id"], "should be the same transaction id")
 }
 
+func sendReplacementTx(t *testing.T) {
+	best := thorChain.Repo().BestBlockSummary().Header.Number()
+	newTx := func(blockRef uint32, nonce uint64, priorityFee, maxFee int64) *tx.Transaction {
+		trx := tx.NewBuilder(tx.TypeDynamicFee).
+			ChainTag(chainTag).
+			BlockRef(tx.NewBlockRef(blockRef)).
+			Expiration(10).
+			Gas(21000).
+			MaxFeePerGas(big.NewInt(maxFee)).
+			MaxPriorityFeePerGas(big.NewInt(priorityFee)).
+			Nonce(nonce).
+			Build()
+		return tx.MustSign(trx, genesis.DevAccounts()[1].PrivateKey)
+	}
+	send := func(trx *tx.Transaction, replaces *thor.Bytes32, status int) []byte {
+		rlpTx, err := trx.MarshalBinary()
+		require.NoError(t, err)
+		return httpPostAndCheckResponseStatus(t, "/transactions", api.SendTxRequest{RawTx: api.RawTx{Raw: hexutil.Encode(rlpTx)}, Replaces: replaces}, status)
+	}
+
+	// not executable for a while, so not announced to peers
+	pending := newTx(best+10, 100, 10, thor.InitialBaseFee*10)
+	send(pending, nil, 200)
+
+	unknown := thor.Bytes32{1}
+	res := send(newTx(best+10, 101, 20, thor.InitialBaseFee*11), &unknown, 403)
+	assert.Contains(t, string(res), "tx to replace not found")
+
+	replacement := newTx(best+10, 101, 20, thor.InitialBaseFee*11)
+	pendingID := pending.ID()
+	send(replacement, &pendingID, 200)
+
+	r := httpGetAndCheckResponseStatus(t, "/transactions/"+pending.ID().String()+"/status", 200)
+	var status *api.TxStatus
+	require.NoError(t, json.Unmarshal(r, &status))
+	assert.Equal(t, string(txpool.TxStatusDropped), status.Status)
+	assert.Equal(t, txpool.DropReasonReplaced, status.Reason)
+	assert.Equal(t, replacement.ID(), *status.ReplacedBy)
+
+	// executable ones are announced to peers at once
+	announced := newTx(best, 102, 10, thor.InitialBaseFee*10)
+	send(announced, nil, 200)
+	announcedID := announced.ID()
+	res = send(newTx(best, 103, 20, thor.InitialBaseFee*11), &announcedID, 403)
+	assert.Contains(t, string(res), "tx to replace already announced to peers")
+}
+
+func newBundleMember(t *testing.T, from genesis.DevAccount, chainTag byte) *api.RawTx {
+	trx := tx.NewBuilder(tx.TypeDynamicFee).
+		ChainTag(chainTag).
+		Expiration(10).
+		Gas(21000).
+		MaxFeePerGas(big.NewInt(thor.InitialBaseFee * 10)).
+		MaxPriorityFeePerGas(big.NewInt(10)).
+		Build()
+	raw, err := tx.MustSign(trx, from.PrivateKey).MarshalBinary()
+	require.NoError(t, err)
+	return &api.RawTx{Raw: hexutil.Encode(raw)}
+}
+
+func sendBundle(t *testing.T) {
+	req := api.SendBundleRequest{Txs: []*api.RawTx{
+		newBundleMember(t, genesis.DevAccounts()[2], chainTag),
+		newBundleMember(t, genesis.DevAccounts()[3], chainTag),
+	}}
+	res := httpPostAndCheckResponseStatus(t, "/transactions/bundles", req, 200)
+	var result api.SendBundleResult
+	require.NoError(t, json.Unmarshal(res, &result))
+
+	txs := make(tx.Transactions, 0, len(req.Txs))
+	for _, raw := range req.Txs {
+		trx, err := raw.Decode()
+		require.NoError(t, err)
+		txs = append(txs, trx)
+	}
+	assert.Equal(t, txpool.BundleID(txs), *result.ID)
+	assert.Equal(t, []thor.Bytes32{txs[0].ID(), txs[1].ID()}, result.Txs)
+
+	r := httpGetAndCheckResponseStatus(t, "/transactions/bundles/"+result.ID.String(), 200)
+	var status *api.TxStatus
+	require.NoError(t, json.Unmarshal(r, &status))
+	require.Len(t, status.Transitions, 1)
+	assert.Equal(t, string(txpool.TxStatusAdded), status.Status)
+
+	// members are pending in the bundle only
+	res = httpGetAndCheckResponseStatus(t, "/transactions/"+txs[0].ID().String()+"?pending=true", 200)
+	assert.Equal(t, "null\n", string(res))
+}
+
+func sendBundleWithBadFormat(t *testing.T) {
+	httpPostAndCheckResponseStatus(t, "/transactions/bundles", nil, 400)
+	httpPostAndCheckResponseStatus(t, "/transactions/bundles", api.SendBundleRequest{Txs: []*api.RawTx{{Raw: "0x123"}}}, 400)
+	httpPostAndCheckResponseStatus(t, "/transactions/bundles", api.SendBundleRequest{Txs: []*api.RawTx{nil}}, 400)
+}
+
+func sendBundleWithBadMember(t *testing.T) {
+	res := httpPostAndCheckResponseStatus(t, "/transactions/bundles", api.SendBundleRequest{}, 400)
+	assert.Equal(t, "bad tx: empty bundle\n", string(res))
+
+	req := api.SendBundleRequest{Txs: []*api.RawTx{
+		newBundleMember(t, genesis.DevAccounts()[4], chainTag),
+		newBundleMember(t, genesis.DevAccounts()[5], chainTag+1),
+	}}
+	res = httpPostAndCheckResponseStatus(t, "/transactions/bundles", req, 400)
+	assert.Equal(t, "bad tx: bundle member 1: chain tag mismatch\n", string(res))
+}
+
+func getUnknownBundleStatus(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/transactions/bundles/"+thor.Bytes32{}.String(), 200)
+	assert.Equal(t, "null\n", string(res))
+}
+
+func getBundleStatusWithBadID(t *testing.T) {
+	httpGetAndCheckResponseStatus(t, "/transactions/bundles/0x123", 400)
+}
+
 func sendNullTx(t *testing.T) {
 	httpPostAndCheckResponseStatus(t, "/transactions", nil, 400)
 }
@@ -31,6 +31,12 @@ func (rtx *RawTx) Decode() (*tx.Transaction, error) {
 	return tx, nil
 }
 
+// SendTxRequest is the raw tx to send, which may explicitly replace a pending tx of the same origin.
+type SendTxRequest struct {
+	RawTx
+	Replaces *thor.Bytes32 `json:"replaces,omitempty"`
+}
+
 type RawTransaction struct {
 	RawTx
 	Meta *TxMeta `json:"meta"`
@@ -42,6 +48,21 @@ type TxMeta struct {
 	BlockTimestamp uint64       `json:"blockTimestamp"`
 }
 
+// TxStatusTransition is a state transition of tx through the tx pool.
+type TxStatusTransition struct {
+	Status     string        `json:"status"`
+	Reason     string        `json:"reason,omitempty"`
+	ReplacedBy *thor.Bytes32 `json:"replacedBy,omitempty"`
+	BlockID    *thor.Bytes32 `json:"blockID,omitempty"`
+	Timestamp  int64         `json:"timestamp"`
+}
+
+// TxStatus is the lifecycle status of tx, with the latest transition flattened.
+type TxStatus struct {
+	*TxStatusTransition
+	Transitions []*TxStatusTransition `json:"transitions"`
+}
+
 type ReceiptMeta struct {
 	BlockID        thor.Bytes32 `json:"blockID"`
 	BlockNumber    uint32       `json:"blockNumber"`
@@ -132,3 +153,14 @@ func ConvertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transact
 type SendTxResult struct {
 	ID *thor.Bytes32 `json:"id"`
 }
+
+// SendBundleRequest is the raw txs to send as a bundle, which are packed into the same block in order, or not at all.
+type SendBundleRequest struct {
+	Txs []*RawTx `json:"txs"`
+}
+
+// SendBundleResult is the response to the Send Bundle method
+type SendBundleResult struct {
+	ID  *thor.Bytes32  `json:"id"`
+	Txs []thor.Bytes32 `json:"txs"`
+}
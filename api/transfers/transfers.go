@@ -19,13 +19,19 @@ import (
 	"github.com/vechain/thor/v2/logdb"
 )
 
+// LogDB is the source of transfers, either the log db or the log scanner.
+type LogDB interface {
+	FilterTransfers(ctx context.Context, filter *logdb.TransferFilter) ([]*logdb.Transfer, error)
+	OldestBlockNum() (uint32, error)
+}
+
 type Transfers struct {
 	repo  *chain.Repository
-	db    *logdb.LogDB
+	db    LogDB
 	limit uint64
 }
 
-func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64) *Transfers {
+func New(repo *chain.Repository, db LogDB, logsLimit uint64) *Transfers {
 	return &Transfers{
 		repo,
 		db,
@@ -39,6 +45,13 @@ func (t *Transfers) filter(ctx context.Context, filter *api.TransferFilter) ([]*
 	if err != nil {
 		return nil, err
 	}
+	oldest, err := t.db.OldestBlockNum()
+	if err != nil {
+		return nil, err
+	}
+	if err := api.CheckRangeRetained(filter.Range, rng, oldest); err != nil {
+		return nil, restutil.BadRequest(err)
+	}
 
 	transfers, err := t.db.FilterTransfers(ctx, &logdb.TransferFilter{
 		CriteriaSet: filter.CriteriaSet,
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package witnesses
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/witness"
+)
+
+type Witnesses struct {
+	repo  *chain.Repository
+	bft   bft.Committer
+	store *witness.Store
+}
+
+func New(repo *chain.Repository, bft bft.Committer, store *witness.Store) *Witnesses {
+	return &Witnesses{
+		repo,
+		bft,
+		store,
+	}
+}
+
+func (ws *Witnesses) handleGetWitness(w http.ResponseWriter, req *http.Request) error {
+	revision, err := restutil.ParseRevision(mux.Vars(req)["revision"], false)
+	if err != nil {
+		return restutil.BadRequest(errors.WithMessage(err, "revision"))
+	}
+
+	summary, err := restutil.GetSummary(revision, ws.repo, ws.bft)
+	if err != nil {
+		if ws.repo.IsNotFound(err) {
+			return restutil.WriteJSON(w, nil)
+		}
+		return err
+	}
+	if summary.Header.Number() == 0 {
+		return restutil.BadRequest(errors.New("revision: genesis block has no witness"))
+	}
+
+	raw, err := ws.store.GetRaw(summary.Header.ID())
+	if err != nil {
+		if ws.store.IsNotFound(err) {
+			return restutil.HTTPError(errors.New("witness not recorded"), http.StatusNotFound)
+		}
+		return err
+	}
+	return restutil.WriteJSON(w, &api.JSONRawWitness{
+		Raw: fmt.Sprintf("0x%s", hex.EncodeToString(raw)),
+	})
+}
+
+func (ws *Witnesses) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/{revision}").
+		Methods(http.MethodGet).
+		Name("GET /witnesses/{revision}").
+		HandlerFunc(restutil.WrapHandlerFunc(ws.handleGetWitness))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package witnesses
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/witness"
+)
+
+func TestWitnesses(t *testing.T) {
+	thorChain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	to := thor.BytesToAddress([]byte("to"))
+	require.NoError(t, thorChain.MintClauses(genesis.DevAccounts()[0], []*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}))
+
+	repo := thorChain.Repo()
+	store := witness.NewStore(thorChain.Database(), thorChain.Stater(), repo, thorChain.GetForkConfig())
+	router := mux.NewRouter()
+	New(repo, thorChain.Engine(), store).Mount(router, "/witnesses")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	client := thorclient.New(ts.URL).RawHTTPClient()
+
+	// not recorded on demand
+	res, statusCode, err := client.RawHTTPGet("/witnesses/best")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Equal(t, "witness not recorded", strings.TrimSpace(string(res)))
+
+	require.NoError(t, store.Save(repo.BestBlockSummary().Header.ID()))
+	res, statusCode, err = client.RawHTTPGet("/witnesses/best")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+
+	var raw api.JSONRawWitness
+	require.NoError(t, json.Unmarshal(res, &raw))
+	data, err := hexutil.Decode(raw.Raw)
+	require.NoError(t, err)
+	w, err := witness.Decode(data)
+	require.NoError(t, err)
+
+	best := repo.BestBlockSummary()
+	blk, err := repo.GetBlock(best.Header.ID())
+	require.NoError(t, err)
+	parent, err := repo.GetBlockSummary(best.Header.ParentID())
+	require.NoError(t, err)
+	_, err = witness.Execute(repo, thorChain.GetForkConfig(), parent, blk, best.Conflicts, w)
+	assert.NoError(t, err)
+
+	res, statusCode, err = client.RawHTTPGet("/witnesses/0")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, "revision: genesis block has no witness", strings.TrimSpace(string(res)))
+
+	res, statusCode, err = client.RawHTTPGet("/witnesses/100")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "null", strings.TrimSpace(string(res)))
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain/freezer"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	frozenTxsTable      = "txs"
+	frozenReceiptsTable = "receipts"
+
+	freezeBatchSize = 1024
+)
+
+// frozenKey is the key of the count of blocks migrated into the freezer.
+var frozenKey = []byte("frozen")
+
+// frozenBlock is the freezer item of txs or receipts of a block.
+type frozenBlock struct {
+	Conflicts uint32
+	Items     []rlp.RawValue
+}
+
+type frozenCacheKey struct {
+	table string
+	num   uint32
+}
+
+// OpenFreezer opens the freezer in dir, to store ancient blocks of the repository.
+func OpenFreezer(dir string) (*freezer.Freezer, error) {
+	return freezer.Open(dir, frozenTxsTable, frozenReceiptsTable)
+}
+
+// OpenFreezerReadOnly opens the freezer in dir for reading only, which is owned by another process.
+func OpenFreezerReadOnly(dir string) (*freezer.Freezer, error) {
+	return freezer.OpenReadOnly(dir, frozenTxsTable, frozenReceiptsTable)
+}
+
+// AttachFreezer makes the repository read ancient blocks from the freezer, and enables Freeze.
+// It should be called before the repository is used.
+func (r *Repository) AttachFreezer(f *freezer.Freezer) error {
+	migrated, err := r.loadMigrated()
+	if err != nil {
+		return err
+	}
+
+	if frozen := f.Frozen(); frozen < migrated {
+		return errors.Errorf("freezer missing blocks, frozen %v, migrated %v", frozen, migrated)
+	} else if frozen > migrated {
+		// the migration was interrupted, blocks beyond are still in the kv store
+		if err := f.Truncate(migrated); err != nil {
+			return err
+		}
+	}
+	r.freezer = f
+	return nil
+}
+
+// loadMigrated returns the count of blocks migrated into the freezer.
+func (r *Repository) loadMigrated() (uint32, error) {
+	val, err := r.propStore.Get(frozenKey)
+	if err != nil {
+		if r.propStore.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(val), nil
+}
+
+// Frozen returns the count of blocks migrated into the freezer.
+func (r *Repository) Frozen() uint32 {
+	if r.freezer == nil {
+		return 0
+	}
+	return r.freezer.Frozen()
+}
+
+// Freeze migrates txs, receipts and tx metadata of blocks on the chain of headID, with number
+// below limit, from the kv store into the freezer. It does nothing if no freezer attached.
+//
+// Blocks being frozen must be final, since forked blocks below are no longer readable.
+func (r *Repository) Freeze(ctx context.Context, headID thor.Bytes32, limit uint32) error {
+	if r.freezer == nil {
+		return nil
+	}
+	limit = min(limit, block.Number(headID)+1)
+	chain := r.NewChain(headID)
+
+	for next := r.freezer.Frozen(); next < limit; {
+		end := min(limit, next+freezeBatchSize)
+		if err := r.freezeBatch(ctx, chain, next, end); err != nil {
+			// keep the freezer consistent with the kv store
+			if terr := r.freezer.Truncate(next); terr != nil {
+				return terr
+			}
+			return err
+		}
+		next = end
+	}
+	return nil
+}
+
+// freezeBatch migrates blocks in the range [from, to).
+func (r *Repository) freezeBatch(ctx context.Context, chain *Chain, from, to uint32) error {
+	var (
+		bulk          = r.db.NewStore("").Bulk()
+		bodyPutter    = kv.Bucket(bodyStoreName).NewPutter(bulk)
+		propPutter    = kv.Bucket(propStoreName).NewPutter(bulk)
+		txIndexPutter = kv.Bucket(txIndexStoreName).NewPutter(bulk)
+		keyBuf        []byte
+	)
+
+	for num := from; num < to; num++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		summary, err := chain.GetBlockSummary(num)
+		if err != nil {
+			return err
+		}
+
+		txs := frozenBlock{Conflicts: summary.Conflicts}
+		receipts := frozenBlock{Conflicts: summary.Conflicts}
+		for i, txID := range summary.Txs {
+			for _, flag := range []byte{txFlag, receiptFlag} {
+				keyBuf = appendTxKey(keyBuf[:0], num, summary.Conflicts, uint64(i), flag)
+				data, err := r.bodyStore.Get(keyBuf)
+				if err != nil {
+					return errors.Wrapf(err, "load body of block %v", num)
+				}
+				if flag == txFlag {
+					txs.Items = append(txs.Items, data)
+				} else {
+					receipts.Items = append(receipts.Items, data)
+				}
+				if err := bodyPutter.Delete(keyBuf); err != nil {
+					return err
+				}
+			}
+
+			// the metadata is resolved from frozen receipts, only the locator is kept
+			keyBuf = append(keyBuf[:0], txID[:]...)
+			keyBuf = binary.AppendUvarint(keyBuf, uint64(num))
+			keyBuf = binary.AppendUvarint(keyBuf, uint64(summary.Conflicts))
+			if err := txIndexPutter.Put(keyBuf, nil); err != nil {
+				return err
+			}
+		}
+
+		txsData, err := rlp.EncodeToBytes(&txs)
+		if err != nil {
+			return err
+		}
+		receiptsData, err := rlp.EncodeToBytes(&receipts)
+		if err != nil {
+			return err
+		}
+		if err := r.freezer.Append(num, map[string][]byte{
+			frozenTxsTable:      txsData,
+			frozenReceiptsTable: receiptsData,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// data must be durable in the freezer before removed from the kv store
+	if err := r.freezer.Sync(); err != nil {
+		return err
+	}
+	if err := propPutter.Put(frozenKey, binary.BigEndian.AppendUint32(nil, to)); err != nil {
+		return err
+	}
+	return bulk.Write()
+}
+
+// loadFrozenRLP decodes the tx or receipt of the given key from the freezer.
+// It returns false if not frozen.
+func (r *Repository) loadFrozenRLP(key []byte, val any) (bool, error) {
+	if r.freezer == nil {
+		return false, nil
+	}
+	num, conflicts, index, flag, ok := parseTxKey(key)
+	if !ok {
+		return false, nil
+	}
+
+	table := frozenTxsTable
+	if flag == receiptFlag {
+		table = frozenReceiptsTable
+	}
+	fb, _, err := r.caches.frozen.GetOrLoad(frozenCacheKey{table, num}, func() (any, error) {
+		data, err := r.freezer.Retrieve(table, num)
+		if err != nil {
+			return nil, err
+		}
+		var fb frozenBlock
+		if err := rlp.DecodeBytes(data, &fb); err != nil {
+			return nil, err
+		}
+		return &fb, nil
+	})
+	if err != nil {
+		if err == freezer.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	items := fb.(*frozenBlock)
+	if items.Conflicts != conflicts || index >= uint64(len(items.Items)) {
+		return false, nil
+	}
+	if err := rlp.DecodeBytes(items.Items[index], val); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadFrozenTxMeta resolves the metadata of tx in the frozen block.
+func (r *Repository) loadFrozenTxMeta(summary *BlockSummary, txID thor.Bytes32) (*storageTxMeta, error) {
+	index := slices.Index(summary.Txs, txID)
+	if index < 0 {
+		return nil, errNotFound
+	}
+	receipt, err := r.getReceipt(appendTxKey(nil, summary.Header.Number(), summary.Conflicts, uint64(index), receiptFlag))
+	if err != nil {
+		return nil, err
+	}
+	return &storageTxMeta{
+		Index:    uint64(index),
+		Reverted: receipt.Reverted,
+	}, nil
+}
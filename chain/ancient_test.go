@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestFreeze(t *testing.T) {
+	db, repo := newTestRepo()
+	dir := t.TempDir()
+
+	f, err := OpenFreezer(dir)
+	require.NoError(t, err)
+	require.NoError(t, repo.AttachFreezer(f))
+
+	var (
+		blocks   = []*block.Block{repo.GenesisBlock()}
+		receipts = []tx.Receipts{nil}
+	)
+	for i := 1; i <= 5; i++ {
+		tx1, tx2 := newTx(tx.TypeLegacy), newTx(tx.TypeDynamicFee)
+		b := newBlock(blocks[i-1], uint64(i*10), tx1, tx2)
+		r := tx.Receipts{{GasUsed: uint64(i)}, {GasUsed: uint64(i), Reverted: true}}
+		require.NoError(t, repo.AddBlock(b, r, 0, true))
+		blocks = append(blocks, b)
+		receipts = append(receipts, r)
+	}
+	// a fork block
+	fork := newBlock(blocks[1], 25, newTx(tx.TypeLegacy))
+	require.NoError(t, repo.AddBlock(fork, tx.Receipts{{}}, 1, false))
+
+	headID := blocks[5].Header().ID()
+	require.NoError(t, repo.Freeze(context.Background(), headID, 4))
+	assert.Equal(t, uint32(4), repo.Frozen())
+
+	check := func(repo *Repository) {
+		for i, b := range blocks {
+			id := b.Header().ID()
+			got, err := repo.GetBlock(id)
+			require.NoError(t, err)
+			assert.Equal(t, b.Transactions().RootHash(), got.Transactions().RootHash())
+
+			gotReceipts, err := repo.GetBlockReceipts(id)
+			require.NoError(t, err)
+			assert.Equal(t, receipts[i].RootHash(), gotReceipts.RootHash())
+
+			for j, trx := range b.Transactions() {
+				gotTx, meta, err := repo.NewChain(headID).GetTransaction(trx.ID())
+				require.NoError(t, err)
+				assert.Equal(t, trx.ID(), gotTx.ID())
+				assert.Equal(t, &TxMeta{BlockNum: uint32(i), Index: uint64(j), Reverted: j == 1}, meta)
+			}
+		}
+		// fork block remains in the kv store
+		got, err := repo.GetBlock(fork.Header().ID())
+		require.NoError(t, err)
+		assert.Equal(t, fork.Transactions().RootHash(), got.Transactions().RootHash())
+	}
+	check(repo)
+
+	// frozen bodies removed from the kv store
+	has, err := repo.bodyStore.Has(appendTxKey(nil, 1, 0, 0, txFlag))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = repo.bodyStore.Has(appendTxKey(nil, 4, 0, 0, txFlag))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// blocks above head are not frozen
+	require.NoError(t, repo.Freeze(context.Background(), headID, 100))
+	assert.Equal(t, uint32(6), repo.Frozen())
+	require.NoError(t, f.Close())
+
+	// reopen
+	repo, err = NewRepository(db, blocks[0])
+	require.NoError(t, err)
+	f, err = OpenFreezer(dir)
+	require.NoError(t, err)
+	require.NoError(t, repo.AttachFreezer(f))
+	check(repo)
+	require.NoError(t, f.Close())
+}
+
+func TestAttachFreezer(t *testing.T) {
+	_, repo := newTestRepo()
+
+	f, err := OpenFreezer(t.TempDir())
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, repo.AttachFreezer(f))
+
+	b1 := newBlock(repo.GenesisBlock(), 10, newTx(tx.TypeLegacy))
+	require.NoError(t, repo.AddBlock(b1, tx.Receipts{{}}, 0, true))
+	require.NoError(t, repo.Freeze(context.Background(), b1.Header().ID(), 1))
+
+	// block appended but the migration interrupted
+	require.NoError(t, f.Append(1, map[string][]byte{frozenTxsTable: {0x1}, frozenReceiptsTable: {0x1}}))
+	require.NoError(t, repo.AttachFreezer(f))
+	assert.Equal(t, uint32(1), repo.Frozen())
+	_, err = repo.GetBlock(b1.Header().ID())
+	assert.NoError(t, err)
+
+	// canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, repo.Freeze(ctx, b1.Header().ID(), 2))
+	assert.Equal(t, uint32(1), repo.Frozen())
+
+	// blocks lost
+	require.NoError(t, repo.propStore.Put(frozenKey, []byte{0, 0, 0, 5}))
+	assert.Error(t, repo.AttachFreezer(f))
+}
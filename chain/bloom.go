@@ -0,0 +1,209 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thor/bloom"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	bloomStoreName = "chain.bloom" // for log blooms
+
+	blockBloomFlag   = byte('b')
+	sectionBloomFlag = byte('s')
+
+	// LogBloomSectionSize is the count of blocks covered by a section log bloom.
+	LogBloomSectionSize = 4096
+
+	logBloomBitsPerKey = 10
+)
+
+// keys of log blooms, prefixed by kind, to not mix fields.
+var (
+	hasEventKey    = []byte{'E'}
+	hasTransferKey = []byte{'T'}
+)
+
+// EventBloomKeys returns keys to test against log blooms, for events of the given address and topics.
+// Nil fields are wildcards.
+func EventBloomKeys(address *thor.Address, topics [5]*thor.Bytes32) [][]byte {
+	keys := [][]byte{hasEventKey}
+	if address != nil {
+		keys = append(keys, append([]byte{'a'}, address[:]...))
+	}
+	for i, topic := range topics {
+		if topic != nil {
+			keys = append(keys, append([]byte{'t', byte(i)}, topic[:]...))
+		}
+	}
+	return keys
+}
+
+// TransferBloomKeys returns keys to test against log blooms, for transfers of the given tx origin,
+// sender and recipient. Nil fields are wildcards.
+// Section blooms don't contain tx origins, so origin keys are skipped if forSection is set.
+func TransferBloomKeys(txOrigin, sender, recipient *thor.Address, forSection bool) [][]byte {
+	keys := [][]byte{hasTransferKey}
+	if txOrigin != nil && !forSection {
+		keys = append(keys, append([]byte{'o'}, txOrigin[:]...))
+	}
+	if sender != nil {
+		keys = append(keys, append([]byte{'s'}, sender[:]...))
+	}
+	if recipient != nil {
+		keys = append(keys, append([]byte{'r'}, recipient[:]...))
+	}
+	return keys
+}
+
+// addLogBloomKeys adds keys of logs in receipts into the bloom generator, and returns the count of logs.
+// Tx origins are added only if txs given.
+func addLogBloomKeys(g *bloom.Generator, txs tx.Transactions, receipts tx.Receipts) (int, error) {
+	n := 0
+	for i, r := range receipts {
+		var origin *thor.Address
+		for _, output := range r.Outputs {
+			for _, ev := range output.Events {
+				var topics [5]*thor.Bytes32
+				for j := range ev.Topics {
+					topics[j] = &ev.Topics[j]
+				}
+				for _, key := range EventBloomKeys(&ev.Address, topics) {
+					g.Add(key)
+				}
+				n++
+			}
+			for _, tr := range output.Transfers {
+				if origin == nil && txs != nil {
+					o, err := txs[i].Origin()
+					if err != nil {
+						return 0, err
+					}
+					origin = &o
+				}
+				for _, key := range TransferBloomKeys(origin, &tr.Sender, &tr.Recipient, false) {
+					g.Add(key)
+				}
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// LogBloom is the bloom filter of logs.
+type LogBloom struct {
+	filter *bloom.Filter // nil if no log
+}
+
+// MayContain returns whether logs may contain all the keys.
+func (b *LogBloom) MayContain(keys [][]byte) bool {
+	if b.filter == nil {
+		return false
+	}
+	for _, key := range keys {
+		if !b.filter.Contains(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func newLogBloom(g *bloom.Generator, nLogs int) *LogBloom {
+	if nLogs == 0 {
+		return &LogBloom{}
+	}
+	return &LogBloom{g.Generate(logBloomBitsPerKey, bloom.K(logBloomBitsPerKey))}
+}
+
+func encodeLogBloom(b *LogBloom) []byte {
+	if b.filter == nil {
+		return []byte{}
+	}
+	return append(append([]byte(nil), b.filter.Bits...), b.filter.K)
+}
+
+func decodeLogBloom(data []byte) *LogBloom {
+	if len(data) == 0 {
+		return &LogBloom{}
+	}
+	return &LogBloom{&bloom.Filter{
+		Bits: data[:len(data)-1],
+		K:    data[len(data)-1],
+	}}
+}
+
+func saveBlockLogBloom(w kv.Putter, id thor.Bytes32, txs tx.Transactions, receipts tx.Receipts) error {
+	var g bloom.Generator
+	n, err := addLogBloomKeys(&g, txs, receipts)
+	if err != nil {
+		return err
+	}
+	return w.Put(append([]byte{blockBloomFlag}, id[:]...), encodeLogBloom(newLogBloom(&g, n)))
+}
+
+// EnableLogBlooms makes the repository save log blooms of blocks, to scan logs without the log db.
+// It should be called before the repository is used.
+func (r *Repository) EnableLogBlooms() {
+	r.logBlooms = true
+}
+
+func (r *Repository) getLogBloom(key []byte) (*LogBloom, error) {
+	data, err := r.bloomStore.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLogBloom(data), nil
+}
+
+// GetBlockLogBloom returns the log bloom of the block.
+// Blocks saved before log blooms introduced have no bloom.
+func (r *Repository) GetBlockLogBloom(id thor.Bytes32) (*LogBloom, error) {
+	return r.getLogBloom(append([]byte{blockBloomFlag}, id[:]...))
+}
+
+// GetSectionLogBloom returns the log bloom of the section which ends with the given block.
+// Since the section bloom is keyed by its last block, it's never used by blocks on other branches.
+func (r *Repository) GetSectionLogBloom(endID thor.Bytes32) (*LogBloom, error) {
+	return r.getLogBloom(append([]byte{sectionBloomFlag}, endID[:]...))
+}
+
+// BuildSectionLogBloom builds and saves the log bloom of the section which ends with the given block.
+// The section bloom doesn't contain tx origins, to not recover them from signatures.
+func (r *Repository) BuildSectionLogBloom(endID thor.Bytes32) error {
+	num := block.Number(endID)
+	if (num+1)%LogBloomSectionSize != 0 {
+		return errors.Errorf("block %v is not the end of a section", num)
+	}
+
+	var (
+		chain = r.NewChain(endID)
+		g     bloom.Generator
+		nLogs int
+	)
+	for i := num + 1 - LogBloomSectionSize; i <= num; i++ {
+		id, err := chain.GetBlockID(i)
+		if err != nil {
+			return err
+		}
+		receipts, err := r.GetBlockReceipts(id)
+		if err != nil {
+			return err
+		}
+		n, err := addLogBloomKeys(&g, nil, receipts)
+		if err != nil {
+			return err
+		}
+		nLogs += n
+	}
+	return r.bloomStore.Put(append([]byte{sectionBloomFlag}, endID[:]...), encodeLogBloom(newLogBloom(&g, nLogs)))
+}
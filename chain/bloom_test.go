@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestLogBloom(t *testing.T) {
+	_, repo := newTestRepo()
+	repo.EnableLogBlooms()
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	origin := thor.Address(crypto.PubkeyToAddress(pk.PublicKey))
+
+	var (
+		addr      = thor.BytesToAddress([]byte("addr"))
+		topic     = thor.BytesToBytes32([]byte("topic"))
+		sender    = thor.BytesToAddress([]byte("sender"))
+		recipient = thor.BytesToAddress([]byte("recipient"))
+		other     = thor.BytesToAddress([]byte("other"))
+	)
+	trx := tx.MustSign(new(tx.Builder).Build(), pk)
+	receipt := &tx.Receipt{Outputs: []*tx.Output{{
+		Events:    tx.Events{{Address: addr, Topics: []thor.Bytes32{topic}}},
+		Transfers: tx.Transfers{{Sender: sender, Recipient: recipient, Amount: big.NewInt(1)}},
+	}}}
+
+	// the section [0, LogBloomSectionSize), with logs in block 1 only
+	parent := repo.GenesisBlock()
+	for i := uint32(1); i < LogBloomSectionSize; i++ {
+		var (
+			txs      []*tx.Transaction
+			receipts tx.Receipts
+		)
+		if i == 1 {
+			txs, receipts = []*tx.Transaction{trx}, tx.Receipts{receipt}
+		}
+		b := newBlock(parent, uint64(i)*10, txs...)
+		require.NoError(t, repo.AddBlock(b, receipts, 0, true))
+		parent = b
+	}
+
+	b1, err := repo.NewBestChain().GetBlockID(1)
+	require.NoError(t, err)
+	bloom, err := repo.GetBlockLogBloom(b1)
+	require.NoError(t, err)
+	assert.True(t, bloom.MayContain(EventBloomKeys(&addr, [5]*thor.Bytes32{&topic})))
+	assert.True(t, bloom.MayContain(EventBloomKeys(nil, [5]*thor.Bytes32{nil, nil})))
+	assert.False(t, bloom.MayContain(EventBloomKeys(&other, [5]*thor.Bytes32{})))
+	assert.False(t, bloom.MayContain(EventBloomKeys(&addr, [5]*thor.Bytes32{nil, &topic})))
+	assert.True(t, bloom.MayContain(TransferBloomKeys(&origin, &sender, &recipient, false)))
+	assert.False(t, bloom.MayContain(TransferBloomKeys(&other, nil, nil, false)))
+
+	// block without logs
+	b2, err := repo.NewBestChain().GetBlockID(2)
+	require.NoError(t, err)
+	bloom, err = repo.GetBlockLogBloom(b2)
+	require.NoError(t, err)
+	assert.False(t, bloom.MayContain(EventBloomKeys(nil, [5]*thor.Bytes32{})))
+	assert.False(t, bloom.MayContain(TransferBloomKeys(nil, nil, nil, false)))
+
+	// section
+	assert.Error(t, repo.BuildSectionLogBloom(b2), "not section end")
+
+	endID := parent.Header().ID()
+	_, err = repo.GetSectionLogBloom(endID)
+	assert.True(t, repo.IsNotFound(err))
+
+	require.NoError(t, repo.BuildSectionLogBloom(endID))
+	bloom, err = repo.GetSectionLogBloom(endID)
+	require.NoError(t, err)
+	assert.True(t, bloom.MayContain(EventBloomKeys(&addr, [5]*thor.Bytes32{&topic})))
+	assert.False(t, bloom.MayContain(EventBloomKeys(&other, [5]*thor.Bytes32{})))
+	assert.True(t, bloom.MayContain(TransferBloomKeys(&origin, &sender, nil, true)))
+	assert.False(t, bloom.MayContain(TransferBloomKeys(nil, &other, nil, true)))
+}
+
+func TestLogBloomDisabled(t *testing.T) {
+	_, repo := newTestRepo()
+
+	b1 := newBlock(repo.GenesisBlock(), 10)
+	require.NoError(t, repo.AddBlock(b1, nil, 0, true))
+
+	// not saved unless enabled
+	_, err := repo.GetBlockLogBloom(b1.Header().ID())
+	assert.True(t, repo.IsNotFound(err))
+}
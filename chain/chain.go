@@ -126,7 +126,14 @@ func (c *Chain) GetTransactionMeta(id thor.Bytes32) (*TxMeta, error) {
 		}
 		if s.Conflicts == uint32(conflicts) {
 			var sMeta storageTxMeta
-			if err := rlp.DecodeBytes(iter.Value(), &sMeta); err != nil {
+			if len(iter.Value()) == 0 {
+				// the block is frozen
+				frozen, err := c.repo.loadFrozenTxMeta(s, id)
+				if err != nil {
+					return nil, err
+				}
+				sMeta = *frozen
+			} else if err := rlp.DecodeBytes(iter.Value(), &sMeta); err != nil {
 				return nil, err
 			}
 			return &TxMeta{
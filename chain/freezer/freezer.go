@@ -0,0 +1,218 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package freezer implements the storage of ancient block data, in compressed append-only files.
+package freezer
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// maxSegmentSize is the size limit of a segment file.
+const maxSegmentSize = 2 * 1024 * 1024 * 1024
+
+// ErrNotFound is returned when the item is not frozen.
+var ErrNotFound = errors.New("not frozen")
+
+var errReadOnly = errors.New("freezer opened read-only")
+
+// Freezer stores items of ancient blocks in tables, one item per table for each block,
+// indexed by block number.
+//
+// It's thread-safe.
+type Freezer struct {
+	lock     sync.RWMutex
+	tables   map[string]*table
+	frozen   atomic.Uint32
+	readOnly bool
+}
+
+// Open opens the freezer in dir, with the given tables.
+func Open(dir string, tables ...string) (*Freezer, error) {
+	return open(dir, maxSegmentSize, tables)
+}
+
+// OpenReadOnly opens the freezer in dir for reading only, while it may be appended by another process.
+// Blocks appended by the owner are not visible until Reload is called.
+func OpenReadOnly(dir string, tables ...string) (*Freezer, error) {
+	f := &Freezer{tables: make(map[string]*table, len(tables)), readOnly: true}
+	frozen := uint32(0)
+	for i, name := range tables {
+		t, err := openTableReadOnly(dir, name)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		f.tables[name] = t
+		if i == 0 || t.items < frozen {
+			frozen = t.items
+		}
+	}
+	f.frozen.Store(frozen)
+	return f, nil
+}
+
+func open(dir string, maxSize uint32, names []string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	f := &Freezer{tables: make(map[string]*table, len(names))}
+	frozen := uint32(0)
+	for i, name := range names {
+		t, err := openTable(dir, name, maxSize)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		f.tables[name] = t
+		if i == 0 || t.items < frozen {
+			frozen = t.items
+		}
+	}
+	// tables might be inconsistent after an unclean shutdown
+	if err := f.truncate(frozen); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Frozen returns the count of frozen blocks.
+func (f *Freezer) Frozen() uint32 {
+	return f.frozen.Load()
+}
+
+// Retrieve retrieves the item of the block in the table.
+func (f *Freezer) Retrieve(table string, num uint32) ([]byte, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	t, ok := f.tables[table]
+	if !ok {
+		return nil, errors.Errorf("unknown table %v", table)
+	}
+	if num >= f.frozen.Load() {
+		return nil, ErrNotFound
+	}
+	data, err := t.retrieve(num)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Decode(nil, data)
+}
+
+// Reload makes blocks appended by the owner visible, up to the given count of frozen blocks,
+// if the freezer is opened read-only.
+func (f *Freezer) Reload(frozen uint32) error {
+	if !f.readOnly {
+		return nil
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, t := range f.tables {
+		if err := t.reload(frozen); err != nil {
+			return err
+		}
+	}
+	f.frozen.Store(frozen)
+	return nil
+}
+
+// Append appends items of the next block, which must be given for every table.
+// The appended block is not durable until Sync is called.
+func (f *Freezer) Append(num uint32, items map[string][]byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.readOnly {
+		return errReadOnly
+	}
+
+	if frozen := f.frozen.Load(); num != frozen {
+		return errors.Errorf("append block %v, expected %v", num, frozen)
+	}
+	if len(items) != len(f.tables) {
+		return errors.New("incomplete items")
+	}
+	for name := range items {
+		if _, ok := f.tables[name]; !ok {
+			return errors.Errorf("unknown table %v", name)
+		}
+	}
+	for name, item := range items {
+		if err := f.tables[name].append(snappy.Encode(nil, item)); err != nil {
+			// leave no partial block
+			f.truncate(num)
+			return err
+		}
+	}
+	f.frozen.Store(num + 1)
+	return nil
+}
+
+// Truncate drops frozen blocks from the tail, to keep the given count of blocks.
+func (f *Freezer) Truncate(frozen uint32) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if frozen >= f.frozen.Load() {
+		return nil
+	}
+	if f.readOnly {
+		// files are owned by another process, only hide blocks beyond
+		f.frozen.Store(frozen)
+		return nil
+	}
+	return f.truncate(frozen)
+}
+
+func (f *Freezer) truncate(frozen uint32) error {
+	for _, t := range f.tables {
+		if t.items > frozen {
+			if err := t.truncate(frozen); err != nil {
+				return err
+			}
+		}
+	}
+	f.frozen.Store(frozen)
+	return nil
+}
+
+// Sync flushes appended blocks to disk.
+func (f *Freezer) Sync() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.readOnly {
+		return errReadOnly
+	}
+
+	for _, t := range f.tables {
+		if err := t.sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the freezer.
+func (f *Freezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var err error
+	for _, t := range f.tables {
+		if e := t.close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package freezer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func itemOf(table string, num uint32) []byte {
+	return []byte(fmt.Sprintf("%s-%d", table, num))
+}
+
+func appendBlocks(t *testing.T, f *Freezer, from, to uint32) {
+	for i := from; i < to; i++ {
+		require.NoError(t, f.Append(i, map[string][]byte{"a": itemOf("a", i), "b": itemOf("b", i)}))
+	}
+	require.NoError(t, f.Sync())
+}
+
+func checkBlocks(t *testing.T, f *Freezer, count uint32) {
+	assert.Equal(t, count, f.Frozen())
+	for i := range count {
+		for _, table := range []string{"a", "b"} {
+			item, err := f.Retrieve(table, i)
+			require.NoError(t, err)
+			assert.Equal(t, itemOf(table, i), item)
+		}
+	}
+	_, err := f.Retrieve("a", count)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestFreezer(t *testing.T) {
+	dir := t.TempDir()
+
+	// small segments to test rolling over
+	f, err := open(dir, 32, []string{"a", "b"})
+	require.NoError(t, err)
+	appendBlocks(t, f, 0, 20)
+	checkBlocks(t, f, 20)
+
+	assert.Error(t, f.Append(30, map[string][]byte{"a": nil, "b": nil}))
+	assert.Error(t, f.Append(20, map[string][]byte{"a": nil}))
+	_, err = f.Retrieve("c", 0)
+	assert.Error(t, err)
+
+	require.NoError(t, f.Truncate(5))
+	checkBlocks(t, f, 5)
+	appendBlocks(t, f, 5, 10)
+	require.NoError(t, f.Close())
+
+	f, err = open(dir, 32, []string{"a", "b"})
+	require.NoError(t, err)
+	checkBlocks(t, f, 10)
+	require.NoError(t, f.Close())
+}
+
+func TestFreezerRepair(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := open(dir, 32, []string{"a", "b"})
+	require.NoError(t, err)
+	appendBlocks(t, f, 0, 10)
+	require.NoError(t, f.Close())
+
+	// table b lost its tail
+	b, err := openTable(dir, "b", 32)
+	require.NoError(t, err)
+	require.NoError(t, b.truncate(7))
+	require.NoError(t, b.close())
+
+	// partially written index entry and data of table a
+	idx, err := os.OpenFile(filepath.Join(dir, "a.idx"), os.O_RDWR, 0)
+	require.NoError(t, err)
+	stat, err := idx.Stat()
+	require.NoError(t, err)
+	require.NoError(t, idx.Truncate(stat.Size()-indexEntrySize/2))
+	require.NoError(t, idx.Close())
+
+	f, err = open(dir, 32, []string{"a", "b"})
+	require.NoError(t, err)
+	checkBlocks(t, f, 7)
+	appendBlocks(t, f, 7, 12)
+	checkBlocks(t, f, 12)
+	require.NoError(t, f.Close())
+}
+
+func TestFreezerReadOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := open(dir, 32, []string{"a", "b"})
+	require.NoError(t, err)
+	defer f.Close()
+	appendBlocks(t, f, 0, 5)
+
+	ro, err := OpenReadOnly(dir, "a", "b")
+	require.NoError(t, err)
+	defer ro.Close()
+	checkBlocks(t, ro, 5)
+
+	assert.Error(t, ro.Append(5, map[string][]byte{"a": nil, "b": nil}))
+	assert.Error(t, ro.Sync())
+
+	// blocks appended by the owner, which roll over segments
+	appendBlocks(t, f, 5, 20)
+	checkBlocks(t, ro, 5)
+	require.NoError(t, ro.Reload(20))
+	checkBlocks(t, ro, 20)
+
+	// truncation hides blocks only
+	require.NoError(t, ro.Truncate(10))
+	checkBlocks(t, ro, 10)
+	checkBlocks(t, f, 20)
+}
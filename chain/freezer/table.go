@@ -0,0 +1,280 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package freezer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const indexEntrySize = 8
+
+// indexEntry locates the end of an item, in the segment file it belongs to.
+type indexEntry struct {
+	segment uint32
+	offset  uint32
+}
+
+func (e *indexEntry) encode(buf []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, e.segment)
+	return binary.BigEndian.AppendUint32(buf, e.offset)
+}
+
+func (e *indexEntry) decode(data []byte) {
+	e.segment = binary.BigEndian.Uint32(data)
+	e.offset = binary.BigEndian.Uint32(data[4:])
+}
+
+// table is an append-only sequence of items, stored in segment files with an index file.
+//
+// Item n spans the segment file of index entry n, from the end of item n-1 (or 0 if item n
+// starts a new segment) to the offset of entry n.
+// It's not thread-safe.
+type table struct {
+	dir      string
+	name     string
+	maxSize  uint32
+	index    *os.File
+	segments []*os.File
+	items    uint32
+	head     indexEntry // the end of the last item
+	synced   uint32     // segments before it are synced
+}
+
+func (t *table) segmentPath(n uint32) string {
+	return filepath.Join(t.dir, fmt.Sprintf("%s.%04d.dat", t.name, n))
+}
+
+// openTable opens the table, and repairs the tail damaged by an unclean shutdown.
+func openTable(dir, name string, maxSize uint32) (*table, error) {
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	t := &table{
+		dir:     dir,
+		name:    name,
+		maxSize: maxSize,
+		index:   index,
+	}
+	if err := t.repair(); err != nil {
+		t.close()
+		return nil, errors.Wrapf(err, "open table %v", name)
+	}
+	return t, nil
+}
+
+// openTableReadOnly opens the table for reading only, without repairing.
+// Since data is written before the index entry, every entry in the index refers to written data.
+func openTableReadOnly(dir, name string) (*table, error) {
+	index, err := os.Open(filepath.Join(dir, name+".idx"))
+	if err != nil {
+		return nil, err
+	}
+	t := &table{
+		dir:   dir,
+		name:  name,
+		index: index,
+	}
+	stat, err := index.Stat()
+	if err != nil {
+		t.close()
+		return nil, err
+	}
+	if err := t.reload(uint32(stat.Size() / indexEntrySize)); err != nil {
+		t.close()
+		return nil, errors.Wrapf(err, "open table %v", name)
+	}
+	return t, nil
+}
+
+// reload opens segments appended by the owner, for the read-only table to see the given count of items.
+func (t *table) reload(items uint32) error {
+	var head indexEntry
+	if items > 0 {
+		var err error
+		if head, err = t.entry(items - 1); err != nil {
+			return err
+		}
+	}
+	for i := uint32(len(t.segments)); i <= head.segment; i++ {
+		f, err := os.Open(t.segmentPath(i))
+		if err != nil {
+			return err
+		}
+		t.segments = append(t.segments, f)
+	}
+	t.items = items
+	t.head = head
+	return nil
+}
+
+func (t *table) repair() error {
+	stat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+	// drop the partially written entry
+	items := uint32(stat.Size() / indexEntrySize)
+
+	var head indexEntry
+	if items > 0 {
+		if head, err = t.entry(items - 1); err != nil {
+			return err
+		}
+	}
+	for i := uint32(0); i <= head.segment; i++ {
+		f, err := os.OpenFile(t.segmentPath(i), os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return err
+		}
+		t.segments = append(t.segments, f)
+	}
+
+	// drop entries whose data is not completely written
+	for items > 0 {
+		stat, err := t.segments[head.segment].Stat()
+		if err != nil {
+			return err
+		}
+		if stat.Size() >= int64(head.offset) {
+			break
+		}
+		items--
+		head = indexEntry{}
+		if items > 0 {
+			if head, err = t.entry(items - 1); err != nil {
+				return err
+			}
+		}
+	}
+	return t.truncate(items)
+}
+
+// entry reads the index entry of item n.
+func (t *table) entry(n uint32) (indexEntry, error) {
+	var (
+		buf [indexEntrySize]byte
+		e   indexEntry
+	)
+	if _, err := t.index.ReadAt(buf[:], int64(n)*indexEntrySize); err != nil {
+		return e, err
+	}
+	e.decode(buf[:])
+	return e, nil
+}
+
+// append appends the item.
+func (t *table) append(item []byte) error {
+	if uint64(t.head.offset)+uint64(len(item)) > uint64(t.maxSize) && t.head.offset > 0 {
+		// open a new segment
+		f, err := os.OpenFile(t.segmentPath(t.head.segment+1), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		t.segments = append(t.segments, f)
+		t.head = indexEntry{segment: t.head.segment + 1}
+	}
+
+	// data goes first, so the index never refers to absent data
+	if _, err := t.segments[t.head.segment].WriteAt(item, int64(t.head.offset)); err != nil {
+		return err
+	}
+	head := indexEntry{t.head.segment, t.head.offset + uint32(len(item))}
+	if _, err := t.index.WriteAt(head.encode(nil), int64(t.items)*indexEntrySize); err != nil {
+		return err
+	}
+	t.head = head
+	t.items++
+	return nil
+}
+
+// retrieve reads item n.
+func (t *table) retrieve(n uint32) ([]byte, error) {
+	end, err := t.entry(n)
+	if err != nil {
+		return nil, err
+	}
+	start := uint32(0)
+	if n > 0 {
+		prev, err := t.entry(n - 1)
+		if err != nil {
+			return nil, err
+		}
+		if prev.segment == end.segment {
+			start = prev.offset
+		}
+	}
+	if int(end.segment) >= len(t.segments) || start > end.offset {
+		return nil, errors.Errorf("table %v: invalid index entry of item %v", t.name, n)
+	}
+
+	item := make([]byte, end.offset-start)
+	if _, err := t.segments[end.segment].ReadAt(item, int64(start)); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// truncate drops items from the tail, to keep the given count of items.
+func (t *table) truncate(items uint32) error {
+	head := indexEntry{}
+	if items > 0 {
+		var err error
+		if head, err = t.entry(items - 1); err != nil {
+			return err
+		}
+	}
+	if err := t.index.Truncate(int64(items) * indexEntrySize); err != nil {
+		return err
+	}
+	for len(t.segments) > int(head.segment)+1 {
+		last := len(t.segments) - 1
+		t.segments[last].Close()
+		if err := os.Remove(t.segmentPath(uint32(last))); err != nil {
+			return err
+		}
+		t.segments = t.segments[:last]
+	}
+	if err := t.segments[head.segment].Truncate(int64(head.offset)); err != nil {
+		return err
+	}
+	t.items = items
+	t.head = head
+	t.synced = min(t.synced, head.segment)
+	return nil
+}
+
+// sync flushes segments written since last sync and the index to disk.
+func (t *table) sync() error {
+	for i := t.synced; i <= t.head.segment; i++ {
+		if err := t.segments[i].Sync(); err != nil {
+			return err
+		}
+	}
+	t.synced = t.head.segment
+	return t.index.Sync()
+}
+
+func (t *table) close() error {
+	var errs []error
+	for _, f := range t.segments {
+		if err := f.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := t.index.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
@@ -24,6 +24,24 @@ func appendTxKey(buf []byte, blockNum, blockConflicts uint32, index uint64, flag
 	return binary.AppendUvarint(buf, index)
 }
 
+// parseTxKey parses the key composed by appendTxKey.
+func parseTxKey(key []byte) (blockNum, blockConflicts uint32, index uint64, flag byte, ok bool) {
+	if len(key) < 4 {
+		return
+	}
+	blockNum = binary.BigEndian.Uint32(key)
+	conflicts, n := binary.Uvarint(key[4:])
+	if n <= 0 || len(key) < 4+n+1 {
+		return
+	}
+	flag = key[4+n]
+	index, m := binary.Uvarint(key[4+n+1:])
+	if m <= 0 {
+		return
+	}
+	return blockNum, uint32(conflicts), index, flag, true
+}
+
 // BlockSummary presents block summary.
 type BlockSummary struct {
 	Header    *block.Header
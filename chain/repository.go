@@ -14,6 +14,7 @@ import (
 
 	"github.com/vechain/thor/v2/block"
 	cache2 "github.com/vechain/thor/v2/cache"
+	"github.com/vechain/thor/v2/chain/freezer"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/kv"
 	"github.com/vechain/thor/v2/muxdb"
@@ -43,12 +44,15 @@ var (
 //
 // It's thread-safe.
 type Repository struct {
-	db        *muxdb.MuxDB
-	hdrStore  kv.Store
-	bodyStore kv.Store
-	propStore kv.Store
-	headStore kv.Store
-	txIndexer kv.Store
+	db         *muxdb.MuxDB
+	hdrStore   kv.Store
+	bodyStore  kv.Store
+	propStore  kv.Store
+	headStore  kv.Store
+	txIndexer  kv.Store
+	bloomStore kv.Store
+	freezer    *freezer.Freezer
+	logBlooms  bool // whether to save log blooms of blocks
 
 	genesis *block.Block
 	tag     byte
@@ -60,6 +64,7 @@ type Repository struct {
 		summaries *cache
 		txs       *cache
 		receipts  *cache
+		frozen    *cache
 
 		stats struct {
 			summaries cache2.Stats
@@ -80,19 +85,21 @@ func NewRepository(db *muxdb.MuxDB, genesis *block.Block) (*Repository, error) {
 
 	genesisID := genesis.Header().ID()
 	repo := &Repository{
-		db:        db,
-		hdrStore:  db.NewStore(hdrStoreName),
-		bodyStore: db.NewStore(bodyStoreName),
-		propStore: db.NewStore(propStoreName),
-		headStore: db.NewStore(headStoreName),
-		txIndexer: db.NewStore(txIndexStoreName),
-		genesis:   genesis,
-		tag:       genesisID[31],
+		db:         db,
+		hdrStore:   db.NewStore(hdrStoreName),
+		bodyStore:  db.NewStore(bodyStoreName),
+		propStore:  db.NewStore(propStoreName),
+		headStore:  db.NewStore(headStoreName),
+		txIndexer:  db.NewStore(txIndexStoreName),
+		bloomStore: db.NewStore(bloomStoreName),
+		genesis:    genesis,
+		tag:        genesisID[31],
 	}
 
 	repo.caches.summaries = newCache(512)
 	repo.caches.txs = newCache(2048)
 	repo.caches.receipts = newCache(2048)
+	repo.caches.frozen = newCache(64)
 
 	if val, err := repo.propStore.Get(bestBlockIDKey); err != nil {
 		if !repo.propStore.IsNotFound(err) {
@@ -140,6 +147,36 @@ func (r *Repository) BestBlockSummary() *BlockSummary {
 	return r.bestSummary.Load().(*BlockSummary)
 }
 
+// Refresh reloads the best block and frozen blocks, which are written by another process, when the
+// repository is on the database opened read-only. The ticker is signaled if the best block changed.
+func (r *Repository) Refresh() error {
+	if r.freezer != nil {
+		migrated, err := r.loadMigrated()
+		if err != nil {
+			return err
+		}
+		if err := r.freezer.Reload(migrated); err != nil {
+			return err
+		}
+	}
+
+	val, err := r.propStore.Get(bestBlockIDKey)
+	if err != nil {
+		return err
+	}
+	bestID := thor.BytesToBytes32(val)
+	if bestID == r.BestBlockSummary().Header.ID() {
+		return nil
+	}
+	summary, err := r.GetBlockSummary(bestID)
+	if err != nil {
+		return errors.Wrap(err, "get best block")
+	}
+	r.bestSummary.Store(summary)
+	r.tick.Broadcast()
+	return nil
+}
+
 func (r *Repository) saveBlock(block *block.Block, receipts tx.Receipts, conflicts uint32, asBest bool) (*BlockSummary, error) {
 	var (
 		header        = block.Header()
@@ -153,6 +190,7 @@ func (r *Repository) saveBlock(block *block.Block, receipts tx.Receipts, conflic
 		propPutter    = kv.Bucket(propStoreName).NewPutter(bulk)
 		headPutter    = kv.Bucket(headStoreName).NewPutter(bulk)
 		txIndexPutter = kv.Bucket(txIndexStoreName).NewPutter(bulk)
+		bloomPutter   = kv.Bucket(bloomStoreName).NewPutter(bulk)
 		keyBuf        []byte
 	)
 
@@ -195,6 +233,11 @@ func (r *Repository) saveBlock(block *block.Block, receipts tx.Receipts, conflic
 			r.caches.receipts.Add(string(keyBuf), receipt)
 		}
 	}
+	if r.logBlooms {
+		if err := saveBlockLogBloom(bloomPutter, id, txs, receipts); err != nil {
+			return nil, err
+		}
+	}
 	if err := indexChainHead(headPutter, header); err != nil {
 		return nil, err
 	}
@@ -329,7 +372,16 @@ func (r *Repository) GetBlockSummary(id thor.Bytes32) (*BlockSummary, error) {
 
 func (r *Repository) getTransaction(key []byte) (*tx.Transaction, error) {
 	trx, cached, err := r.caches.txs.GetOrLoad(string(key), func() (any, error) {
-		return loadTransaction(r.bodyStore, key)
+		trx, err := loadTransaction(r.bodyStore, key)
+		if err != nil && r.bodyStore.IsNotFound(err) {
+			var frozen tx.Transaction
+			if found, ferr := r.loadFrozenRLP(key, &frozen); ferr != nil {
+				return nil, ferr
+			} else if found {
+				return &frozen, nil
+			}
+		}
+		return trx, err
 	})
 	if err != nil {
 		return nil, err
@@ -392,7 +444,16 @@ func (r *Repository) GetBlock(id thor.Bytes32) (*block.Block, error) {
 
 func (r *Repository) getReceipt(key []byte) (*tx.Receipt, error) {
 	receipt, cached, err := r.caches.receipts.GetOrLoad(string(key), func() (any, error) {
-		return loadReceipt(r.bodyStore, key)
+		receipt, err := loadReceipt(r.bodyStore, key)
+		if err != nil && r.bodyStore.IsNotFound(err) {
+			var frozen tx.Receipt
+			if found, ferr := r.loadFrozenRLP(key, &frozen); ferr != nil {
+				return nil, ferr
+			} else if found {
+				return &frozen, nil
+			}
+		}
+		return receipt, err
 	})
 	if err != nil {
 		return nil, err
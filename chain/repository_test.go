@@ -270,3 +270,43 @@ func TestRepository_ErrorBranches(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestRefresh(t *testing.T) {
+	path := t.TempDir()
+	opts := &muxdb.Options{TrieHistPartitionFactor: 1, TrieDedupedPartitionFactor: 1}
+
+	db, err := muxdb.Open(path, opts)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	b0 := new(block.Builder).ParentID(thor.Bytes32{0xff, 0xff, 0xff, 0xff}).Build()
+	repo, err := NewRepository(db, b0)
+	assert.Nil(t, err)
+
+	roDB, err := muxdb.OpenReadOnly(path, opts)
+	assert.Nil(t, err)
+	defer roDB.Close()
+	roRepo, err := NewRepository(roDB, b0)
+	assert.Nil(t, err)
+
+	ticker := roRepo.NewTicker()
+	assert.Nil(t, roRepo.Refresh())
+	select {
+	case <-ticker.C():
+		t.Fatal("unexpected tick")
+	default:
+	}
+
+	b1 := newBlock(b0, 10)
+	assert.Nil(t, repo.AddBlock(b1, nil, 0, true))
+	assert.Equal(t, b0.Header().ID(), roRepo.BestBlockSummary().Header.ID())
+
+	assert.Nil(t, roDB.Reload())
+	assert.Nil(t, roRepo.Refresh())
+	assert.Equal(t, b1.Header().ID(), roRepo.BestBlockSummary().Header.ID())
+	<-ticker.C()
+
+	id, err := roRepo.NewBestChain().GetBlockID(1)
+	assert.Nil(t, err)
+	assert.Equal(t, b1.Header().ID(), id)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/httpserver"
+	"github.com/vechain/thor/v2/cmd/thor/replica"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+)
+
+// noPeers is the network of the replica, which has no p2p.
+type noPeers struct{}
+
+func (noPeers) PeersStats() []*comm.PeerStats { return nil }
+
+// apiOnlyAction serves the API from the data dir written by another thor process, with databases opened read-only.
+func apiOnlyAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	defer func() { log.Info("exited") }()
+
+	if _, err := initLogger(ctx); err != nil {
+		return err
+	}
+	return runAPIOnly(ctx, exitSignal)
+}
+
+// runAPIOnly serves the API until the exit signal is received.
+func runAPIOnly(ctx *cli.Context, exitSignal context.Context) error {
+	writerURL := ctx.String(apiOnlyWriterFlag.Name)
+	if writerURL == "" {
+		return fmt.Errorf("missing writer API URL, use -%s to specify", apiOnlyWriterFlag.Name)
+	}
+	pollInterval := ctx.Duration(apiOnlyPollIntervalFlag.Name)
+	if pollInterval <= 0 {
+		return errors.New("poll-interval flag should be positive")
+	}
+	reloadInterval := ctx.Duration(apiOnlyReloadIntervalFlag.Name)
+	if reloadInterval < 0 {
+		return errors.New("reload-interval flag should not be negative")
+	}
+
+	gene, forkConfig, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+
+	mainDB, err := openMainDBReadOnly(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	skipLogs := ctx.Bool(skipLogsFlag.Name)
+	var logDB *logdb.LogDB
+	if !skipLogs {
+		if logDB, err = openLogDBReadOnly(instanceDir); err != nil {
+			return err
+		}
+		defer func() { log.Info("closing log database..."); logDB.Close() }()
+	}
+
+	// only the genesis block is needed, since the main db can't be written
+	genesisBlock, _, _, err := gene.Build(state.NewStater(muxdb.NewMem()))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	// the repository writes nothing if the data dir is initialized by the writer
+	repo, err := chain.NewRepository(mainDB, genesisBlock)
+	if err != nil {
+		return errors.Wrap(err, "load block chain")
+	}
+
+	freezer, err := attachFreezerReadOnly(repo, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer func() { log.Info("closing freezer..."); freezer.Close() }()
+
+	printUpstreamStartupMessage("Thor api-only", gene, repo, "Writer API", writerURL, instanceDir)
+
+	follower, err := replica.NewFollower(mainDB, repo, forkConfig, writerURL, pollInterval, reloadInterval)
+	if err != nil {
+		return err
+	}
+	defer func() { log.Info("stopping follower..."); follower.Stop() }()
+
+	logAPIRequests := &atomic.Bool{}
+	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+
+	apiURL, srvCloser, err := httpserver.StartAPIServer(
+		ctx.String(apiAddrFlag.Name),
+		repo,
+		state.NewStater(mainDB),
+		replica.NewTxForwarder(writerURL),
+		logDB,
+		follower,
+		noPeers{},
+		forkConfig,
+		makeAPIConfig(ctx, logAPIRequests, false),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { log.Info("stopping API server..."); srvCloser() }()
+
+	printStartupMessage2(gene, apiURL, "", "", "")
+
+	<-exitSignal.Done()
+	return nil
+}
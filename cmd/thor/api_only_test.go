@@ -0,0 +1,81 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestAPIOnly(t *testing.T) {
+	dataDir := t.TempDir()
+	_, repo := newTestInstance(t, dataDir)
+
+	// the writer API is unavailable, so databases are reloaded at every poll
+	writer := httptest.NewServer(http.NotFoundHandler())
+	defer writer.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	apiAddr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ctx := newTestContext(t,
+		[]cli.Flag{
+			networkFlag, dataDirFlag, skipLogsFlag, apiOnlyWriterFlag, apiOnlyPollIntervalFlag, apiOnlyReloadIntervalFlag,
+			apiAddrFlag, apiCallGasLimitFlag, apiBacktraceLimitFlag, apiLogsLimitFlag,
+		},
+		"--network", "main",
+		"--data-dir", dataDir,
+		"--writer-api", writer.URL,
+		"--poll-interval", "10ms",
+		"--reload-interval", "0",
+		"--api-addr", apiAddr,
+	)
+	exitSignal, exit := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runAPIOnly(ctx, exitSignal) }()
+	defer func() {
+		exit()
+		assert.NoError(t, <-done)
+	}()
+
+	client := thorclient.New("http://" + apiAddr)
+	bestID := func() thor.Bytes32 {
+		best, err := client.Block("best")
+		if err != nil {
+			return thor.Bytes32{}
+		}
+		return best.ID
+	}
+	assert.Eventually(t, func() bool {
+		return bestID() == repo.GenesisBlock().Header().ID()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// blocks written by the writer are followed
+	blk := new(block.Builder).
+		ParentID(repo.GenesisBlock().Header().ID()).
+		Timestamp(repo.GenesisBlock().Header().Timestamp() + thor.BlockInterval()).
+		ReceiptsRoot(tx.Receipts(nil).RootHash()).
+		Build()
+	require.NoError(t, repo.AddBlock(blk, nil, 0, true))
+	assert.Eventually(t, func() bool {
+		return bestID() == blk.Header().ID()
+	}, 5*time.Second, 10*time.Millisecond)
+}
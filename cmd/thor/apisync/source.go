@@ -0,0 +1,195 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package apisync syncs blocks from the REST API of a trusted node, for nodes which can't join the p2p network.
+package apisync
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var logger = log.WithContext("pkg", "apisync")
+
+const (
+	syncInterval   = 2 * time.Second
+	fetchBatchSize = 32 // blocks fetched concurrently
+)
+
+var emptyTxsRoot = tx.Transactions(nil).RootHash()
+
+// Source pulls blocks from the API of a trusted node. Blocks are only pulled, and still validated and
+// executed by the handler, the same as blocks synced from peers.
+type Source struct {
+	repo   *chain.Repository
+	client *thorclient.Client
+}
+
+// New creates a source pulling blocks from the API at the given url.
+func New(repo *chain.Repository, url string) *Source {
+	return &Source{
+		repo:   repo,
+		client: thorclient.New(url),
+	}
+}
+
+// Sync keeps pulling blocks beyond the local best block, until ctx is done.
+func (s *Source) Sync(ctx context.Context, handler comm.HandleBlockStream) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx, handler); err != nil && ctx.Err() == nil {
+			logger.Warn("failed to sync from the API", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync pulls blocks up to the remote best block, which is assumed to be the best if its total score is higher.
+func (s *Source) sync(ctx context.Context, handler comm.HandleBlockStream) error {
+	remote, err := s.client.Block("best")
+	if err != nil {
+		return errors.Wrap(err, "get remote best block")
+	}
+	best := s.repo.BestBlockSummary().Header
+	if remote.ID == best.ID() || remote.TotalScore <= best.TotalScore() {
+		return nil
+	}
+
+	ancestor, err := s.findCommonAncestor(min(best.Number(), remote.Number))
+	if err != nil {
+		return errors.WithMessage(err, "find common ancestor")
+	}
+	return s.download(ctx, handler, ancestor+1, remote.Number)
+}
+
+// findCommonAncestor returns the number of the latest block shared by the local and remote chains.
+// Forks are short, so it steps back from the head one block at a time.
+func (s *Source) findCommonAncestor(headNum uint32) (uint32, error) {
+	bestChain := s.repo.NewBestChain()
+	for num := headNum; num > 0; num-- {
+		remote, err := s.client.Block(strconv.FormatUint(uint64(num), 10))
+		if err != nil {
+			return 0, err
+		}
+		id, err := bestChain.GetBlockID(num)
+		if err != nil {
+			return 0, err
+		}
+		if id == remote.ID {
+			return num, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Source) download(ctx context.Context, handler comm.HandleBlockStream, from, to uint32) error {
+	g, ctx := errgroup.WithContext(ctx)
+	blocks := make(chan *block.Block, fetchBatchSize)
+
+	g.Go(func() error {
+		defer close(blocks)
+		return s.fetchBlocks(ctx, from, to, blocks)
+	})
+	g.Go(func() error {
+		return handler(ctx, blocks)
+	})
+	return g.Wait()
+}
+
+// fetchBlocks fetches blocks in the range in batches, and sends them in order.
+func (s *Source) fetchBlocks(ctx context.Context, from, to uint32, blocks chan<- *block.Block) error {
+	for num := uint64(from); num <= uint64(to); num += fetchBatchSize {
+		batch := make([]*block.Block, min(fetchBatchSize, uint64(to)-num+1))
+
+		g, gctx := errgroup.WithContext(ctx)
+		for i := range batch {
+			g.Go(func() (err error) {
+				if gctx.Err() != nil {
+					return gctx.Err()
+				}
+				batch[i], err = s.fetchBlock(uint32(num) + uint32(i))
+				return
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
+
+		for _, blk := range batch {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case blocks <- blk:
+			}
+		}
+	}
+	return nil
+}
+
+// fetchBlock fetches the raw header of the block, and then its raw transactions if any.
+func (s *Source) fetchBlock(num uint32) (*block.Block, error) {
+	raw, err := s.client.RawBlock(strconv.FormatUint(uint64(num), 10))
+	if err != nil {
+		return nil, errors.Wrapf(err, "get block %v", num)
+	}
+	data, err := hexutil.Decode(raw.Raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode block %v", num)
+	}
+	var header block.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return nil, errors.Wrapf(err, "decode block %v", num)
+	}
+	if header.Number() != num {
+		return nil, errors.Errorf("got block %v, want %v", header.Number(), num)
+	}
+	// warm up caches, as done for blocks synced from peers
+	id := header.ID()
+	_, _ = header.Beta()
+
+	if header.TxsRoot() == emptyTxsRoot {
+		return block.Compose(&header, nil), nil
+	}
+
+	summary, err := s.client.Block(id.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "get txs of block %v", num)
+	}
+	txs := make(tx.Transactions, 0, len(summary.Transactions))
+	for _, txID := range summary.Transactions {
+		rawTx, err := s.client.RawTransaction(&txID, thorclient.Revision(id.String()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "get tx %v", txID)
+		}
+		trx, err := rawTx.Decode()
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode tx %v", txID)
+		}
+		_ = trx.ID()
+		_, _ = trx.IntrinsicGas()
+		_, _ = trx.Delegator()
+		txs = append(txs, trx)
+	}
+	return block.Compose(&header, txs), nil
+}
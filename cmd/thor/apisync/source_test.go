@@ -0,0 +1,111 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package apisync
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newTrustedNode(t *testing.T) (*testchain.Chain, string) {
+	thorChain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	to := thor.BytesToAddress([]byte("to"))
+	for i := range 3 {
+		trx := tx.NewBuilder(tx.TypeLegacy).
+			ChainTag(thorChain.Repo().ChainTag()).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+			Build()
+		require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], tx.MustSign(trx, genesis.DevAccounts()[0].PrivateKey)))
+	}
+	require.NoError(t, thorChain.MintBlock(genesis.DevAccounts()[0]))
+
+	router := mux.NewRouter()
+	blocks.New(thorChain.Repo(), thorChain.Engine()).Mount(router, "/blocks")
+	transactions.New(thorChain.Repo(), nil).Mount(router, "/transactions")
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+	return thorChain, ts.URL
+}
+
+// collect returns the handler which collects blocks, and adds them to the repo.
+func collect(t *testing.T, repo *chain.Repository, collected *[]*block.Block) func(context.Context, <-chan *block.Block) error {
+	return func(_ context.Context, stream <-chan *block.Block) error {
+		for blk := range stream {
+			*collected = append(*collected, blk)
+			receipts := make(tx.Receipts, len(blk.Transactions()))
+			for i := range receipts {
+				receipts[i] = &tx.Receipt{}
+			}
+			require.NoError(t, repo.AddBlock(blk, receipts, 0, true))
+		}
+		return nil
+	}
+}
+
+func TestSync(t *testing.T) {
+	thorChain, url := newTrustedNode(t)
+	expected, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+
+	repo, err := chain.NewRepository(muxdb.NewMem(), thorChain.GenesisBlock())
+	require.NoError(t, err)
+
+	var collected []*block.Block
+	source := New(repo, url)
+	require.NoError(t, source.sync(context.Background(), collect(t, repo, &collected)))
+
+	require.Len(t, collected, len(expected)-1)
+	for i, blk := range collected {
+		assert.Equal(t, expected[i+1].Header().ID(), blk.Header().ID())
+		assert.Equal(t, expected[i+1].Header().TxsRoot(), blk.Transactions().RootHash())
+	}
+
+	// nothing to pull once synced
+	collected = nil
+	require.NoError(t, source.sync(context.Background(), collect(t, repo, &collected)))
+	assert.Empty(t, collected)
+}
+
+func TestSync_Fork(t *testing.T) {
+	thorChain, url := newTrustedNode(t)
+	expected, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+
+	repo, err := chain.NewRepository(muxdb.NewMem(), thorChain.GenesisBlock())
+	require.NoError(t, err)
+	// a local side block at #1, with lower total score than the remote chain
+	side := new(block.Builder).ParentID(thorChain.GenesisBlock().Header().ID()).Timestamp(1).Build()
+	require.NoError(t, repo.AddBlock(side, nil, 0, true))
+
+	var collected []*block.Block
+	source := New(repo, url)
+	require.NoError(t, source.sync(context.Background(), collect(t, repo, &collected)))
+
+	require.Len(t, collected, len(expected)-1)
+	assert.Equal(t, expected[1].Header().ID(), collected[0].Header().ID())
+	assert.Equal(t, expected[len(expected)-1].Header().ID(), repo.BestBlockSummary().Header.ID())
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package backup takes point-in-time backups of a running node's databases, and restores them.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+)
+
+var logger = log.WithContext("pkg", "backup")
+
+// names of files in a backup, the same as in the instance dir.
+const (
+	ManifestFile = "manifest.json"
+	MainDBName   = "main.db"
+	LogDBName    = "logs-v2.db"
+	FreezerName  = "ancient"
+	archiveName  = "backup.tar.gz"
+)
+
+// stages of a backup.
+const (
+	StageMainDB   = "main-db"  // progress in entries, total unknown
+	StageLogDB    = "log-db"   // progress in pages
+	StageFreezer  = "freezer"  // progress in bytes
+	StageCompress = "compress" // progress in bytes
+)
+
+// Manifest describes a backup.
+type Manifest struct {
+	GenesisID    thor.Bytes32 `json:"genesisID"`
+	BestBlockID  thor.Bytes32 `json:"bestBlockID"`
+	BestBlockNum uint32       `json:"bestBlockNum"`
+	Timestamp    uint64       `json:"timestamp"`
+	Compressed   bool         `json:"compressed"`
+}
+
+// Progress is the progress of a backup stage.
+type Progress struct {
+	Stage  string
+	Copied uint64
+	Total  uint64 // zero if unknown
+}
+
+// Source is the databases to back up.
+type Source struct {
+	MainDB     *muxdb.MuxDB
+	LogDB      *logdb.LogDB // nil to skip
+	FreezerDir string       // empty to skip
+	Repo       *chain.Repository
+}
+
+// Run backs up the source into dir, which must not exist or be empty. If compress is set, databases
+// are packed into a gzipped tarball. The manifest is always written in plain.
+//
+// The main db snapshot is the point in time. The log db and the freezer are copied afterwards, which
+// may have newer blocks, and they are truncated to the best block of the manifest on restore.
+func Run(ctx context.Context, src *Source, dir string, compress bool, progress func(Progress)) (*Manifest, error) {
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+	if err := makeEmptyDir(dir); err != nil {
+		return nil, err
+	}
+
+	dataDir := dir
+	if compress {
+		dataDir = filepath.Join(dir, "data.tmp")
+		defer os.RemoveAll(dataDir)
+	}
+
+	if err := src.MainDB.Backup(ctx, filepath.Join(dataDir, MainDBName), func(entries, _ uint64) {
+		progress(Progress{Stage: StageMainDB, Copied: entries})
+	}); err != nil {
+		return nil, errors.Wrap(err, "backup main db")
+	}
+
+	// the best block is read from the backup, which is the point in time
+	manifest, err := readManifest(filepath.Join(dataDir, MainDBName), src.Repo.GenesisBlock())
+	if err != nil {
+		return nil, err
+	}
+
+	if src.LogDB != nil {
+		if err := src.LogDB.Backup(ctx, filepath.Join(dataDir, LogDBName), func(copied, total int) {
+			progress(Progress{Stage: StageLogDB, Copied: uint64(copied), Total: uint64(total)})
+		}); err != nil {
+			return nil, errors.Wrap(err, "backup log db")
+		}
+	}
+
+	if src.FreezerDir != "" {
+		if err := copyFreezer(ctx, src.FreezerDir, filepath.Join(dataDir, FreezerName), progress); err != nil {
+			return nil, errors.Wrap(err, "backup freezer")
+		}
+	}
+
+	if compress {
+		if err := compressDir(ctx, dataDir, filepath.Join(dir, archiveName), progress); err != nil {
+			return nil, errors.Wrap(err, "compress")
+		}
+		manifest.Compressed = true
+	}
+
+	if err := writeManifest(filepath.Join(dir, ManifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// readManifest builds the manifest from the main db at path.
+func readManifest(path string, genesis *block.Block) (*Manifest, error) {
+	db, err := muxdb.Open(path, &muxdb.Options{})
+	if err != nil {
+		return nil, errors.Wrap(err, "open main db")
+	}
+	defer db.Close()
+
+	repo, err := chain.NewRepository(db, genesis)
+	if err != nil {
+		return nil, errors.Wrap(err, "load chain")
+	}
+	best := repo.BestBlockSummary().Header
+	return &Manifest{
+		GenesisID:    genesis.Header().ID(),
+		BestBlockID:  best.ID(),
+		BestBlockNum: best.Number(),
+		Timestamp:    uint64(time.Now().Unix()),
+	}, nil
+}
+
+func makeEmptyDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return errors.Errorf("dir [%v] not empty", dir)
+	}
+	return nil
+}
+
+func writeManifest(path string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// ReadManifest reads the manifest of the backup in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(err, "decode manifest")
+	}
+	return &m, nil
+}
+
+// copyFreezer copies freezer files. Index files are copied before segment files, so that copied segments
+// cover copied indexes, while the freezer keeps being appended. Items appended during copying are dropped
+// on opening, if not completely copied.
+func copyFreezer(ctx context.Context, src, dst string, progress func(Progress)) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var (
+		names []string
+		total uint64
+	)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		names = append(names, e.Name())
+		total += uint64(info.Size())
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return strings.HasSuffix(names[i], ".idx") && !strings.HasSuffix(names[j], ".idx")
+	})
+
+	if err := os.MkdirAll(dst, 0o700); err != nil {
+		return err
+	}
+	var copied uint64
+	for _, name := range names {
+		n, err := copyFile(ctx, filepath.Join(src, name), filepath.Join(dst, name))
+		if err != nil {
+			return err
+		}
+		copied += uint64(n)
+		progress(Progress{Stage: StageFreezer, Copied: copied, Total: max(total, copied)})
+	}
+	return nil
+}
+
+func copyFile(ctx context.Context, src, dst string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	n, err := io.Copy(out, &ctxReader{ctx, in})
+	if err != nil {
+		return n, err
+	}
+	return n, out.Sync()
+}
+
+// ctxReader aborts reading when the context is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package backup
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newTestSource(t *testing.T) (*Source, *testchain.Chain) {
+	dir := t.TempDir()
+	db, err := muxdb.Open(filepath.Join(dir, MainDBName), &muxdb.Options{
+		TrieHistPartitionFactor:    1,
+		TrieDedupedPartitionFactor: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	logDB, err := logdb.New(filepath.Join(dir, LogDBName))
+	require.NoError(t, err)
+	t.Cleanup(func() { logDB.Close() })
+
+	forkConfig := testchain.DefaultForkConfig
+	gene := genesis.NewDevnetWithConfig(genesis.DevConfig{ForkConfig: &forkConfig})
+	stater := state.NewStater(db)
+	b0, _, _, err := gene.Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, b0)
+	require.NoError(t, err)
+
+	freezerDir := filepath.Join(dir, FreezerName)
+	f, err := chain.OpenFreezer(freezerDir)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	require.NoError(t, repo.AttachFreezer(f))
+
+	tchain := testchain.New(db, gene, bft.NewMockedEngine(b0.Header().ID()), repo, stater, b0, logDB, &forkConfig)
+	mintAndFreeze(t, tchain, 3)
+
+	return &Source{
+		MainDB:     db,
+		LogDB:      logDB,
+		FreezerDir: freezerDir,
+		Repo:       repo,
+	}, tchain
+}
+
+// mintAndFreeze mints n blocks, and freezes blocks except the best one.
+func mintAndFreeze(t *testing.T, tchain *testchain.Chain, n int) {
+	to := thor.BytesToAddress([]byte("to"))
+	for range n {
+		require.NoError(t, tchain.MintClauses(genesis.DevAccounts()[0], []*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}))
+	}
+	best := tchain.Repo().BestBlockSummary().Header
+	require.NoError(t, tchain.Repo().Freeze(context.Background(), best.ID(), best.Number()))
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	src, _ := newTestSource(t)
+	best := src.Repo.BestBlockSummary().Header
+
+	for _, compress := range []bool{false, true} {
+		dir := filepath.Join(t.TempDir(), "backup")
+		stages := make(map[string]bool)
+		manifest, err := Run(context.Background(), src, dir, compress, func(p Progress) {
+			stages[p.Stage] = true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, best.ID(), manifest.BestBlockID)
+		assert.Equal(t, best.Number(), manifest.BestBlockNum)
+		assert.Equal(t, compress, manifest.Compressed)
+		assert.True(t, stages[StageMainDB])
+		assert.True(t, stages[StageLogDB])
+		assert.True(t, stages[StageFreezer])
+		assert.Equal(t, compress, stages[StageCompress])
+
+		read, err := ReadManifest(dir)
+		require.NoError(t, err)
+		assert.Equal(t, manifest, read)
+
+		instanceDir := t.TempDir()
+		restored, err := Restore(dir, instanceDir, src.Repo.GenesisBlock())
+		require.NoError(t, err)
+		assert.Equal(t, manifest, restored)
+
+		assertRestored(t, instanceDir, src.Repo.GenesisBlock(), best)
+
+		// databases exist
+		_, err = Restore(dir, instanceDir, src.Repo.GenesisBlock())
+		assert.ErrorContains(t, err, "exists in instance dir")
+	}
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	_, err := Run(context.Background(), src, dir, false, nil)
+	require.NoError(t, err)
+
+	// not empty
+	_, err = Run(context.Background(), src, dir, false, nil)
+	assert.ErrorContains(t, err, "not empty")
+
+	// another network
+	other := genesis.NewDevnetWithConfig(genesis.DevConfig{ForkConfig: &testchain.DefaultForkConfig, LaunchTime: uint64(time.Now().Unix())})
+	otherBlock, _, _, err := other.Build(state.NewStater(muxdb.NewMem()))
+	require.NoError(t, err)
+	_, err = Restore(dir, t.TempDir(), otherBlock)
+	assert.ErrorContains(t, err, "genesis mismatch")
+}
+
+// assertRestored checks that databases restored in the instance dir are consistent at the best block.
+func assertRestored(t *testing.T, instanceDir string, genesis *block.Block, best *block.Header) {
+	db, err := muxdb.Open(filepath.Join(instanceDir, MainDBName), &muxdb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err := chain.NewRepository(db, genesis)
+	require.NoError(t, err)
+	assert.Equal(t, best.ID(), repo.BestBlockSummary().Header.ID())
+
+	f, err := chain.OpenFreezer(filepath.Join(instanceDir, FreezerName))
+	require.NoError(t, err)
+	defer f.Close()
+	assert.Equal(t, best.Number(), f.Frozen())
+	require.NoError(t, repo.AttachFreezer(f))
+	_, err = repo.GetBlock(best.ParentID())
+	assert.NoError(t, err)
+
+	logDB, err := logdb.New(filepath.Join(instanceDir, LogDBName))
+	require.NoError(t, err)
+	defer logDB.Close()
+	newest, err := logDB.NewestBlockID()
+	require.NoError(t, err)
+	assert.Equal(t, best.ID(), newest)
+}
+
+func TestRestoreWhileWriting(t *testing.T) {
+	src, tchain := newTestSource(t)
+	best := src.Repo.BestBlockSummary().Header
+
+	for _, compress := range []bool{false, true} {
+		dir := filepath.Join(t.TempDir(), "backup")
+		// blocks are written and frozen after the main db snapshot, before the log db and the freezer are copied
+		minted := false
+		manifest, err := Run(context.Background(), src, dir, compress, func(p Progress) {
+			if p.Stage == StageMainDB && !minted {
+				minted = true
+				mintAndFreeze(t, tchain, 2)
+			}
+		})
+		require.NoError(t, err)
+		require.True(t, minted)
+		assert.Equal(t, best.ID(), manifest.BestBlockID)
+
+		instanceDir := t.TempDir()
+		_, err = Restore(dir, instanceDir, src.Repo.GenesisBlock())
+		require.NoError(t, err)
+		assertRestored(t, instanceDir, src.Repo.GenesisBlock(), best)
+
+		best = src.Repo.BestBlockSummary().Header
+	}
+}
+
+func TestService(t *testing.T) {
+	src, _ := newTestSource(t)
+	s := NewService(src)
+	defer s.Close()
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	require.NoError(t, s.Start(dir, true))
+
+	var status Status
+	require.Eventually(t, func() bool {
+		status = s.Status()
+		return !status.Running
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Empty(t, status.Error)
+	assert.Equal(t, dir, status.Dir)
+	require.NotNil(t, status.Manifest)
+	assert.Equal(t, src.Repo.BestBlockSummary().Header.ID(), status.Manifest.BestBlockID)
+
+	// fails as the dir is not empty
+	require.NoError(t, s.Start(dir, true))
+	require.Eventually(t, func() bool {
+		status = s.Status()
+		return !status.Running
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Contains(t, status.Error, "not empty")
+	assert.Nil(t, status.Manifest)
+}
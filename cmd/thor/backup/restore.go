@@ -0,0 +1,144 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+)
+
+// Restore restores the backup in dir into the instance dir, which must hold no databases.
+// The backup must be of the given genesis, and the restored main db must have the best block
+// recorded in the manifest. Blocks beyond it are dropped from the restored log db and freezer.
+func Restore(dir, instanceDir string, genesis *block.Block) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "read manifest")
+	}
+	if manifest.GenesisID != genesis.Header().ID() {
+		return nil, errors.Errorf("genesis mismatch, backup %v, want %v", manifest.GenesisID, genesis.Header().ID())
+	}
+
+	names := []string{MainDBName, LogDBName, FreezerName}
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(instanceDir, name)); err == nil {
+			return nil, errors.Errorf("[%v] exists in instance dir", name)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	// restore into a temp dir first, to leave nothing behind on failure
+	tmpDir := filepath.Join(instanceDir, "restore.tmp")
+	if err := makeEmptyDir(tmpDir); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if manifest.Compressed {
+		if err := extract(filepath.Join(dir, archiveName), tmpDir); err != nil {
+			return nil, errors.Wrap(err, "extract")
+		}
+	} else {
+		for _, name := range names {
+			if err := copyTree(filepath.Join(dir, name), filepath.Join(tmpDir, name)); err != nil {
+				return nil, errors.Wrapf(err, "copy [%v]", name)
+			}
+		}
+	}
+
+	if err := truncate(tmpDir, manifest, genesis); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if err := os.Rename(filepath.Join(tmpDir, name), filepath.Join(instanceDir, name)); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// truncate drops blocks beyond the main db from the log db and the freezer in dir, which are copied
+// after the main db snapshot, while the node keeps writing blocks.
+func truncate(dir string, manifest *Manifest, genesis *block.Block) error {
+	db, err := muxdb.Open(filepath.Join(dir, MainDBName), &muxdb.Options{})
+	if err != nil {
+		return errors.Wrap(err, "open main db")
+	}
+	defer db.Close()
+
+	repo, err := chain.NewRepository(db, genesis)
+	if err != nil {
+		return errors.Wrap(err, "load chain")
+	}
+	if best := repo.BestBlockSummary().Header.ID(); best != manifest.BestBlockID {
+		return errors.Errorf("best block mismatch, restored %v, want %v", best, manifest.BestBlockID)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, FreezerName)); err == nil {
+		f, err := chain.OpenFreezer(filepath.Join(dir, FreezerName))
+		if err != nil {
+			return errors.Wrap(err, "open freezer")
+		}
+		defer f.Close()
+		// blocks frozen after the snapshot are dropped on attaching
+		if err := repo.AttachFreezer(f); err != nil {
+			return errors.Wrap(err, "truncate freezer")
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, LogDBName)); err == nil {
+		logDB, err := logdb.New(filepath.Join(dir, LogDBName))
+		if err != nil {
+			return errors.Wrap(err, "open log db")
+		}
+		defer logDB.Close()
+		// logs of forked blocks below are resynced by the node on startup
+		w := logDB.NewWriter()
+		if err := w.Truncate(manifest.BestBlockNum + 1); err != nil {
+			return errors.Wrap(err, "truncate log db")
+		}
+		if err := w.Commit(); err != nil {
+			return errors.Wrap(err, "truncate log db")
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// copyTree copies the file or dir at src to dst. It does nothing if src not exists.
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && name == src {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(src, name)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o700)
+		}
+		_, err = copyFile(context.Background(), name, target)
+		return err
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package backup
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/co"
+)
+
+// ErrRunning is returned when starting a backup while another one is running.
+var ErrRunning = errors.New("backup running")
+
+// Status is the status of the last backup.
+type Status struct {
+	Running  bool
+	Dir      string
+	Progress Progress
+	Manifest *Manifest // set when succeeded
+	Error    string
+}
+
+// Service runs backups in background, one at a time.
+type Service struct {
+	src    *Source
+	ctx    context.Context
+	cancel func()
+	goes   co.Goes
+	mu     sync.Mutex
+	status Status
+}
+
+// NewService creates a backup service.
+func NewService(src *Source) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		src:    src,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start starts a backup into dir.
+func (s *Service) Start(dir string, compress bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status.Running {
+		return ErrRunning
+	}
+	s.status = Status{Running: true, Dir: dir}
+
+	s.goes.Go(func() {
+		logger.Info("backup started", "dir", dir, "compress", compress)
+		manifest, err := Run(s.ctx, s.src, dir, compress, func(p Progress) {
+			s.mu.Lock()
+			s.status.Progress = p
+			s.mu.Unlock()
+		})
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.status.Running = false
+		if err != nil {
+			logger.Warn("backup failed", "dir", dir, "err", err)
+			s.status.Error = err.Error()
+			return
+		}
+		logger.Info("backup done", "dir", dir, "best", manifest.BestBlockNum)
+		s.status.Manifest = manifest
+	})
+	return nil
+}
+
+// Status returns the status of the last backup.
+func (s *Service) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Close aborts the running backup and waits for it to exit.
+func (s *Service) Close() {
+	s.cancel()
+	s.goes.Wait()
+}
@@ -0,0 +1,520 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/chain/freezer"
+	"github.com/vechain/thor/v2/cmd/thor/backup"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+)
+
+// storeNames lists the named stores of the main database, to classify keys on inspection.
+var storeNames = []string{
+	"muxdb.props",
+	"chain.hdr",
+	"chain.body",
+	"chain.props",
+	"chain.heads",
+	"chain.txi",
+	"chain.bloom",
+	"bft.engine",
+	"state.code",
+	"state.snap",
+	"witness",
+	"pruner.archive",
+	"pruner.props",
+}
+
+// dbInstance holds databases of the instance dir, opened for offline maintenance.
+type dbInstance struct {
+	dir     string
+	mainDB  *muxdb.MuxDB
+	logDB   *logdb.LogDB
+	freezer *freezer.Freezer
+	repo    *chain.Repository
+}
+
+// openDBInstance opens databases of the instance dir read-only, so that they are left untouched by inspections,
+// except the log db if writeLogs is set.
+func openDBInstance(ctx *cli.Context, writeLogs bool) (_ *dbInstance, err error) {
+	gene, _, err := selectGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return nil, err
+	}
+
+	inst := &dbInstance{dir: instanceDir}
+	defer func() {
+		if err != nil {
+			inst.Close()
+		}
+	}()
+
+	if inst.mainDB, err = openMainDBReadOnly(ctx, instanceDir); err != nil {
+		return nil, err
+	}
+	if writeLogs {
+		inst.logDB, err = openLogDB(instanceDir)
+	} else {
+		inst.logDB, err = openLogDBReadOnly(instanceDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// only the genesis block is needed, the main db is not written
+	genesisBlock, _, _, err := gene.Build(state.NewStater(muxdb.NewMem()))
+	if err != nil {
+		return nil, errors.Wrap(err, "build genesis block")
+	}
+	if inst.repo, err = chain.NewRepository(inst.mainDB, genesisBlock); err != nil {
+		return nil, errors.Wrap(err, "load block chain")
+	}
+	if inst.freezer, err = attachFreezerReadOnly(inst.repo, instanceDir); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func (d *dbInstance) Close() {
+	if d.freezer != nil {
+		d.freezer.Close()
+	}
+	if d.logDB != nil {
+		d.logDB.Close()
+	}
+	if d.mainDB != nil {
+		d.mainDB.Close()
+	}
+}
+
+func dbInspectAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	inst, err := openDBInstance(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer inst.Close()
+
+	fmt.Printf("Instance dir [ %v ]\n", inst.dir)
+	fmt.Println(">> Inspecting main database <<")
+	stats, err := inst.mainDB.Inspect(exitSignal, storeNames, func(n uint64) {
+		fmt.Printf("\r%v keys walked", n)
+	})
+	if err != nil {
+		return errors.Wrap(err, "inspect main database")
+	}
+	fmt.Print("\r")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "SPACE\tKEYS\tSIZE\t")
+	var total muxdb.SpaceStats
+	for _, s := range stats {
+		fmt.Fprintf(w, "%v\t%v\t%v\t\n", s.Name, s.Count, common.StorageSize(s.Size))
+		total.Count += s.Count
+		total.Size += s.Size
+	}
+	fmt.Fprintf(w, "total\t%v\t%v\t\n", total.Count, common.StorageSize(total.Size))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println(">> Inspecting freezer <<")
+	size, err := dirSize(filepath.Join(inst.dir, "ancient"))
+	if err != nil {
+		return errors.Wrap(err, "inspect freezer")
+	}
+	fmt.Printf("frozen blocks %v, size %v\n", inst.repo.Frozen(), common.StorageSize(size))
+
+	fmt.Println(">> Inspecting log database <<")
+	tables, size, err := inst.logDB.Stats(exitSignal)
+	if err != nil {
+		return errors.Wrap(err, "inspect log database")
+	}
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "TABLE\tROWS\t")
+	for _, t := range tables {
+		fmt.Fprintf(w, "%v\t%v\t\n", t.Name, t.Rows)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("size %v\n", common.StorageSize(size))
+	return nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func dbVerifyAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	inst, err := openDBInstance(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer inst.Close()
+
+	best := inst.repo.BestBlockSummary()
+	heights, err := parseBlockNumbers(ctx.String(dbVerifyStateAtFlag.Name), best.Header.Number())
+	if err != nil {
+		return errors.Wrap(err, "parse state-at flag")
+	}
+
+	fmt.Printf("Instance dir [ %v ]\n", inst.dir)
+	problems, err := verifyChain(exitSignal, inst.repo)
+	if err != nil {
+		return errors.Wrap(err, "verify chain")
+	}
+
+	bestChain := inst.repo.NewBestChain()
+	for _, h := range heights {
+		fmt.Printf(">> Verifying state at #%v <<\n", h)
+		summary, err := bestChain.GetBlockSummary(h)
+		if err != nil {
+			return errors.Wrapf(err, "get block #%v", h)
+		}
+		res, err := state.Verify(exitSignal, inst.mainDB, summary.Root())
+		if err != nil {
+			if exitSignal.Err() != nil {
+				return exitSignal.Err()
+			}
+			problems++
+			fmt.Printf("state unreachable: %v\n", err)
+			continue
+		}
+		fmt.Printf("accounts %v, contracts %v, storage slots %v\n", res.Accounts, res.Contracts, res.Slots)
+	}
+
+	if !ctx.Bool(dbSkipLogsFlag.Name) {
+		pos, err := seekLogDBSyncPosition(inst.repo, inst.logDB)
+		if err != nil {
+			return errors.Wrap(err, "seek log db sync position")
+		}
+		if pos > 0 {
+			if err := verifyLogDB(exitSignal, pos-1, inst.repo, inst.logDB); err != nil {
+				if exitSignal.Err() != nil {
+					return exitSignal.Err()
+				}
+				problems++
+				fmt.Printf("log db inconsistent: %v\n", err)
+			}
+		}
+		if pos < best.Header.Number() {
+			fmt.Printf("log db is behind the best block #%v\n", best.Header.Number())
+		}
+	}
+
+	if problems > 0 {
+		return errors.Errorf("%v problems found", problems)
+	}
+	fmt.Println("no problem found")
+	return nil
+}
+
+func dbReindexLogsAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	// logs are rewritten, while blocks are only read
+	inst, err := openDBInstance(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer inst.Close()
+
+	best := inst.repo.BestBlockSummary().Header
+	from, to, err := parseBlockRange(ctx.String(dbRangeFlag.Name), best.Number())
+	if err != nil {
+		return errors.Wrap(err, "parse range flag")
+	}
+
+	// logs before the oldest block are pruned, and blocks after the sync position are left to the node,
+	// to not leave a gap in the log db.
+	oldest, err := inst.logDB.OldestBlockNum()
+	if err != nil {
+		return err
+	}
+	pos, err := seekLogDBSyncPosition(inst.repo, inst.logDB)
+	if err != nil {
+		return errors.Wrap(err, "seek log db sync position")
+	}
+	from = max(from, oldest, 1)
+	if pos > 0 {
+		to = min(to, pos-1)
+	}
+	if pos == 0 || from > to {
+		return errors.New("no synced logs in the range")
+	}
+
+	mode := reindexRebuild
+	if ctx.Bool(dbReindexVerifyFlag.Name) {
+		mode = reindexRepair
+		if ctx.Bool(dbDryRunFlag.Name) {
+			mode = reindexVerify
+		}
+	}
+
+	fmt.Printf("Instance dir [ %v ]\n", inst.dir)
+	switch mode {
+	case reindexRebuild:
+		fmt.Printf(">> Rebuilding logs of blocks #%v to #%v <<\n", from, to)
+	case reindexRepair:
+		fmt.Printf(">> Repairing logs of blocks #%v to #%v <<\n", from, to)
+	default:
+		fmt.Printf(">> Verifying logs of blocks #%v to #%v <<\n", from, to)
+	}
+	inconsistent, err := reindexLogDB(exitSignal, inst.repo, inst.logDB, from, to, mode)
+	if err != nil {
+		return err
+	}
+	switch {
+	case mode == reindexRebuild:
+		fmt.Println("logs rebuilt")
+	case inconsistent == 0:
+		fmt.Println("no inconsistent block found")
+	case mode == reindexRepair:
+		fmt.Printf("%v inconsistent blocks repaired\n", inconsistent)
+	default:
+		return errors.Errorf("%v inconsistent blocks found", inconsistent)
+	}
+	return nil
+}
+
+// parseBlockNumbers parses comma separated block numbers, where "best" stands for the best block.
+func parseBlockNumbers(s string, best uint32) ([]uint32, error) {
+	var nums []uint32
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "best" {
+			nums = append(nums, best)
+			continue
+		}
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if uint32(n) > best {
+			return nil, errors.Errorf("block #%v beyond the best block", n)
+		}
+		nums = append(nums, uint32(n))
+	}
+	slices.Sort(nums)
+	return slices.Compact(nums), nil
+}
+
+// verifyChain walks the best chain, and checks that bodies and receipts of blocks are present
+// and match roots in headers. It returns the count of problems found.
+func verifyChain(ctx context.Context, repo *chain.Repository) (int, error) {
+	best := repo.BestBlockSummary()
+	bestNum := best.Header.Number()
+	fmt.Println(">> Verifying chain <<")
+
+	pb := pb.New64(int64(bestNum)).
+		SetMaxWidth(90).
+		Start()
+	defer func() { pb.NotPrint = true }()
+
+	var (
+		problems int
+		report   = func(num uint32, format string, args ...any) {
+			problems++
+			fmt.Printf("\nblock #%v: %v\n", num, fmt.Sprintf(format, args...))
+		}
+		bestChain = repo.NewChain(best.Header.ID())
+	)
+	for num := uint32(0); num <= bestNum; num++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		summary, err := bestChain.GetBlockSummary(num)
+		if err != nil {
+			return 0, errors.Wrapf(err, "get block summary #%v", num)
+		}
+		header := summary.Header
+
+		if txs, err := repo.GetBlockTransactions(header.ID()); err != nil {
+			report(num, "txs unavailable: %v", err)
+		} else if root := txs.RootHash(); root != header.TxsRoot() {
+			report(num, "txs root mismatch, want %v, got %v", header.TxsRoot(), root)
+		} else {
+			for i, tx := range txs {
+				if tx.ID() != summary.Txs[i] {
+					report(num, "tx #%v id mismatch, want %v, got %v", i, summary.Txs[i], tx.ID())
+				}
+			}
+		}
+
+		if receipts, err := repo.GetBlockReceipts(header.ID()); err != nil {
+			report(num, "receipts unavailable: %v", err)
+		} else if len(receipts) != len(summary.Txs) {
+			report(num, "receipts count mismatch, want %v, got %v", len(summary.Txs), len(receipts))
+		} else if root := receipts.RootHash(); root != header.ReceiptsRoot() {
+			report(num, "receipts root mismatch, want %v, got %v", header.ReceiptsRoot(), root)
+		}
+
+		// recreate the chain to avoid the internal trie holds too many nodes.
+		if num > 0 && num%10000 == 0 {
+			bestChain = repo.NewChain(best.Header.ID())
+		}
+		pb.Set64(int64(num))
+	}
+	pb.Finish()
+	return problems, nil
+}
+
+func dbBackupAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	dir := ctx.String(dbBackupDirFlag.Name)
+	if dir == "" {
+		return errors.New("backup-dir flag required")
+	}
+	if !filepath.IsAbs(dir) {
+		// resolved by the node, which may run in another working dir
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		dir = abs
+	}
+
+	url := "http://" + ctx.String(adminAddrFlag.Name) + "/admin/backup"
+	status, err := requestBackup(exitSignal, http.MethodPost, url, &api.BackupRequest{
+		Dir:      dir,
+		Compress: ctx.Bool(dbBackupCompressFlag.Name),
+	})
+	if err != nil {
+		return errors.Wrap(err, "start backup")
+	}
+
+	fmt.Printf("Backing up into [ %v ]\n", status.Dir)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for status.Running {
+		select {
+		case <-exitSignal.Done():
+			// the backup goes on in the node
+			return exitSignal.Err()
+		case <-ticker.C:
+		}
+		if status, err = requestBackup(exitSignal, http.MethodGet, url, nil); err != nil {
+			return errors.Wrap(err, "query backup status")
+		}
+		if status.Total > 0 {
+			fmt.Printf("\r%-10v %v/%v", status.Stage, status.Copied, status.Total)
+		} else {
+			fmt.Printf("\r%-10v %v", status.Stage, status.Copied)
+		}
+	}
+	fmt.Println()
+
+	if status.Error != "" {
+		return errors.Errorf("backup failed: %v", status.Error)
+	}
+	fmt.Printf("backup done, best block #%v %v\n", status.Manifest.BestBlockNum, status.Manifest.BestBlockID)
+	return nil
+}
+
+func requestBackup(ctx context.Context, method, url string, body *api.BackupRequest) (*api.BackupStatus, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%v: %v", res.Status, strings.TrimSpace(string(data)))
+	}
+	var status api.BackupStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func dbRestoreAction(ctx *cli.Context) error {
+	dir := ctx.String(dbBackupDirFlag.Name)
+	if dir == "" {
+		return errors.New("backup-dir flag required")
+	}
+	gene, _, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	genesisBlock, _, _, err := gene.Build(state.NewStater(muxdb.NewMem()))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+
+	fmt.Printf("Restoring [ %v ] into [ %v ]\n", dir, instanceDir)
+	manifest, err := backup.Restore(dir, instanceDir, genesisBlock)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("restored, best block #%v %v\n", manifest.BestBlockNum, manifest.BestBlockID)
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestParseBlockNumbers(t *testing.T) {
+	nums, err := parseBlockNumbers("best, 10,5,10", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{5, 10, 100}, nums)
+
+	nums, err = parseBlockNumbers("", 100)
+	require.NoError(t, err)
+	assert.Empty(t, nums)
+
+	_, err = parseBlockNumbers("101", 100)
+	assert.Error(t, err)
+	_, err = parseBlockNumbers("x", 100)
+	assert.Error(t, err)
+}
+
+func TestVerifyChain(t *testing.T) {
+	b0 := new(block.Builder).
+		ParentID(thor.Bytes32{0xff, 0xff, 0xff, 0xff}).
+		ReceiptsRoot(tx.Receipts(nil).RootHash()).
+		Build()
+	repo, err := chain.NewRepository(muxdb.NewMem(), b0)
+	require.NoError(t, err)
+
+	receipts := tx.Receipts{{GasUsed: 1}}
+	b1 := new(block.Builder).
+		ParentID(b0.Header().ID()).
+		Transaction(new(tx.Builder).Build()).
+		ReceiptsRoot(receipts.RootHash()).
+		Build()
+	require.NoError(t, repo.AddBlock(b1, receipts, 0, true))
+
+	problems, err := verifyChain(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, 0, problems)
+
+	// receipts root mismatch
+	b2 := new(block.Builder).ParentID(b1.Header().ID()).Build()
+	require.NoError(t, repo.AddBlock(b2, nil, 0, true))
+
+	problems, err = verifyChain(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, 1, problems)
+}
+
+// newTestContext returns the cli context with the flags parsed from args.
+func newTestContext(t *testing.T, flags []cli.Flag, args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range flags {
+		f.Apply(set)
+	}
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(nil, set, nil)
+}
+
+// newTestInstance initializes the instance dir of the main network in the data dir, in the way the node does, and
+// keeps databases opened by the node until the test ends.
+func newTestInstance(t *testing.T, dataDir string) (instanceDir string, repo *chain.Repository) {
+	ctx := newTestContext(t, []cli.Flag{networkFlag, dataDirFlag}, "--network", "main", "--data-dir", dataDir)
+	gene, _, err := selectGenesis(ctx)
+	require.NoError(t, err)
+	instanceDir, err = makeInstanceDir(ctx, gene)
+	require.NoError(t, err)
+
+	mainDB, err := openMainDB(ctx, instanceDir)
+	require.NoError(t, err)
+	t.Cleanup(func() { mainDB.Close() })
+	logDB, err := openLogDB(instanceDir)
+	require.NoError(t, err)
+	t.Cleanup(func() { logDB.Close() })
+	repo, err = initChainRepository(gene, mainDB, logDB)
+	require.NoError(t, err)
+	freezer, err := attachFreezer(repo, instanceDir)
+	require.NoError(t, err)
+	t.Cleanup(func() { freezer.Close() })
+	return instanceDir, repo
+}
+
+// dirModTimes returns modification times of files in the dir.
+func dirModTimes(t *testing.T, dir string) map[string]int64 {
+	times := make(map[string]int64)
+	require.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		times[path] = info.ModTime().UnixNano()
+		return nil
+	}))
+	return times
+}
+
+func TestOpenDBInstance(t *testing.T) {
+	dataDir := t.TempDir()
+	instanceDir, repo := newTestInstance(t, dataDir)
+	ctx := newTestContext(t, []cli.Flag{networkFlag, dataDirFlag}, "--network", "main", "--data-dir", dataDir)
+
+	// opened while the node is running, and nothing is written
+	before := dirModTimes(t, instanceDir)
+	inst, err := openDBInstance(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, repo.BestBlockSummary().Header.ID(), inst.repo.BestBlockSummary().Header.ID())
+	inst.Close()
+	assert.Equal(t, before, dirModTimes(t, instanceDir))
+}
@@ -6,9 +6,12 @@
 package main
 
 import (
+	"time"
+
 	cli "gopkg.in/urfave/cli.v1"
 
 	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/txpool"
 )
 
 var (
@@ -146,13 +149,21 @@ var (
 	}
 	skipLogsFlag = cli.BoolFlag{
 		Name:  "skip-logs",
-		Usage: "skip writing event|transfer logs (/logs API will be disabled)",
+		Usage: "skip writing event|transfer logs (/logs API falls back to slower scanning of blocks)",
 	}
 	verifyLogsFlag = cli.BoolFlag{
 		Name:   "verify-logs",
 		Usage:  "verify log db at startup",
 		Hidden: true,
 	}
+	logRetentionBlocksFlag = cli.Uint64Flag{
+		Name:  "log-retention-blocks",
+		Usage: "retain event|transfer logs of the latest N blocks only (0 to retain all)",
+	}
+	logRetentionAgeFlag = cli.DurationFlag{
+		Name:  "log-retention-age",
+		Usage: "retain event|transfer logs of blocks within the age only, e.g. 2160h for 90 days (0 to retain all)",
+	}
 	cacheFlag = cli.Uint64Flag{
 		Name:  "cache",
 		Usage: "megabytes of ram allocated to trie nodes cache",
@@ -162,6 +173,18 @@ var (
 		Name:  "disable-pruner",
 		Usage: "disable state pruner to keep all history",
 	}
+	archiveSpacingFlag = cli.Uint64Flag{
+		Name:  "archive-spacing",
+		Usage: "retain states every N blocks while pruning, to regenerate pruned states for API queries (0 to disable)",
+	}
+	recordWitnessFlag = cli.BoolFlag{
+		Name:  "record-witness",
+		Usage: "record, save and serve witnesses of trunk blocks, for stateless verification",
+	}
+	disableSnapshotFlag = cli.BoolFlag{
+		Name:  "disable-state-snapshot",
+		Usage: "disable flat state snapshot, which speeds up state reads at recent blocks",
+	}
 	enableMetricsFlag = cli.BoolFlag{
 		Name:  "enable-metrics",
 		Usage: "enables metrics collection",
@@ -185,6 +208,29 @@ var (
 		Value: 128,
 		Usage: "set tx limit per account in pool",
 	}
+	txPoolPriorityFeeBumpFlag = cli.Uint64Flag{
+		Name:  "txpool-priority-fee-bump",
+		Value: 10,
+		Usage: "min percentage to bump max priority fee per gas (or gas price coef) to replace a pending tx",
+	}
+	txPoolMaxFeeBumpFlag = cli.Uint64Flag{
+		Name:  "txpool-max-fee-bump",
+		Value: 10,
+		Usage: "min percentage to bump max fee per gas to replace a pending tx",
+	}
+	txPoolOrderingFlag = cli.StringFlag{
+		Name:  "txpool-ordering",
+		Value: txpool.OrderingPriorityFee,
+		Usage: "strategy to order pending txs to be packed (priority-fee|fifo|fair-share|local-first)",
+	}
+	txPoolSchedulerLimitFlag = cli.Uint64Flag{
+		Name:  "txpool-scheduler-limit",
+		Usage: "max count of txs held to be released into pool at a block number or timestamp (0 to disable scheduler)",
+	}
+	txPoolJournalIntervalFlag = cli.DurationFlag{
+		Name:  "txpool-journal-interval",
+		Usage: "interval to persist pending txs of the pool, which are restored on startup (0 to disable journal)",
+	}
 
 	allowedTracersFlag = cli.StringFlag{
 		Name:  "api-allowed-tracers",
@@ -197,6 +243,11 @@ var (
 		Value: 0,
 		Usage: "set a minimum effective priority fee for transactions to be included in the block proposed by the block proposer",
 	}
+	lightServFlag = cli.Uint64Flag{
+		Name:  "light-serv",
+		Value: 0,
+		Usage: "maximum number of light clients to serve (light protocol disabled if set to 0)",
+	}
 
 	// solo mode only flags
 	hayabusaFlag = cli.BoolFlag{
@@ -230,4 +281,53 @@ var (
 		Name:  "genesis",
 		Usage: "path or URL to genesis file, if not set, the default devnet genesis will be used",
 	}
+
+	// db command only flags
+	dbVerifyStateAtFlag = cli.StringFlag{
+		Name:  "state-at",
+		Value: "best",
+		Usage: "comma separated block numbers to verify state at, 'best' for the best block",
+	}
+	dbSkipLogsFlag = cli.BoolFlag{
+		Name:  "skip-logs",
+		Usage: "skip verifying log db",
+	}
+	dbRangeFlag = cli.StringFlag{
+		Name:  "range",
+		Usage: "block range in form of 'from-to', where 'to' can be 'best' for the best block",
+	}
+	dbReindexVerifyFlag = cli.BoolFlag{
+		Name:  "verify",
+		Usage: "verify logs of the range against receipts, and rewrite inconsistent blocks only",
+	}
+	dbDryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "with --verify, report inconsistent blocks without repairing",
+	}
+	dbBackupDirFlag = cli.StringFlag{
+		Name:  "backup-dir",
+		Usage: "directory of the backup",
+	}
+	dbBackupCompressFlag = cli.BoolFlag{
+		Name:  "compress",
+		Usage: "pack databases into a gzipped tarball",
+	}
+	apiOnlyWriterFlag = cli.StringFlag{
+		Name:  "writer-api",
+		Usage: "API URL of the thor process writing the data dir, to follow its best block and forward transactions",
+	}
+	apiOnlyPollIntervalFlag = cli.DurationFlag{
+		Name:  "poll-interval",
+		Value: time.Second,
+		Usage: "interval to poll the best block of the writer",
+	}
+	apiOnlyReloadIntervalFlag = cli.DurationFlag{
+		Name:  "reload-interval",
+		Value: 30 * time.Second,
+		Usage: "minimum interval to reload databases once the best block of the writer changed",
+	}
+	syncFromFlag = cli.StringFlag{
+		Name:  "sync-from",
+		Usage: "API URL of the trusted node to sync blocks from, and forward transactions to",
+	}
 )
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/cmd/thor/apisync"
+	"github.com/vechain/thor/v2/cmd/thor/httpserver"
+	"github.com/vechain/thor/v2/cmd/thor/node"
+	"github.com/vechain/thor/v2/cmd/thor/replica"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+// followAction runs the node syncing blocks from the API of a trusted node, without p2p. Blocks are still
+// validated and executed locally, and transactions submitted to the API are forwarded to the trusted node.
+func followAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	defer func() { log.Info("exited") }()
+
+	logLevel, err := initLogger(ctx)
+	if err != nil {
+		return err
+	}
+
+	trustedURL := ctx.String(syncFromFlag.Name)
+	if trustedURL == "" {
+		return fmt.Errorf("missing trusted node API URL, use -%s to specify", syncFromFlag.Name)
+	}
+
+	metricsURL, closeMetrics, err := startMetricsServer(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeMetrics()
+
+	in, closeInstance, err := openInstance(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeInstance()
+	repo := in.repo
+
+	printUpstreamStartupMessage("Thor follower", in.gene, repo, "Trusted API", trustedURL, in.dir)
+
+	closeStater, err := in.initState(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStater()
+
+	if err := in.initLogs(ctx, exitSignal); err != nil {
+		return err
+	}
+
+	// the pool only takes back txs of side chains on forks, and is never packed or broadcast
+	txPool := txpool.New(repo, in.stater, defaultTxPoolOptions, in.forkConfig)
+	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
+
+	logAPIRequests := &atomic.Bool{}
+	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+	apiConfig := makeAPIConfig(ctx, logAPIRequests, false)
+
+	// scheduled txs are released to the trusted node, the same as txs submitted
+	forwarder := replica.NewTxForwarder(trustedURL)
+	scheduler, closeTxPoolServices, err := in.startTxPoolServices(ctx, txPool, forwarder.AddLocal)
+	if err != nil {
+		return err
+	}
+	defer closeTxPoolServices()
+
+	adminURL := ""
+	if ctx.Bool(enableAdminFlag.Name) {
+		url, closeFunc, err := httpserver.StartAdminServer(
+			ctx.String(adminAddrFlag.Name),
+			logLevel,
+			repo,
+			nil,
+			nil,
+			logAPIRequests,
+			nil,
+			nil,
+			scheduler,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to start admin server - %w", err)
+		}
+		adminURL = url
+		defer func() { log.Info("stopping admin server..."); closeFunc() }()
+	}
+
+	bftEngine, err := bft.NewEngine(repo, in.mainDB, in.forkConfig, thor.Address{})
+	if err != nil {
+		return errors.Wrap(err, "init bft engine")
+	}
+
+	options, consStater := in.nodeOptions(ctx, &apiConfig)
+
+	apiURL, srvCloser, err := httpserver.StartAPIServer(
+		ctx.String(apiAddrFlag.Name),
+		repo,
+		in.apiStater,
+		forwarder,
+		in.logDB,
+		bftEngine,
+		noPeers{},
+		in.forkConfig,
+		apiConfig,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { log.Info("stopping API server..."); srvCloser() }()
+
+	printStartupMessage2(in.gene, apiURL, "", metricsURL, adminURL)
+
+	defer in.startBackground(ctx, bftEngine)()
+
+	log.Info("syncing from the trusted node", "url", trustedURL)
+	return node.New(
+		nil,
+		repo,
+		bftEngine,
+		in.stater,
+		in.logDB,
+		txPool,
+		"",
+		nil,
+		in.forkConfig,
+		options,
+		consensus.New(repo, consStater, in.forkConfig),
+		nil,
+	).Follow(exitSignal, apisync.New(repo, trustedURL))
+}
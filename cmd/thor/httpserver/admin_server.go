@@ -15,11 +15,14 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/vechain/thor/v2/api/admin"
+	"github.com/vechain/thor/v2/api/admin/backup"
 	"github.com/vechain/thor/v2/api/admin/health"
+	"github.com/vechain/thor/v2/api/admin/peers"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/txpool"
 )
 
 func StartAdminServer(
@@ -27,15 +30,18 @@ func StartAdminServer(
 	logLevel *slog.LevelVar,
 	repo *chain.Repository,
 	p2p *comm.Communicator,
+	peerManager peers.Manager,
 	apiLogs *atomic.Bool,
 	master *node.Master,
+	backupService backup.Service,
+	scheduler *txpool.Scheduler,
 ) (string, func(), error) {
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen admin API addr [%v]", addr)
 	}
 
-	adminHandler := admin.NewHTTPHandler(logLevel, health.New(repo, p2p), apiLogs, master)
+	adminHandler := admin.NewHTTPHandler(logLevel, health.New(repo, p2p), apiLogs, master, peerManager, backupService, scheduler)
 
 	srv := &http.Server{Handler: adminHandler, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
 	var goes co.Goes
@@ -24,18 +24,22 @@ import (
 	"github.com/vechain/thor/v2/api/doc"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/fees"
+	"github.com/vechain/thor/v2/api/logs"
 	"github.com/vechain/thor/v2/api/middleware"
 	"github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/api/subscriptions"
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/api/transfers"
+	"github.com/vechain/thor/v2/api/witnesses"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/logscan"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/witness"
 )
 
 var logger = log.WithContext("pkg", "api")
@@ -62,6 +66,7 @@ type APIConfig struct {
 	APIBacktraceLimit          int
 	PriorityIncreasePercentage int
 	Timeout                    int
+	Witnesses                  *witness.Store
 }
 
 func StartAPIServer(
@@ -105,10 +110,17 @@ func StartAPIServer(
 		})
 
 	accounts.New(repo, stater, config.CallGasLimit, forkConfig, bft, config.EnableDeprecated).Mount(router, "/accounts")
-	if !config.SkipLogs {
-		events.New(repo, logDB, config.LogsLimit).Mount(router, "/logs/event")
-		transfers.New(repo, logDB, config.LogsLimit).Mount(router, "/logs/transfer")
+	var logSource interface {
+		events.LogDB
+		transfers.LogDB
+	} = logDB
+	if config.SkipLogs {
+		// slower, but works without the log db
+		logSource = logscan.New(repo)
 	}
+	events.New(repo, logSource, config.LogsLimit).Mount(router, "/logs/event")
+	transfers.New(repo, logSource, config.LogsLimit).Mount(router, "/logs/transfer")
+	logs.New(logSource).Mount(router, "/logs")
 	blocks.New(repo, bft).Mount(router, "/blocks")
 	transactions.New(repo, txPool).Mount(router, "/transactions")
 	debug.New(repo, stater, forkConfig, bft,
@@ -123,6 +135,9 @@ func StartAPIServer(
 		PriorityIncreasePercentage: config.PriorityIncreasePercentage,
 		FixedCacheSize:             defaultFeeCacheSize,
 	}).Mount(router, "/fees")
+	if config.Witnesses != nil {
+		witnesses.New(repo, bft, config.Witnesses).Mount(router, "/witnesses")
+	}
 	subs := subscriptions.New(repo, origins, config.BacktraceLimit, txPool, config.EnableDeprecated)
 	subs.Mount(router, "/subscriptions")
 
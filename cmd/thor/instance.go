@@ -0,0 +1,224 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/httpserver"
+	"github.com/vechain/thor/v2/cmd/thor/node"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/logscan"
+	"github.com/vechain/thor/v2/metrics"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+	"github.com/vechain/thor/v2/witness"
+)
+
+// instance holds databases and components of a node syncing blocks into the instance dir, which are set up the
+// same way by the default and follow actions.
+type instance struct {
+	gene       *genesis.Genesis
+	forkConfig *thor.ForkConfig
+	dir        string
+	mainDB     *muxdb.MuxDB
+	logDB      *logdb.LogDB
+	repo       *chain.Repository
+
+	stater         *state.Stater
+	apiStater      *state.Stater // regenerates pruned states, if archived
+	archiveSpacing uint32
+
+	skipLogs           bool
+	logRetentionBlocks uint32
+}
+
+// startMetricsServer starts the metrics server if enabled, and returns its url.
+func startMetricsServer(ctx *cli.Context) (string, func(), error) {
+	if !ctx.Bool(enableMetricsFlag.Name) {
+		return "", func() {}, nil
+	}
+	metrics.InitializePrometheusMetrics()
+	url, closeFunc, err := httpserver.StartMetricsServer(ctx.String(metricsAddrFlag.Name))
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to start metrics server - %w", err)
+	}
+	return url, func() { log.Info("stopping metrics server..."); closeFunc() }, nil
+}
+
+// openInstance opens databases in the instance dir of the selected network, and the chain repository with the
+// freezer attached.
+func openInstance(ctx *cli.Context) (*instance, func(), error) {
+	var (
+		in      = &instance{}
+		closers []func()
+		err     error
+	)
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+	fail := func(err error) (*instance, func(), error) {
+		closeAll()
+		return nil, nil, err
+	}
+
+	if in.gene, in.forkConfig, err = selectGenesis(ctx); err != nil {
+		return nil, nil, err
+	}
+	if in.dir, err = makeInstanceDir(ctx, in.gene); err != nil {
+		return nil, nil, err
+	}
+
+	if in.mainDB, err = openMainDB(ctx, in.dir); err != nil {
+		return nil, nil, err
+	}
+	if ctx.Bool(enableMetricsFlag.Name) {
+		in.mainDB.EnableMetrics()
+	}
+	closers = append(closers, func() { log.Info("closing main database..."); in.mainDB.Close() })
+
+	if in.logDB, err = openLogDB(in.dir); err != nil {
+		return fail(err)
+	}
+	closers = append(closers, func() { log.Info("closing log database..."); in.logDB.Close() })
+
+	if in.repo, err = initChainRepository(in.gene, in.mainDB, in.logDB); err != nil {
+		return fail(err)
+	}
+
+	freezer, err := attachFreezer(in.repo, in.dir)
+	if err != nil {
+		return fail(err)
+	}
+	closers = append(closers, func() { log.Info("closing freezer..."); freezer.Close() })
+
+	return in, closeAll, nil
+}
+
+// initState creates the staters. The API stater regenerates pruned states from archived ones, if archived.
+func (in *instance) initState(ctx *cli.Context) (func(), error) {
+	archiveSpacing := ctx.Uint64(archiveSpacingFlag.Name)
+	if archiveSpacing > math.MaxUint32 {
+		return nil, errors.New("archive-spacing flag out of range")
+	}
+
+	stater, closeStater, err := newStater(ctx, in.mainDB)
+	if err != nil {
+		return nil, err
+	}
+	in.stater, in.apiStater, in.archiveSpacing = stater, stater, uint32(archiveSpacing)
+	if in.archiveSpacing > 0 && !ctx.Bool(disablePrunerFlag.Name) {
+		in.apiStater = stater.WithRegenerator(pruner.NewRegenerator(in.mainDB, in.repo, in.forkConfig))
+	}
+	return closeStater, nil
+}
+
+// initLogs syncs the log db with the chain, or enables log blooms if logs are skipped.
+func (in *instance) initLogs(ctx *cli.Context, exitSignal context.Context) error {
+	logRetentionBlocks := ctx.Uint64(logRetentionBlocksFlag.Name)
+	if logRetentionBlocks > math.MaxUint32 {
+		return errors.New("log-retention-blocks flag out of range")
+	}
+	in.logRetentionBlocks = uint32(logRetentionBlocks)
+
+	in.skipLogs = ctx.Bool(skipLogsFlag.Name)
+	if in.skipLogs {
+		// logs are scanned from blocks instead, filtered by log blooms
+		in.repo.EnableLogBlooms()
+		return nil
+	}
+	return syncLogDB(exitSignal, in.repo, in.logDB, ctx.Bool(verifyLogsFlag.Name))
+}
+
+// startTxPoolServices starts the journal persisting the pool, and the scheduler releasing txs by submit, if
+// enabled by flags. The scheduler is returned to be served by the admin server, or nil if disabled.
+func (in *instance) startTxPoolServices(
+	ctx *cli.Context,
+	txPool *txpool.TxPool,
+	submit func(*tx.Transaction) error,
+) (*txpool.Scheduler, func(), error) {
+	var closers []func()
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	if interval := ctx.Duration(txPoolJournalIntervalFlag.Name); interval > 0 {
+		journal, err := txpool.NewJournal(txPool, filepath.Join(in.dir, "tx.journal"), interval)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "open txpool journal")
+		}
+		closers = append(closers, func() { log.Info("stopping txpool journal..."); journal.Stop() })
+	}
+
+	var scheduler *txpool.Scheduler
+	if schedulerLimit := ctx.Uint64(txPoolSchedulerLimitFlag.Name); schedulerLimit > 0 {
+		limit, err := readIntFromUInt64Flag(schedulerLimit)
+		if err != nil {
+			closeAll()
+			return nil, nil, errors.Wrap(err, "parse txpool-scheduler-limit flag")
+		}
+		scheduler, err = txpool.NewScheduler(in.repo, txPool, filepath.Join(in.dir, "tx.scheduled"), limit, submit)
+		if err != nil {
+			closeAll()
+			return nil, nil, errors.Wrap(err, "open tx scheduler")
+		}
+		closers = append(closers, func() { log.Info("stopping tx scheduler..."); scheduler.Stop() })
+	}
+	return scheduler, closeAll, nil
+}
+
+// nodeOptions returns options of the node, and the stater to execute blocks on. If witnesses are recorded, blocks
+// are executed on the recording stater, and witnesses are served by the API.
+func (in *instance) nodeOptions(ctx *cli.Context, apiConfig *httpserver.APIConfig) (node.Options, *state.Stater) {
+	options := node.Options{
+		SkipLogs:           in.skipLogs,
+		LogRetentionBlocks: in.logRetentionBlocks,
+		LogRetentionAge:    ctx.Duration(logRetentionAgeFlag.Name),
+	}
+	if !ctx.Bool(recordWitnessFlag.Name) {
+		return options, in.stater
+	}
+	witnesses := witness.NewStore(in.mainDB, in.stater, in.repo, in.forkConfig)
+	options.Witnesses = witnesses
+	apiConfig.Witnesses = witnesses
+	return options, witnesses.Recording().Stater()
+}
+
+// startBackground starts the pruner unless disabled, and the log bloom indexer if logs are skipped.
+func (in *instance) startBackground(ctx *cli.Context, bftEngine bft.Committer) func() {
+	var closers []func()
+	if !ctx.Bool(disablePrunerFlag.Name) {
+		pruner := pruner.New(in.mainDB, in.repo, bftEngine, in.archiveSpacing)
+		closers = append(closers, func() { log.Info("stopping pruner..."); pruner.Stop() })
+	}
+	if in.skipLogs {
+		indexer := logscan.NewIndexer(in.repo)
+		closers = append(closers, func() { log.Info("stopping log bloom indexer..."); indexer.Stop() })
+	}
+	return func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+}
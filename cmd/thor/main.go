@@ -24,6 +24,7 @@ import (
 
 	"github.com/vechain/thor/v2/api/doc"
 	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/cmd/thor/backup"
 	"github.com/vechain/thor/v2/cmd/thor/httpserver"
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/cmd/thor/pruner"
@@ -54,6 +55,8 @@ var (
 		Limit:           10000,
 		LimitPerAccount: 128,
 		MaxLifetime:     20 * time.Minute,
+		PriorityFeeBump: 10,
+		MaxFeeBump:      10,
 	}
 )
 
@@ -115,14 +118,25 @@ func main() {
 			skipLogsFlag,
 			pprofFlag,
 			verifyLogsFlag,
+			logRetentionBlocksFlag,
+			logRetentionAgeFlag,
 			disablePrunerFlag,
+			archiveSpacingFlag,
+			recordWitnessFlag,
+			disableSnapshotFlag,
 			enableMetricsFlag,
 			metricsAddrFlag,
 			adminAddrFlag,
 			enableAdminFlag,
 			txPoolLimitPerAccountFlag,
+			txPoolPriorityFeeBumpFlag,
+			txPoolMaxFeeBumpFlag,
+			txPoolOrderingFlag,
+			txPoolSchedulerLimitFlag,
+			txPoolJournalIntervalFlag,
 			allowedTracersFlag,
 			minEffectivePriorityFeeFlag,
+			lightServFlag,
 		},
 		Action: defaultAction,
 		Commands: []cli.Command{
@@ -156,6 +170,9 @@ func main() {
 					skipLogsFlag,
 					txPoolLimitFlag,
 					txPoolLimitPerAccountFlag,
+					txPoolPriorityFeeBumpFlag,
+					txPoolMaxFeeBumpFlag,
+					txPoolOrderingFlag,
 					disablePrunerFlag,
 					enableMetricsFlag,
 					metricsAddrFlag,
@@ -167,6 +184,76 @@ func main() {
 				},
 				Action: soloAction,
 			},
+			{
+				Name:  "follow",
+				Usage: "sync blocks from the API of a trusted node instead of p2p",
+				Flags: []cli.Flag{
+					networkFlag,
+					syncFromFlag,
+					dataDirFlag,
+					cacheFlag,
+					apiAddrFlag,
+					apiCorsFlag,
+					apiTimeoutFlag,
+					apiCallGasLimitFlag,
+					apiBacktraceLimitFlag,
+					apiAllowCustomTracerFlag,
+					apiEnableDeprecatedFlag,
+					enableAPILogsFlag,
+					apiLogsLimitFlag,
+					apiPriorityFeesPercentageFlag,
+					verbosityFlag,
+					verbosityStakerFlag,
+					jsonLogsFlag,
+					skipLogsFlag,
+					pprofFlag,
+					verifyLogsFlag,
+					logRetentionBlocksFlag,
+					logRetentionAgeFlag,
+					disablePrunerFlag,
+					archiveSpacingFlag,
+					recordWitnessFlag,
+					disableSnapshotFlag,
+					txPoolSchedulerLimitFlag,
+					txPoolJournalIntervalFlag,
+					enableMetricsFlag,
+					metricsAddrFlag,
+					enableAdminFlag,
+					adminAddrFlag,
+					allowedTracersFlag,
+				},
+				Action: followAction,
+			},
+			{
+				Name:  "api-only",
+				Usage: "serve the API from the data dir written by another thor process",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					cacheFlag,
+					disablePrunerFlag,
+					apiOnlyWriterFlag,
+					apiOnlyPollIntervalFlag,
+					apiOnlyReloadIntervalFlag,
+					apiAddrFlag,
+					apiCorsFlag,
+					apiTimeoutFlag,
+					apiCallGasLimitFlag,
+					apiBacktraceLimitFlag,
+					apiAllowCustomTracerFlag,
+					apiEnableDeprecatedFlag,
+					enableAPILogsFlag,
+					apiLogsLimitFlag,
+					apiPriorityFeesPercentageFlag,
+					allowedTracersFlag,
+					skipLogsFlag,
+					verbosityFlag,
+					verbosityStakerFlag,
+					jsonLogsFlag,
+					pprofFlag,
+				},
+				Action: apiOnlyAction,
+			},
 			{
 				Name:  "master-key",
 				Usage: "master key management",
@@ -177,6 +264,68 @@ func main() {
 				},
 				Action: masterKeyAction,
 			},
+			{
+				Name:  "db",
+				Usage: "database maintenance",
+				Subcommands: []cli.Command{
+					{
+						Name:  "inspect",
+						Usage: "report key counts and sizes of databases",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							disablePrunerFlag,
+						},
+						Action: dbInspectAction,
+					},
+					{
+						Name:  "verify",
+						Usage: "check the consistency of chain data, state and logs",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							disablePrunerFlag,
+							dbVerifyStateAtFlag,
+							dbSkipLogsFlag,
+						},
+						Action: dbVerifyAction,
+					},
+					{
+						Name:  "reindex-logs",
+						Usage: "rebuild logs of a block range, or verify and repair them",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							disablePrunerFlag,
+							dbRangeFlag,
+							dbReindexVerifyFlag,
+							dbDryRunFlag,
+						},
+						Action: dbReindexLogsAction,
+					},
+					{
+						Name:  "backup",
+						Usage: "take a consistent backup of a running node through its admin API",
+						Flags: []cli.Flag{
+							adminAddrFlag,
+							dbBackupDirFlag,
+							dbBackupCompressFlag,
+						},
+						Action: dbBackupAction,
+					},
+					{
+						Name:  "restore",
+						Usage: "restore databases from a backup, while the node is stopped",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							disablePrunerFlag,
+							dbBackupDirFlag,
+						},
+						Action: dbRestoreAction,
+					},
+				},
+			},
 		},
 	}
 
@@ -197,59 +346,34 @@ func defaultAction(ctx *cli.Context) error {
 	}
 
 	// enable metrics as soon as possible
-	enableMetrics := ctx.Bool(enableMetricsFlag.Name)
-	metricsURL := ""
-	if enableMetrics {
-		metrics.InitializePrometheusMetrics()
-		url, closeFunc, err := httpserver.StartMetricsServer(ctx.String(metricsAddrFlag.Name))
-		if err != nil {
-			return fmt.Errorf("unable to start metrics server - %w", err)
-		}
-		metricsURL = url
-		defer func() { log.Info("stopping metrics server..."); closeFunc() }()
-	}
-
-	gene, forkConfig, err := selectGenesis(ctx)
-	if err != nil {
-		return err
-	}
-	instanceDir, err := makeInstanceDir(ctx, gene)
+	metricsURL, closeMetrics, err := startMetricsServer(ctx)
 	if err != nil {
 		return err
 	}
+	defer closeMetrics()
 
-	mainDB, err := openMainDB(ctx, instanceDir)
+	in, closeInstance, err := openInstance(ctx)
 	if err != nil {
 		return err
 	}
-	if enableMetrics {
-		mainDB.EnableMetrics()
-	}
-	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+	defer closeInstance()
+	repo := in.repo
 
-	logDB, err := openLogDB(instanceDir)
+	master, err := loadNodeMaster(ctx)
 	if err != nil {
 		return err
 	}
-	defer func() { log.Info("closing log database..."); logDB.Close() }()
 
-	repo, err := initChainRepository(gene, mainDB, logDB)
-	if err != nil {
-		return err
-	}
+	printStartupMessage1(in.gene, repo, master, in.dir, in.forkConfig)
 
-	master, err := loadNodeMaster(ctx)
+	closeStater, err := in.initState(ctx)
 	if err != nil {
 		return err
 	}
+	defer closeStater()
 
-	printStartupMessage1(gene, repo, master, instanceDir, forkConfig)
-
-	skipLogs := ctx.Bool(skipLogsFlag.Name)
-	if !skipLogs {
-		if err := syncLogDB(exitSignal, repo, logDB, ctx.Bool(verifyLogsFlag.Name)); err != nil {
-			return err
-		}
+	if err := in.initLogs(ctx, exitSignal); err != nil {
+		return err
 	}
 
 	txpoolOpt := defaultTxPoolOptions
@@ -257,25 +381,46 @@ func defaultAction(ctx *cli.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "parse txpool-limit-per-account flag")
 	}
-	txPool := txpool.New(repo, state.NewStater(mainDB), txpoolOpt, forkConfig)
+	if err := readTxPoolPolicies(ctx, &txpoolOpt); err != nil {
+		return err
+	}
+	txPool := txpool.New(repo, in.stater, txpoolOpt, in.forkConfig)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
-	p2pCommunicator, err := newP2PCommunicator(ctx, repo, txPool, instanceDir)
+	logAPIRequests := &atomic.Bool{}
+	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+	apiConfig := makeAPIConfig(ctx, logAPIRequests, false)
+	scheduler, closeTxPoolServices, err := in.startTxPoolServices(ctx, txPool, nil)
+	if err != nil {
+		return err
+	}
+	defer closeTxPoolServices()
+
+	p2pCommunicator, err := newP2PCommunicator(ctx, repo, txPool, in.dir)
 	if err != nil {
 		return err
 	}
 
 	adminURL := ""
-	logAPIRequests := &atomic.Bool{}
-	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
 	if ctx.Bool(enableAdminFlag.Name) {
+		backupService := backup.NewService(&backup.Source{
+			MainDB:     in.mainDB,
+			LogDB:      in.logDB,
+			FreezerDir: filepath.Join(in.dir, backup.FreezerName),
+			Repo:       repo,
+		})
+		defer func() { log.Info("stopping backup service..."); backupService.Close() }()
+
 		url, closeFunc, err := httpserver.StartAdminServer(
 			ctx.String(adminAddrFlag.Name),
 			logLevel,
 			repo,
 			p2pCommunicator.Communicator(),
+			p2pCommunicator,
 			logAPIRequests,
 			master,
+			backupService,
+			scheduler,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to start admin server - %w", err)
@@ -284,64 +429,65 @@ func defaultAction(ctx *cli.Context) error {
 		defer func() { log.Info("stopping admin server..."); closeFunc() }()
 	}
 
-	bftEngine, err := bft.NewEngine(repo, mainDB, forkConfig, master.Address())
+	bftEngine, err := bft.NewEngine(repo, in.mainDB, in.forkConfig, master.Address())
 	if err != nil {
 		return errors.Wrap(err, "init bft engine")
 	}
 
+	if maxLightPeers := ctx.Uint64(lightServFlag.Name); maxLightPeers > 0 {
+		n, err := readIntFromUInt64Flag(maxLightPeers)
+		if err != nil {
+			return errors.Wrap(err, "parse light-serv flag")
+		}
+		p2pCommunicator.Communicator().ServeLight(in.stater, bftEngine, n)
+	}
+
+	options, consStater := in.nodeOptions(ctx, &apiConfig)
+	options.MinTxPriorityFee = ctx.Uint64(minEffectivePriorityFeeFlag.Name)
+	options.TargetGasLimit = ctx.Uint64(targetGasLimitFlag.Name)
+
 	apiURL, srvCloser, err := httpserver.StartAPIServer(
 		ctx.String(apiAddrFlag.Name),
 		repo,
-		state.NewStater(mainDB),
+		in.apiStater,
 		txPool,
-		logDB,
+		in.logDB,
 		bftEngine,
 		p2pCommunicator.Communicator(),
-		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, false),
+		in.forkConfig,
+		apiConfig,
 	)
 	if err != nil {
 		return err
 	}
 	defer func() { log.Info("stopping API server..."); srvCloser() }()
 
-	printStartupMessage2(gene, apiURL, p2pCommunicator.Enode(), metricsURL, adminURL)
+	printStartupMessage2(in.gene, apiURL, p2pCommunicator.Enode(), metricsURL, adminURL)
 
 	if err := p2pCommunicator.Start(); err != nil {
 		return err
 	}
 	defer p2pCommunicator.Stop()
 
-	if !ctx.Bool(disablePrunerFlag.Name) {
-		pruner := pruner.New(mainDB, repo)
-		defer func() { log.Info("stopping pruner..."); pruner.Stop() }()
-	}
-
-	minTxPriorityFee := ctx.Uint64(minEffectivePriorityFeeFlag.Name)
-	if minTxPriorityFee > 0 {
-		log.Info(fmt.Sprintf("the minimum effective priority fee required in transactions is %d wei", minTxPriorityFee))
-	}
+	defer in.startBackground(ctx, bftEngine)()
 
-	options := node.Options{
-		SkipLogs:         skipLogs,
-		MinTxPriorityFee: minTxPriorityFee,
-		TargetGasLimit:   ctx.Uint64(targetGasLimitFlag.Name),
+	if options.MinTxPriorityFee > 0 {
+		log.Info(fmt.Sprintf("the minimum effective priority fee required in transactions is %d wei", options.MinTxPriorityFee))
 	}
-	stater := state.NewStater(mainDB)
 
 	return node.New(
 		master,
 		repo,
 		bftEngine,
-		state.NewStater(mainDB),
-		logDB,
+		in.stater,
+		in.logDB,
 		txPool,
-		filepath.Join(instanceDir, "tx.stash"),
+		filepath.Join(in.dir, "tx.stash"),
 		p2pCommunicator.Communicator(),
-		forkConfig,
+		in.forkConfig,
 		options,
-		consensus.New(repo, stater, forkConfig),
-		packer.New(repo, stater, master.Address(), master.Beneficiary, forkConfig, options.MinTxPriorityFee),
+		consensus.New(repo, consStater, in.forkConfig),
+		packer.New(repo, in.stater, master.Address(), master.Beneficiary, in.forkConfig, options.MinTxPriorityFee),
 	).Run(exitSignal)
 }
 
@@ -434,8 +580,11 @@ func soloAction(ctx *cli.Context) error {
 			logLevel,
 			repo,
 			nil,
+			nil,
 			logAPIRequests,
 			nil,
+			nil,
+			nil,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to start admin server - %w", err)
@@ -447,10 +596,11 @@ func soloAction(ctx *cli.Context) error {
 	printStartupMessage1(gene, repo, nil, instanceDir, forkConfig)
 
 	skipLogs := ctx.Bool(skipLogsFlag.Name)
-	if !skipLogs {
-		if err := syncLogDB(exitSignal, repo, logDB, ctx.Bool(verifyLogsFlag.Name)); err != nil {
-			return err
-		}
+	if skipLogs {
+		// logs are scanned from blocks instead, filtered by log blooms
+		repo.EnableLogBlooms()
+	} else if err := syncLogDB(exitSignal, repo, logDB, ctx.Bool(verifyLogsFlag.Name)); err != nil {
+		return err
 	}
 
 	minTxPriorityFee := ctx.Uint64(minEffectivePriorityFeeFlag.Name)
@@ -481,6 +631,9 @@ func soloAction(ctx *cli.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "parse txpool-limit-per-account flag")
 		}
+		if err := readTxPoolPolicies(ctx, &txPoolOption); err != nil {
+			return err
+		}
 
 		txPool := txpool.New(repo, state.NewStater(mainDB), txPoolOption, forkConfig)
 		defer func() { log.Info("closing tx pool..."); txPool.Close() }()
@@ -506,7 +659,7 @@ func soloAction(ctx *cli.Context) error {
 	printStartupMessage2(gene, apiURL, "", metricsURL, adminURL)
 
 	if !ctx.Bool(disablePrunerFlag.Name) {
-		pruner := pruner.New(mainDB, repo)
+		pruner := pruner.New(mainDB, repo, nil, 0)
 		defer func() { log.Info("stopping pruner..."); pruner.Stop() }()
 	}
 
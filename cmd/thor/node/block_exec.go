@@ -20,6 +20,7 @@ import (
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/witness"
 )
 
 type blockExecContext struct {
@@ -32,6 +33,7 @@ type blockExecContext struct {
 	stats      *blockStats
 	packing    bool
 	startTime  mclock.AbsTime
+	witness    *witness.Witness // recorded while executing the block, nil if not recorded
 }
 
 // guardBlockProcessing adds lock on block processing and maintains block conflicts.
@@ -127,11 +129,23 @@ func (n *Node) executeAndCommitBlock(newBlock *block.Block, stats *blockStats, c
 		return errBFTRejected
 	}
 
-	// process the new block
+	// process the new block, and take its witness if recorded
+	var recording *witness.Recording
+	if n.options.Witnesses != nil {
+		recording = n.options.Witnesses.Recording()
+		// drop data read apart from block execution
+		recording.Take()
+	}
 	ctx.stage, ctx.receipts, err = n.cons.Process(parentSummary, newBlock, uint64(time.Now().Unix()), conflicts)
 	if err != nil {
 		return err
 	}
+	if recording != nil {
+		// empty if the block is not executed on the recording
+		if w := recording.Take(); len(w.Tries) > 0 {
+			ctx.witness = w
+		}
+	}
 
 	// let bft engine decide the best block after fork FINALITY
 	if newBlock.Header().Number() >= n.forkConfig.FINALITY && ctx.prevBest.Number() >= n.forkConfig.FINALITY {
@@ -194,6 +208,7 @@ func (n *Node) commitBlock(ctx *blockExecContext) error {
 
 	if ctx.becomeBest {
 		n.processFork(ctx.newBlock, ctx.prevBest.ID())
+		n.saveWitness(ctx.newBlock.Header().ID(), ctx.witness)
 	}
 
 	commitElapsed := mclock.Now() - ctx.startTime - execElapsed
@@ -375,3 +390,24 @@ side-chain:   %v  %v`,
 		}
 	}
 }
+
+// saveWitness asynchronously saves the witness of the trunk block, if enabled.
+// Blocks without witnesses recorded, e.g. packed locally, are re-executed to record witnesses.
+func (n *Node) saveWitness(id thor.Bytes32, w *witness.Witness) {
+	if n.witnessWorker == nil {
+		return
+	}
+	n.witnessWorker.Run(func() error {
+		save := func() error {
+			if w != nil {
+				return n.options.Witnesses.Put(id, w)
+			}
+			return n.options.Witnesses.Save(id)
+		}
+		if err := save(); err != nil {
+			logger.Warn("failed to save witness", "id", id, "err", err)
+		}
+		// never stop the worker, since a failure affects only one block
+		return nil
+	})
+}
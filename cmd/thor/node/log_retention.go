@@ -0,0 +1,111 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	logRetentionInterval = 5 * time.Minute
+	logPruneBatchSize    = 5000 // max count of events and transfers deleted per batch
+)
+
+// logRetentionLoop periodically prunes logs beyond the retention.
+func (n *Node) logRetentionLoop(ctx context.Context) {
+	logger.Debug("enter log retention loop")
+	defer logger.Debug("leave log retention loop")
+
+	ticker := time.NewTicker(logRetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.pruneLogs(ctx, uint64(time.Now().Unix())); err != nil && ctx.Err() == nil {
+			logger.Warn("failed to prune logs", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// logRetentionCutoff returns the number of the oldest block whose logs should be retained.
+// A block is out of the retention once it's beyond either the block count or the age.
+func (n *Node) logRetentionCutoff(now uint64) (uint32, error) {
+	best := n.repo.BestBlockSummary().Header
+
+	var cutoff uint32
+	if blocks := n.options.LogRetentionBlocks; blocks > 0 && best.Number() >= blocks {
+		cutoff = best.Number() - blocks + 1
+	}
+	if age := uint64(n.options.LogRetentionAge / time.Second); age > 0 && now > age {
+		header, err := n.repo.NewBestChain().FindBlockHeaderByTimestamp(now-age, 1)
+		if err != nil {
+			return 0, err
+		}
+		cutoff = max(cutoff, header.Number())
+	}
+	return cutoff, nil
+}
+
+// pruneLogs deletes logs of blocks before the retention cutoff, batch by batch.
+// Each batch holds the block processing lock, to not interleave with log writing.
+func (n *Node) pruneLogs(ctx context.Context, now uint64) error {
+	cutoff, err := n.logRetentionCutoff(now)
+	if err != nil {
+		return err
+	}
+	oldest, err := n.logDB.OldestBlockNum()
+	if err != nil {
+		return err
+	}
+	if cutoff <= oldest {
+		return nil
+	}
+
+	var total int
+	for {
+		deleted, err := n.pruneLogsBatch(cutoff)
+		if err != nil {
+			return err
+		}
+		total += deleted
+		if deleted == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	logger.Debug("pruned logs", "before", cutoff, "deleted", total)
+	return nil
+}
+
+func (n *Node) pruneLogsBatch(cutoff uint32) (int, error) {
+	n.processLock.Lock()
+	defer n.processLock.Unlock()
+
+	// a failed log db is no longer written, leave it as is
+	if n.logDBFailed {
+		return 0, nil
+	}
+
+	w := n.logDB.NewWriter()
+	deleted, err := w.Prune(cutoff, logPruneBatchSize)
+	if err != nil {
+		_ = w.Rollback()
+		return 0, err
+	}
+	if err := w.Commit(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestPruneLogs(t *testing.T) {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	to := thor.BytesToAddress([]byte("to"))
+	for range 6 {
+		require.NoError(t, chain.MintClauses(genesis.DevAccounts()[0], []*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}))
+	}
+	best := chain.Repo().BestBlockSummary().Header
+	require.Equal(t, uint32(6), best.Number())
+
+	transfers := func() []*logdb.Transfer {
+		trs, err := chain.LogDB().FilterTransfers(context.Background(), nil)
+		require.NoError(t, err)
+		return trs
+	}
+	require.Len(t, transfers(), 6)
+
+	n := &Node{repo: chain.Repo(), logDB: chain.LogDB()}
+
+	// nothing to prune
+	require.NoError(t, n.pruneLogs(context.Background(), best.Timestamp()))
+	assert.Len(t, transfers(), 6)
+
+	// by block count
+	n.options = Options{LogRetentionBlocks: 4}
+	cutoff, err := n.logRetentionCutoff(best.Timestamp())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), cutoff)
+	require.NoError(t, n.pruneLogs(context.Background(), best.Timestamp()))
+	assert.Len(t, transfers(), 4)
+	oldest, err := chain.LogDB().OldestBlockNum()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), oldest)
+
+	// by age, blocks older than the age are pruned, even if within the block count
+	header, err := chain.Repo().NewBestChain().GetBlockHeader(5)
+	require.NoError(t, err)
+	n.options.LogRetentionAge = time.Duration(best.Timestamp()-header.Timestamp()) * time.Second
+	cutoff, err = n.logRetentionCutoff(best.Timestamp())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), cutoff)
+	require.NoError(t, n.pruneLogs(context.Background(), best.Timestamp()))
+	trs := transfers()
+	require.Len(t, trs, 2)
+	assert.Equal(t, uint32(5), trs[0].BlockNumber)
+
+	// failed log db is left as is
+	n.options.LogRetentionBlocks = 1
+	n.logDBFailed = true
+	require.NoError(t, n.pruneLogs(context.Background(), best.Timestamp()))
+	assert.Len(t, transfers(), 2)
+}
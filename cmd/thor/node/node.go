@@ -34,6 +34,7 @@ import (
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
+	"github.com/vechain/thor/v2/witness"
 )
 
 var logger = log.WithContext("pkg", "node")
@@ -51,6 +52,13 @@ type Options struct {
 	TargetGasLimit   uint64
 	SkipLogs         bool
 	MinTxPriorityFee uint64
+	// to save witnesses of trunk blocks, nil to disable.
+	// Witnesses are recorded while blocks executed, if the consensus engine executes on the stater of the store's
+	// recording, otherwise blocks are re-executed to record them.
+	Witnesses *witness.Store
+
+	LogRetentionBlocks uint32        // to prune logs of blocks except the latest ones, zero to retain all
+	LogRetentionAge    time.Duration // to prune logs of blocks older than the age, zero to retain all
 }
 
 // ConsensusEngine defines the interface for consensus processing
@@ -84,6 +92,7 @@ type Node struct {
 	maxBlockNum   uint32
 	processLock   sync.Mutex
 	logWorker     *worker
+	witnessWorker *worker
 }
 
 func New(
@@ -116,12 +125,41 @@ func New(
 	}
 }
 
+// BlockSource is a source of blocks other than the p2p network, which streams blocks to the handler.
+type BlockSource interface {
+	Sync(ctx context.Context, handler comm.HandleBlockStream)
+}
+
 func (n *Node) Run(ctx context.Context) error {
+	return n.run(ctx, func(goes *co.Goes) {
+		goes.Go(func() { n.comm.Sync(ctx, n.handleBlockStream) })
+		goes.Go(func() { n.houseKeeping(ctx) })
+		goes.Go(func() { n.txStashLoop(ctx) })
+		goes.Go(func() { n.packerLoop(ctx) })
+	})
+}
+
+// Follow runs the node syncing blocks from the source only. Blocks are processed in the same way as synced from
+// peers, while there is no p2p, packing or tx stash, and the communicator is not required.
+func (n *Node) Follow(ctx context.Context, source BlockSource) error {
+	return n.run(ctx, func(goes *co.Goes) {
+		goes.Go(func() { source.Sync(ctx, n.handleBlockStream) })
+	})
+}
+
+func (n *Node) run(ctx context.Context, start func(goes *co.Goes)) error {
 	logWorker := newWorker()
 	defer logWorker.Close()
 
 	n.logWorker = logWorker
 
+	if n.options.Witnesses != nil {
+		witnessWorker := newWorker()
+		defer witnessWorker.Close()
+
+		n.witnessWorker = witnessWorker
+	}
+
 	maxBlockNum, err := n.repo.GetMaxBlockNum()
 	if err != nil {
 		return err
@@ -129,10 +167,10 @@ func (n *Node) Run(ctx context.Context) error {
 	n.maxBlockNum = maxBlockNum
 
 	var goes co.Goes
-	goes.Go(func() { n.comm.Sync(ctx, n.handleBlockStream) })
-	goes.Go(func() { n.houseKeeping(ctx) })
-	goes.Go(func() { n.txStashLoop(ctx) })
-	goes.Go(func() { n.packerLoop(ctx) })
+	start(&goes)
+	if !n.options.SkipLogs && (n.options.LogRetentionBlocks > 0 || n.options.LogRetentionAge > 0) {
+		goes.Go(func() { n.logRetentionLoop(ctx) })
+	}
 
 	goes.Wait()
 	return nil
@@ -210,6 +248,8 @@ func (n *Node) houseKeeping(ctx context.Context) {
 					((err == errParentMissing || err == errBlockTemporaryUnprocessable) && futureBlocks.Contains(newBlock.Header().ParentID())) {
 					logger.Debug("future block added", "id", newBlock.Header().ID())
 					futureBlocks.Set(newBlock.Header().ID(), newBlock.Block)
+				} else if consensus.IsCritical(err) {
+					n.comm.ReportMisbehavior(newBlock.PeerID, comm.MisbehaviorInvalidBlock)
 				}
 			} else if isTrunk {
 				n.comm.BroadcastBlock(newBlock.Block)
@@ -284,8 +324,8 @@ func (n *Node) txStashLoop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case txEv := <-txCh:
-			// skip executables
-			if txEv.Executable != nil && *txEv.Executable {
+			// skip executables, and txs not newly added
+			if txEv.Status != txpool.TxStatusAdded || (txEv.Executable != nil && *txEv.Executable) {
 				continue
 			}
 			// only stash non-executable txs
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+	"github.com/vechain/thor/v2/witness"
+)
+
+// blockSlice is the block source streaming blocks in the slice once.
+type blockSlice struct {
+	blocks []*block.Block
+	err    chan error
+}
+
+func (s *blockSlice) Sync(ctx context.Context, handler comm.HandleBlockStream) {
+	stream := make(chan *block.Block)
+	go func() {
+		defer close(stream)
+		for _, blk := range s.blocks {
+			stream <- blk
+		}
+	}()
+	s.err <- handler(ctx, stream)
+	<-ctx.Done()
+}
+
+func TestFollow(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	thorChain, err := testchain.NewIntegrationTestChain(genesis.DevConfig{
+		ForkConfig: &testchain.DefaultForkConfig,
+		LaunchTime: now - now%thor.BlockInterval() - 100*thor.BlockInterval(),
+	}, 180)
+	require.NoError(t, err)
+
+	to := genesis.DevAccounts()[1].Address
+	trx := tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(21000).
+		Clause(tx.NewClause(&to)).
+		Build()
+	trx = tx.MustSign(trx, genesis.DevAccounts()[0].PrivateKey)
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], trx))
+	require.NoError(t, thorChain.MintBlock(genesis.DevAccounts()[0]))
+	blocks, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+
+	for _, recordWitness := range []bool{false, true} {
+		t.Run(fmt.Sprintf("record witness %v", recordWitness), func(t *testing.T) {
+			db := muxdb.NewMem()
+			stater := state.NewStater(db)
+			genesisBlock, _, _, err := thorChain.Genesis().Build(stater)
+			require.NoError(t, err)
+			repo, err := chain.NewRepository(db, genesisBlock)
+			require.NoError(t, err)
+			forkConfig := thorChain.GetForkConfig()
+			bftEngine, err := bft.NewEngine(repo, db, forkConfig, thor.Address{})
+			require.NoError(t, err)
+			logDB, err := logdb.NewMem()
+			require.NoError(t, err)
+			pool := txpool.New(repo, stater, txpool.Options{Limit: LIMIT, LimitPerAccount: LIMIT_PER_ACCOUNT, MaxLifetime: time.Hour}, forkConfig)
+			defer pool.Close()
+
+			var options Options
+			consStater := stater
+			if recordWitness {
+				options.Witnesses = witness.NewStore(db, stater, repo, forkConfig)
+				consStater = options.Witnesses.Recording().Stater()
+			}
+			n := New(nil, repo, bftEngine, stater, logDB, pool, "", nil, forkConfig, options, consensus.New(repo, consStater, forkConfig), nil)
+
+			source := &blockSlice{blocks: blocks[1:], err: make(chan error, 1)}
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- n.Follow(ctx, source) }()
+
+			require.NoError(t, <-source.err)
+			cancel()
+			require.NoError(t, <-done)
+
+			assert.Equal(t, blocks[len(blocks)-1].Header().ID(), repo.BestBlockSummary().Header.ID())
+			receipt, err := repo.NewBestChain().GetTransactionReceipt(trx.ID())
+			require.NoError(t, err)
+			assert.False(t, receipt.Reverted)
+
+			if recordWitness {
+				// witnesses recorded while following are saved, and suffice to execute the blocks
+				saved := db.NewStore(witness.StoreName)
+				for _, blk := range blocks[1:] {
+					data, err := saved.Get(blk.Header().ID().Bytes())
+					require.NoError(t, err)
+					w, err := witness.Decode(data)
+					require.NoError(t, err)
+					parent, err := repo.GetBlockSummary(blk.Header().ParentID())
+					require.NoError(t, err)
+					_, err = witness.Execute(repo, forkConfig, parent, blk, 0, w)
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
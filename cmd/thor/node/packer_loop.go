@@ -125,10 +125,18 @@ func (n *Node) doPack(flow *packer.Flow) error {
 }
 
 func (n *Node) proposeAndCommit(flow *packer.Flow, conflicts uint32) (err error) {
-	var txsToRemove []*tx.Transaction
+	var (
+		txsToRemove   []*tx.Transaction
+		adopted       tx.Transactions
+		bundlesToDrop = make(map[thor.Bytes32]string) // bundle id => reason
+	)
 	defer func() {
 		if err == nil {
+			n.txPool.ObservePacked(adopted)
 			cleanupTransactions(txsToRemove, n.txPool)
+			for id, reason := range bundlesToDrop {
+				n.txPool.DropBundle(id, reason)
+			}
 		}
 	}()
 
@@ -141,9 +149,18 @@ func (n *Node) proposeAndCommit(flow *packer.Flow, conflicts uint32) (err error)
 		becomeBest: true,
 	}
 
-	txs := n.txPool.Executables()
-	// adopt txs
-	for _, tx := range txs {
+	// adopt bundles ahead of individual txs, as they are all or nothing
+	for _, bundle := range n.txPool.Bundles() {
+		if err := flow.AdoptBundle(bundle.Txs); err != nil {
+			if packer.IsGasLimitReached(err) || packer.IsTxNotAdoptableNow(err) {
+				continue
+			}
+			bundlesToDrop[bundle.ID] = err.Error()
+		}
+	}
+
+	// adopt txs in the order of the pool's ordering strategy
+	for _, tx := range n.txPool.Executables() {
 		if err := flow.Adopt(tx); err != nil {
 			if packer.IsGasLimitReached(err) {
 				break
@@ -152,6 +169,8 @@ func (n *Node) proposeAndCommit(flow *packer.Flow, conflicts uint32) (err error)
 				continue
 			}
 			txsToRemove = append(txsToRemove, tx)
+		} else {
+			adopted = append(adopted, tx)
 		}
 	}
 
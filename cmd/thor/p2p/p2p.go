@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p/discover"
@@ -17,16 +19,27 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 
+	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/p2psrv"
 )
 
+// peersSaveInterval is the interval to save peers, so that they survive unclean shutdowns.
+const peersSaveInterval = 5 * time.Minute
+
 type P2P struct {
-	comm           *comm.Communicator
-	p2pSrv         *p2psrv.Server
-	peersCachePath string
-	enode          string
+	comm             *comm.Communicator
+	p2pSrv           *p2psrv.Server
+	peersCachePath   string
+	peersStorePath   string
+	bannedPeersPath  string
+	staticPeersPath  string
+	trustedPeersPath string
+	enode            string
+	lock             sync.Mutex // serializes the changes of static and trusted peers
+	goes             co.Goes
+	done             chan struct{}
 }
 
 func New(
@@ -44,6 +57,13 @@ func New(
 ) *P2P {
 	// known peers will be loaded/stored from/in this file
 	peersCachePath := filepath.Join(instanceDir, "peers.cache")
+	// connection histories of peers will be loaded/stored from/in this file
+	peersStorePath := filepath.Join(instanceDir, "peers.store")
+	// banned peers will be loaded/stored from/in this file
+	bannedPeersPath := filepath.Join(instanceDir, "peers.banned")
+	// static and trusted peers managed at runtime will be loaded/stored from/in these files
+	staticPeersPath := filepath.Join(instanceDir, "peers.static")
+	trustedPeersPath := filepath.Join(instanceDir, "peers.trusted")
 
 	// default option setting
 	// no known nodes for p2p connection
@@ -77,11 +97,21 @@ func New(
 		}
 	}
 
+	loadFile(peersStorePath, &opts.PeerRecords, "peers store")
+	loadFile(bannedPeersPath, &opts.BannedNodes, "banned peers")
+	loadFile(staticPeersPath, &opts.StaticNodes, "static peers")
+	loadFile(trustedPeersPath, &opts.TrustedNodes, "trusted peers")
+
 	return &P2P{
-		comm:           communicator,
-		p2pSrv:         p2psrv.New(opts),
-		peersCachePath: peersCachePath,
-		enode:          fmt.Sprintf("enode://%x@[extip]:%v", discover.PubkeyID(&privateKey.PublicKey).Bytes(), listenPort),
+		comm:             communicator,
+		p2pSrv:           p2psrv.New(opts),
+		peersCachePath:   peersCachePath,
+		peersStorePath:   peersStorePath,
+		bannedPeersPath:  bannedPeersPath,
+		staticPeersPath:  staticPeersPath,
+		trustedPeersPath: trustedPeersPath,
+		enode:            fmt.Sprintf("enode://%x@[extip]:%v", discover.PubkeyID(&privateKey.PublicKey).Bytes(), listenPort),
+		done:             make(chan struct{}),
 	}
 }
 
@@ -90,37 +120,138 @@ func (p *P2P) Start() error {
 	if err := p.p2pSrv.Start(p.comm.Protocols(), p.comm.DiscTopic()); err != nil {
 		return errors.Wrap(err, "start P2P server")
 	}
+	p.comm.SetBanner(p.p2pSrv)
 	p.comm.Start()
+	p.goes.Go(p.saveLoop)
 	return nil
 }
 
 func (p *P2P) Stop() {
+	close(p.done)
+	p.goes.Wait()
+
 	log.Info("stopping communicator...")
 	p.comm.Stop()
 
 	log.Info("stopping P2P server...")
 	p.p2pSrv.Stop()
 
-	log.Info("saving peers cache...")
-	nodes := p.p2pSrv.KnownNodes()
-	data, err := rlp.EncodeToBytes(nodes)
-	if err != nil {
-		log.Warn("failed to encode cached peers", "err", err)
-		return
+	log.Info("saving peers...")
+	p.savePeers()
+}
+
+// saveLoop periodically saves peers until stopped.
+func (p *P2P) saveLoop() {
+	ticker := time.NewTicker(peersSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.savePeers()
+		}
 	}
-	if err := os.WriteFile(p.peersCachePath, data, 0o600); err != nil {
-		log.Warn("failed to write peers cache", "err", err)
+}
+
+// savePeers saves the peers cache, peers store and banned peers, failures are logged only.
+func (p *P2P) savePeers() {
+	if err := saveFile(p.peersCachePath, p.p2pSrv.KnownNodes()); err != nil {
+		log.Warn("failed to save peers cache", "err", err)
 	}
+	if err := saveFile(p.peersStorePath, p.p2pSrv.PeerRecords()); err != nil {
+		log.Warn("failed to save peers store", "err", err)
+	}
+	if err := saveFile(p.bannedPeersPath, p.p2pSrv.BannedNodes()); err != nil {
+		log.Warn("failed to save banned peers", "err", err)
+	}
+}
+
+// PeerRecords returns connection histories of peers.
+func (p *P2P) PeerRecords() []*p2psrv.PeerRecord {
+	return p.p2pSrv.PeerRecords()
+}
+
+// StaticNodes returns static peers.
+func (p *P2P) StaticNodes() p2psrv.Nodes {
+	return p.p2pSrv.StaticNodes()
+}
+
+// AddStatic adds a static peer and persists static peers.
+func (p *P2P) AddStatic(node *discover.Node) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.p2pSrv.AddStatic(node)
+	return saveFile(p.staticPeersPath, p.p2pSrv.StaticNodes())
+}
+
+// RemoveStatic removes a static peer and persists static peers.
+func (p *P2P) RemoveStatic(node *discover.Node) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.p2pSrv.RemoveStatic(node)
+	return saveFile(p.staticPeersPath, p.p2pSrv.StaticNodes())
+}
+
+// TrustedNodes returns trusted peers.
+func (p *P2P) TrustedNodes() p2psrv.Nodes {
+	return p.p2pSrv.TrustedNodes()
+}
+
+// AddTrusted adds a trusted peer and persists trusted peers.
+func (p *P2P) AddTrusted(node *discover.Node) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.p2pSrv.AddTrusted(node)
+	return saveFile(p.trustedPeersPath, p.p2pSrv.TrustedNodes())
+}
+
+// RemoveTrusted removes a trusted peer and persists trusted peers.
+func (p *P2P) RemoveTrusted(node *discover.Node) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.p2pSrv.RemoveTrusted(node)
+	return saveFile(p.trustedPeersPath, p.p2pSrv.TrustedNodes())
+}
+
+// Disconnect disconnects the connected peer.
+func (p *P2P) Disconnect(id discover.NodeID) bool {
+	return p.p2pSrv.Disconnect(id)
 }
 
 func (p *P2P) Communicator() *comm.Communicator {
 	return p.comm
 }
 
+func (p *P2P) Server() *p2psrv.Server {
+	return p.p2pSrv
+}
+
 func (p *P2P) Enode() string {
 	return p.enode
 }
 
+// loadFile loads rlp encoded value from the file, failures are logged only.
+func loadFile(path string, val any, name string) {
+	if data, err := os.ReadFile(path); err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("failed to load "+name, "err", err)
+		}
+	} else if err := rlp.DecodeBytes(data, val); err != nil {
+		log.Warn("failed to load "+name, "err", err)
+	}
+}
+
+// saveFile saves rlp encoded value into the file.
+func saveFile(path string, val any) error {
+	data, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return errors.Wrap(err, "encode")
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
 func dedupNodeSlice(slice1, slice2 p2psrv.Nodes) p2psrv.Nodes {
 	foundMap := map[string]bool{}
 	var dedupedSlice p2psrv.Nodes
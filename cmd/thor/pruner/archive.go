@@ -0,0 +1,80 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package pruner
+
+import (
+	"encoding/binary"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// archiveStoreName is the name of the store indexing archived checkpoints, by block number to block ID.
+const archiveStoreName = "pruner.archive"
+
+// findCheckpoint returns the latest archived checkpoint below limit.
+func findCheckpoint(store kv.Store, limit uint32) (num uint32, id thor.Bytes32, ok bool, err error) {
+	it := store.Iterate(kv.Range{Limit: binary.BigEndian.AppendUint32(nil, limit)})
+	defer it.Release()
+
+	if it.Last() {
+		return binary.BigEndian.Uint32(it.Key()), thor.BytesToBytes32(it.Value()), true, nil
+	}
+	return 0, thor.Bytes32{}, false, it.Error()
+}
+
+// archiveStates retains states at checkpoints within [base, target) into the archive space, before
+// tries get checkpointed and history nodes get deleted. Checkpoints are archived incrementally, which
+// means only nodes updated since the previous checkpoint are copied.
+func (p *Pruner) archiveStates(targetChain *chain.Chain, base, target uint32) error {
+	if p.archiveSpacing == 0 {
+		return nil
+	}
+	store := p.db.NewStore(archiveStoreName)
+
+	first := (uint64(base) + uint64(p.archiveSpacing) - 1) / uint64(p.archiveSpacing) * uint64(p.archiveSpacing)
+	for num := first; num < uint64(target); num += uint64(p.archiveSpacing) {
+		// nodes older than the previous checkpoint have been archived along with it
+		prev, _, _, err := findCheckpoint(store, uint32(num))
+		if err != nil {
+			return err
+		}
+		summary, err := targetChain.GetBlockSummary(uint32(num))
+		if err != nil {
+			return err
+		}
+
+		accTrie := p.db.NewTrie(state.AccountTrieName, summary.Root())
+		accTrie.SetNoFillCache(true)
+
+		var sTries []*muxdb.Trie
+		if err := accTrie.Archive(p.ctx, prev, func(leaf *trie.Leaf) {
+			if sTrie := p.newStorageTrieIfUpdated(leaf, prev); sTrie != nil {
+				sTries = append(sTries, sTrie)
+			}
+		}); err != nil {
+			return err
+		}
+
+		for _, sTrie := range sTries {
+			sTrie.SetNoFillCache(true)
+			if err := sTrie.Archive(p.ctx, prev, nil); err != nil {
+				return err
+			}
+		}
+
+		id := summary.Header.ID()
+		if err := store.Put(binary.BigEndian.AppendUint32(nil, uint32(num)), id[:]); err != nil {
+			return err
+		}
+		logger.Debug("archived state", "num", num)
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/log"
@@ -28,25 +30,35 @@ var logger = log.WithContext("pkg", "pruner")
 const (
 	propsStoreName = "pruner.props"
 	statusKey      = "status"
+
+	freezeMargin = 8640 * 30 // about a month of blocks below the finalized are kept in the kv store
+	freezeRound  = 65536
 )
 
-// Pruner is a background task to prune tries.
+// Pruner is a background task to prune tries, and to migrate ancient blocks into the freezer.
 type Pruner struct {
-	db     *muxdb.MuxDB
-	repo   *chain.Repository
-	ctx    context.Context
-	cancel func()
-	goes   co.Goes
+	db             *muxdb.MuxDB
+	repo           *chain.Repository
+	committer      bft.Committer
+	archiveSpacing uint32
+	ctx            context.Context
+	cancel         func()
+	goes           co.Goes
 }
 
-// New creates and starts the pruner.
-func New(db *muxdb.MuxDB, repo *chain.Repository) *Pruner {
+// New creates and starts the pruner. Ancient blocks are migrated if committer is not nil
+// and the repository has the freezer attached.
+// If archiveSpacing is not zero, states at every archiveSpacing blocks are retained as checkpoints,
+// from which pruned states can be regenerated.
+func New(db *muxdb.MuxDB, repo *chain.Repository, committer bft.Committer, archiveSpacing uint32) *Pruner {
 	ctx, cancel := context.WithCancel(context.Background())
 	o := &Pruner{
-		db:     db,
-		repo:   repo,
-		ctx:    ctx,
-		cancel: cancel,
+		db:             db,
+		repo:           repo,
+		committer:      committer,
+		archiveSpacing: archiveSpacing,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 	o.goes.Go(func() {
 		if err := o.loop(); err != nil {
@@ -55,6 +67,15 @@ func New(db *muxdb.MuxDB, repo *chain.Repository) *Pruner {
 			}
 		}
 	})
+	if committer != nil {
+		o.goes.Go(func() {
+			if err := o.freezeLoop(); err != nil {
+				if err != context.Canceled && errors.Cause(err) != context.Canceled {
+					logger.Warn("freezer interrupted", "error", err)
+				}
+			}
+		})
+	}
 	return o
 }
 
@@ -109,6 +130,44 @@ func (p *Pruner) loop() error {
 	}
 }
 
+// freezeLoop migrates blocks far below the finalized checkpoint into the freezer.
+func (p *Pruner) freezeLoop() error {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		finalized := p.committer.Finalized()
+		if num := block.Number(finalized); num > freezeMargin {
+			frozen := p.repo.Frozen()
+			limit := min(num-freezeMargin, frozen+freezeRound)
+			if limit > frozen {
+				startTime := time.Now().UnixNano()
+				if err := p.repo.Freeze(p.ctx, finalized, limit); err != nil {
+					return errors.Wrap(err, "freeze")
+				}
+				if p.repo.Frozen() == frozen {
+					// no freezer attached
+					return nil
+				}
+				logger.Info("freeze blocks",
+					"range", fmt.Sprintf("#%v+%v", frozen, p.repo.Frozen()-frozen),
+					"et", time.Duration(time.Now().UnixNano()-startTime),
+				)
+				if limit < num-freezeMargin {
+					// more to freeze
+					continue
+				}
+			}
+		}
+
+		select {
+		case <-p.ctx.Done():
+			return p.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // newStorageTrieIfUpdated creates a storage trie object from the account leaf if the storage trie updated since base.
 func (p *Pruner) newStorageTrieIfUpdated(accLeaf *trie.Leaf, base uint32) *muxdb.Trie {
 	if len(accLeaf.Meta) == 0 {
@@ -181,6 +240,12 @@ func (p *Pruner) checkpointTries(targetChain *chain.Chain, base, target uint32)
 
 // pruneTries prunes index/account/storage tries in the range [base, target).
 func (p *Pruner) pruneTries(targetChain *chain.Chain, base, target uint32) error {
+	// states are archived before checkpointing, since nodes older than base are read from the deduped space,
+	// which is overwritten by the checkpoint with newer nodes
+	if err := p.archiveStates(targetChain, base, target); err != nil {
+		return errors.Wrap(err, "archive states")
+	}
+
 	if err := p.checkpointTries(targetChain, base, target); err != nil {
 		return errors.Wrap(err, "checkpoint tries")
 	}
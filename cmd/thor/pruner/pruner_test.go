@@ -109,7 +109,7 @@ func TestNewPruner(t *testing.T) {
 	b0, _, _, _ := gene.Build(stater)
 	repo, _ := chain.NewRepository(db, b0)
 
-	pr := New(db, repo)
+	pr := New(db, repo, nil, 0)
 	pr.Stop()
 }
 
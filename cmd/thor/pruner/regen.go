@@ -0,0 +1,157 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package pruner
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+const regenCacheSize = 16
+
+// Regenerator rebuilds pruned states of the best chain, by re-executing blocks from the nearest
+// archived checkpoint. It implements state.Regenerator.
+type Regenerator struct {
+	db         *muxdb.MuxDB
+	repo       *chain.Repository
+	forkConfig *thor.ForkConfig
+	cache      *lru.Cache // state root => overlay DB holds the regenerated state
+	mu         sync.Mutex
+
+	prunedBase   atomic.Uint32 // base of the pruner status loaded last time, which only grows
+	recentBlocks uint32        // states of recent blocks, which are never pruned
+}
+
+// NewRegenerator creates a regenerator.
+func NewRegenerator(db *muxdb.MuxDB, repo *chain.Repository, forkConfig *thor.ForkConfig) *Regenerator {
+	cache, _ := lru.New(regenCacheSize)
+	return &Regenerator{
+		db:           db,
+		repo:         repo,
+		forkConfig:   forkConfig,
+		cache:        cache,
+		recentBlocks: thor.MaxStateHistory,
+	}
+}
+
+// isPruned returns whether states at the block number are pruned. The pruner status is loaded only for states
+// older than the recent blocks and not below the base loaded last time.
+func (r *Regenerator) isPruned(num uint32) (bool, error) {
+	if num < r.prunedBase.Load() {
+		return true, nil
+	}
+	// the pruner never prunes states of recent blocks
+	if best := r.repo.BestBlockSummary().Header.Number(); uint64(num)+uint64(r.recentBlocks) >= uint64(best) {
+		return false, nil
+	}
+
+	var status status
+	if err := status.Load(r.db.NewStore(propsStoreName)); err != nil {
+		return false, errors.Wrap(err, "load status")
+	}
+	for {
+		base := r.prunedBase.Load()
+		if status.Base <= base || r.prunedBase.CompareAndSwap(base, status.Base) {
+			break
+		}
+	}
+	return num < status.Base, nil
+}
+
+// Regenerate implements state.Regenerator.
+func (r *Regenerator) Regenerate(root trie.Root) (*muxdb.MuxDB, error) {
+	if pruned, err := r.isPruned(root.Ver.Major); err != nil || !pruned {
+		return nil, err
+	}
+
+	if db, ok := r.cache.Get(root); ok {
+		return db.(*muxdb.MuxDB), nil
+	}
+
+	// regenerating is heavy, so never do it concurrently
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if db, ok := r.cache.Get(root); ok {
+		return db.(*muxdb.MuxDB), nil
+	}
+
+	bestChain := r.repo.NewBestChain()
+	summary, err := bestChain.GetBlockSummary(root.Ver.Major)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Root() != root {
+		return nil, errors.New("not a state of the best chain")
+	}
+
+	num, id, ok, err := findCheckpoint(r.db.NewStore(archiveStoreName), root.Ver.Major+1)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("no archived checkpoint")
+	}
+	if bestID, err := bestChain.GetBlockID(num); err != nil {
+		return nil, err
+	} else if bestID != id {
+		return nil, errors.Errorf("archived checkpoint #%v not on the best chain", num)
+	}
+
+	startTime := time.Now().UnixNano()
+	db := r.db.NewArchiveOverlay()
+	if err := r.replay(db, bestChain, num, root.Ver.Major); err != nil {
+		return nil, err
+	}
+	logger.Debug("regenerated state",
+		"range", fmt.Sprintf("#%v+%v", num, root.Ver.Major-num),
+		"et", time.Duration(time.Now().UnixNano()-startTime),
+	)
+
+	r.cache.Add(root, db)
+	return db, nil
+}
+
+// replay re-executes blocks in range (from, to] on the state at block from, and commits states into db.
+func (r *Regenerator) replay(db *muxdb.MuxDB, bestChain *chain.Chain, from, to uint32) error {
+	cons := consensus.New(r.repo, state.NewStater(db), r.forkConfig)
+
+	parent, err := bestChain.GetBlockSummary(from)
+	if err != nil {
+		return err
+	}
+	for num := from + 1; num <= to; num++ {
+		summary, err := bestChain.GetBlockSummary(num)
+		if err != nil {
+			return err
+		}
+		blk, err := r.repo.GetBlock(summary.Header.ID())
+		if err != nil {
+			return err
+		}
+		stage, _, err := cons.Process(parent, blk, blk.Header().Timestamp(), summary.Conflicts)
+		if err != nil {
+			return errors.Wrapf(err, "replay block #%v", num)
+		}
+		if _, err := stage.Commit(); err != nil {
+			return errors.Wrapf(err, "commit state #%v", num)
+		}
+		parent = summary
+	}
+	return nil
+}
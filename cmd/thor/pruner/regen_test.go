@@ -0,0 +1,164 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package pruner
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// newTestChain creates the test chain on a db in the temp dir. The returned func reopens the db, which drops nodes
+// cached in memory, so that they are read from the db afterwards.
+func newTestChain(t *testing.T) (*testchain.Chain, func() *muxdb.MuxDB) {
+	// deduped nodes are not partitioned in production
+	path := filepath.Join(t.TempDir(), "main.db")
+	opts := &muxdb.Options{
+		TrieCachedNodeTTL:          32,
+		TrieHistPartitionFactor:    1,
+		TrieDedupedPartitionFactor: math.MaxUint32,
+		TrieWillCleanHistory:       true,
+	}
+	db, err := muxdb.Open(path, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	reopen := func() *muxdb.MuxDB {
+		require.NoError(t, db.Close())
+		db, err = muxdb.Open(path, opts)
+		require.NoError(t, err)
+		return db
+	}
+
+	now := uint64(time.Now().Unix())
+	forkConfig := testchain.DefaultForkConfig
+	gene := genesis.NewDevnetWithConfig(genesis.DevConfig{ForkConfig: &forkConfig, LaunchTime: now - now%thor.BlockInterval()})
+	stater := state.NewStater(db)
+	b0, _, _, err := gene.Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, b0)
+	require.NoError(t, err)
+	logDB, err := logdb.NewMem()
+	require.NoError(t, err)
+	t.Cleanup(func() { logDB.Close() })
+
+	return testchain.New(db, gene, bft.NewMockedEngine(b0.Header().ID()), repo, stater, b0, logDB, &forkConfig), reopen
+}
+
+func TestRegenerate(t *testing.T) {
+	tchain, reopen := newTestChain(t)
+
+	var (
+		acc    = genesis.DevAccounts()[0]
+		to     = thor.BytesToAddress([]byte("to"))
+		values = [][2]*big.Int{{new(big.Int), new(big.Int)}}
+	)
+	setCreditPlan, ok := builtin.Prototype.ABI.MethodByName("setCreditPlan")
+	require.True(t, ok)
+
+	for i := 1; i <= 20; i++ {
+		clauses := []*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(int64(i)))}
+		// the storage of acc is updated in the first round, then in the second round after the checkpoint #12,
+		// so that the checkpoint #12 has a standalone node older than the base of the second round
+		if i == 8 || i == 14 {
+			data, err := setCreditPlan.EncodeInput(acc.Address, big.NewInt(int64(i)), big.NewInt(1))
+			require.NoError(t, err)
+			clauses = append(clauses, tx.NewClause(&builtin.Prototype.Address).WithData(data))
+		}
+		require.NoError(t, tchain.MintClauses(acc, clauses))
+
+		st := tchain.Stater().NewState(tchain.Repo().BestBlockSummary().Root())
+		bal, err := st.GetBalance(to)
+		require.NoError(t, err)
+		credit, _, err := builtin.Prototype.Native(st).Bind(acc.Address).CreditPlan()
+		require.NoError(t, err)
+		values = append(values, [2]*big.Int{bal, credit})
+	}
+
+	// two rounds, and checkpoints of the second round are archived incrementally on ones of the first round.
+	// the db is reopened before each round, so that nodes are read from the db as in production, rather than from the cache
+	var (
+		db   *muxdb.MuxDB
+		repo *chain.Repository
+		err  error
+	)
+	for _, r := range [][2]uint32{{0, 9}, {9, 18}} {
+		db = reopen()
+		repo, err = chain.NewRepository(db, tchain.GenesisBlock())
+		require.NoError(t, err)
+
+		p := &Pruner{db: db, repo: repo, archiveSpacing: 4, ctx: context.Background()}
+		require.NoError(t, p.pruneTries(repo.NewBestChain(), r[0], r[1]))
+		require.NoError(t, (&status{Base: r[1]}).Save(db.NewStore(propsStoreName)))
+	}
+
+	// checkpoints at #0, #4, #8, #12 and #16
+	num, _, ok, err := findCheckpoint(db.NewStore(archiveStoreName), 16)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(12), num)
+
+	var (
+		regen     = NewRegenerator(db, repo, tchain.GetForkConfig())
+		stater    = state.NewStater(db).WithRegenerator(regen)
+		bestChain = repo.NewBestChain()
+	)
+	// all blocks of the test chain are recent
+	regen.recentBlocks = 0
+
+	for i, want := range values {
+		summary, err := bestChain.GetBlockSummary(uint32(i))
+		require.NoError(t, err)
+
+		st := stater.NewState(summary.Root())
+		bal, err := st.GetBalance(to)
+		require.NoError(t, err)
+		assert.Equal(t, want[0], bal, "balance at #%v", i)
+		credit, _, err := builtin.Prototype.Native(st).Bind(acc.Address).CreditPlan()
+		require.NoError(t, err)
+		assert.Equal(t, want[1], credit, "credit at #%v", i)
+	}
+
+	// cached
+	summary, err := bestChain.GetBlockSummary(14)
+	require.NoError(t, err)
+	db1, err := regen.Regenerate(summary.Root())
+	require.NoError(t, err)
+	db2, err := regen.Regenerate(summary.Root())
+	require.NoError(t, err)
+	assert.Same(t, db1, db2)
+
+	// not pruned
+	summary, err = bestChain.GetBlockSummary(18)
+	require.NoError(t, err)
+	db1, err = regen.Regenerate(summary.Root())
+	require.NoError(t, err)
+	assert.Nil(t, db1)
+
+	// not a state of the best chain
+	root := summary.Root()
+	root.Ver.Major = 13
+	_, err = regen.Regenerate(root)
+	assert.Error(t, err)
+	_, err = stater.NewState(root).GetBalance(to)
+	assert.Error(t, err)
+}
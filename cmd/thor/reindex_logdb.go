@@ -0,0 +1,255 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/cheggaaa/pb.v1"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// reindexBatchSize is the count of blocks read in parallel, and written in one log db transaction.
+const reindexBatchSize = 256
+
+// logBlock is a block along with its receipts.
+type logBlock struct {
+	block    *block.Block
+	receipts tx.Receipts
+}
+
+// reindexMode decides how logs of the range are reindexed.
+type reindexMode int
+
+const (
+	reindexRebuild reindexMode = iota // rewrite logs of all blocks
+	reindexRepair                     // rewrite logs of inconsistent blocks only
+	reindexVerify                     // only report inconsistent blocks
+)
+
+// reindexLogDB reindexes logs of blocks in the range [from, to] of the best chain. Blocks and receipts are read
+// in parallel, and written in sequence order, batch by batch. It returns the count of inconsistent blocks found,
+// which is always zero in rebuild mode.
+func reindexLogDB(ctx context.Context, repo *chain.Repository, logDB *logdb.LogDB, from, to uint32, mode reindexMode) (int, error) {
+	pb := pb.New64(int64(to)).
+		Set64(int64(from - 1)).
+		SetMaxWidth(90).
+		Start()
+	defer func() { pb.NotPrint = true }()
+
+	var (
+		goes    co.Goes
+		pumpErr error
+		ch      = make(chan []*logBlock, 4)
+		cancel  func()
+	)
+	ctx, cancel = context.WithCancel(ctx)
+	defer goes.Wait()
+	goes.Go(func() {
+		defer close(ch)
+		pumpErr = pumpLogBlocks(ctx, repo, repo.BestBlockSummary().Header.ID(), from, to, ch)
+	})
+	defer cancel()
+
+	var (
+		w            = logDB.NewWriterSyncOff()
+		inconsistent int
+	)
+	for batch := range ch {
+		first, last := batch[0].block.Header().Number(), batch[len(batch)-1].block.Header().Number()
+
+		rewrite := batch
+		if mode != reindexRebuild {
+			bad, err := inconsistentLogBlocks(ctx, logDB, batch)
+			if err != nil {
+				return 0, err
+			}
+			for _, b := range bad {
+				fmt.Printf("\rlogs of block #%v inconsistent\n", b.block.Header().Number())
+			}
+			inconsistent += len(bad)
+			rewrite = bad
+			if mode == reindexVerify {
+				rewrite = nil
+			}
+		} else if err := w.DeleteRange(first, last); err != nil {
+			return 0, err
+		}
+
+		for _, b := range rewrite {
+			if mode == reindexRepair {
+				num := b.block.Header().Number()
+				if err := w.DeleteRange(num, num); err != nil {
+					return 0, err
+				}
+			}
+			if err := w.Write(b.block, b.receipts); err != nil {
+				return 0, err
+			}
+		}
+		if err := w.Commit(); err != nil {
+			return 0, err
+		}
+		pb.Set64(int64(last))
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+	}
+	if pumpErr != nil {
+		return 0, pumpErr
+	}
+	pb.Finish()
+	return inconsistent, nil
+}
+
+// inconsistentLogBlocks returns blocks of the batch, whose logs in the log db don't match their receipts.
+func inconsistentLogBlocks(ctx context.Context, logDB *logdb.LogDB, batch []*logBlock) ([]*logBlock, error) {
+	rng := &logdb.Range{
+		From: batch[0].block.Header().Number(),
+		To:   batch[len(batch)-1].block.Header().Number(),
+	}
+	events, err := logDB.FilterEvents(ctx, &logdb.EventFilter{Range: rng})
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := logDB.FilterTransfers(ctx, &logdb.TransferFilter{Range: rng})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blockEvents    = make(map[uint32][]*logdb.Event)
+		blockTransfers = make(map[uint32][]*logdb.Transfer)
+		bad            []*logBlock
+	)
+	for _, ev := range events {
+		blockEvents[ev.BlockNumber] = append(blockEvents[ev.BlockNumber], ev)
+	}
+	for _, tr := range transfers {
+		blockTransfers[tr.BlockNumber] = append(blockTransfers[tr.BlockNumber], tr)
+	}
+	for _, b := range batch {
+		num := b.block.Header().Number()
+		expectedEvents, expectedTransfers := expectedBlockLogs(b.block, b.receipts)
+		if !equalEvents(blockEvents[num], expectedEvents) || !equalTransfers(blockTransfers[num], expectedTransfers) {
+			bad = append(bad, b)
+		}
+	}
+	return bad, nil
+}
+
+// pumpLogBlocks reads blocks and receipts in the range [from, to] of the chain in parallel, and sends them
+// batch by batch in block number order.
+func pumpLogBlocks(ctx context.Context, repo *chain.Repository, headID thor.Bytes32, from, to uint32, ch chan<- []*logBlock) error {
+	chain := repo.NewChain(headID)
+	for start := from; start <= to; {
+		end := min(to, start+reindexBatchSize-1)
+
+		// block ids are resolved sequentially, since the chain is not safe for concurrent use
+		ids := make([]thor.Bytes32, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			id, err := chain.GetBlockID(i)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		var (
+			batch = make([]*logBlock, len(ids))
+			errs  = make([]error, len(ids))
+		)
+		select {
+		case <-co.Parallel(func(queue chan<- func()) {
+			for i, id := range ids {
+				queue <- func() {
+					batch[i], errs[i] = loadLogBlock(repo, id)
+				}
+			}
+		}):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case ch <- batch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// recreate the chain to avoid the internal trie holds too many nodes.
+		if (end-from+1)%(reindexBatchSize*40) == 0 {
+			chain = repo.NewChain(headID)
+		}
+		if end == to {
+			break
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// loadLogBlock loads the block and its receipts, and warms up tx ids and origins which are costly to compute.
+func loadLogBlock(repo *chain.Repository, id thor.Bytes32) (*logBlock, error) {
+	b, err := repo.GetBlock(id)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := repo.GetBlockReceipts(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range b.Transactions() {
+		tx.ID()
+		if _, err := tx.Origin(); err != nil {
+			return nil, err
+		}
+	}
+	return &logBlock{b, receipts}, nil
+}
+
+// parseBlockRange parses the block range in form of 'from-to', where 'to' can be 'best' for the best block.
+func parseBlockRange(s string, best uint32) (uint32, uint32, error) {
+	fromStr, toStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, errors.New("range should be in form of 'from-to'")
+	}
+	from, err := strconv.ParseUint(strings.TrimSpace(fromStr), 10, 32)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "from")
+	}
+	to := uint64(best)
+	if toStr = strings.TrimSpace(toStr); toStr != "best" {
+		if to, err = strconv.ParseUint(toStr, 10, 32); err != nil {
+			return 0, 0, errors.Wrap(err, "to")
+		}
+	}
+	if from > to {
+		return 0, 0, errors.New("from is greater than to")
+	}
+	if to > uint64(best) {
+		return 0, 0, errors.Errorf("block #%v beyond the best block", to)
+	}
+	return uint32(from), uint32(to), nil
+}
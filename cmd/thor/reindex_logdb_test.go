@@ -0,0 +1,86 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestParseBlockRange(t *testing.T) {
+	from, to, err := parseBlockRange("5-best", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{5, 100}, []uint32{from, to})
+
+	from, to, err = parseBlockRange("5-10", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{5, 10}, []uint32{from, to})
+
+	for _, s := range []string{"5", "10-5", "5-101", "x-10"} {
+		_, _, err = parseBlockRange(s, 100)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestReindexLogDB(t *testing.T) {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	to := thor.BytesToAddress([]byte("to"))
+	for range 6 {
+		require.NoError(t, chain.MintClauses(genesis.DevAccounts()[0], []*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}))
+	}
+	var (
+		repo = chain.Repo()
+		db   = chain.LogDB()
+		ctx  = context.Background()
+		best = repo.BestBlockSummary().Header.Number()
+		w    = db.NewWriter()
+		dump = func() []*logdb.Transfer {
+			trs, err := db.FilterTransfers(ctx, nil)
+			require.NoError(t, err)
+			return trs
+		}
+		origin = dump()
+	)
+	require.Len(t, origin, 6)
+
+	// logs of block #2 lost
+	require.NoError(t, w.DeleteRange(2, 2))
+	require.NoError(t, w.Commit())
+
+	inconsistent, err := reindexLogDB(ctx, repo, db, 1, best, reindexVerify)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inconsistent)
+	assert.Len(t, dump(), 5)
+
+	inconsistent, err = reindexLogDB(ctx, repo, db, 1, best, reindexRepair)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inconsistent)
+	assert.Equal(t, origin, dump())
+
+	inconsistent, err = reindexLogDB(ctx, repo, db, 1, best, reindexVerify)
+	require.NoError(t, err)
+	assert.Equal(t, 0, inconsistent)
+
+	// rebuild
+	require.NoError(t, w.DeleteRange(3, best))
+	require.NoError(t, w.Commit())
+	inconsistent, err = reindexLogDB(ctx, repo, db, 3, best, reindexRebuild)
+	require.NoError(t, err)
+	assert.Equal(t, 0, inconsistent)
+	assert.Equal(t, origin, dump())
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package replica implements the read-only API replica, which serves the API from the data dir
+// written by another thor process.
+package replica
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+)
+
+var logger = log.WithContext("pkg", "replica")
+
+// Follower follows the best block of the writer node, by polling its API, and reloads databases opened
+// read-only once the best block changed. Reloading reopens databases, so it's done at most once per reload
+// interval, and the replica lags behind the writer by up to the interval. Reads failed due to files removed
+// by the writer reload databases themselves.
+//
+// It's also the bft committer of the replica, whose finalized checkpoint is reloaded along.
+type Follower struct {
+	db             *muxdb.MuxDB
+	repo           *chain.Repository
+	forkConfig     *thor.ForkConfig
+	writer         *thorclient.Client
+	interval       time.Duration
+	reloadInterval time.Duration
+	lastReload     time.Time
+
+	engine atomic.Pointer[bft.Engine]
+	ctx    context.Context
+	cancel func()
+	goes   co.Goes
+}
+
+// NewFollower creates and starts the follower.
+func NewFollower(
+	db *muxdb.MuxDB,
+	repo *chain.Repository,
+	forkConfig *thor.ForkConfig,
+	writerURL string,
+	interval time.Duration,
+	reloadInterval time.Duration,
+) (*Follower, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &Follower{
+		db:             db,
+		repo:           repo,
+		forkConfig:     forkConfig,
+		writer:         thorclient.New(writerURL),
+		interval:       interval,
+		reloadInterval: reloadInterval,
+		lastReload:     time.Now(),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	if err := f.reloadEngine(); err != nil {
+		cancel()
+		return nil, err
+	}
+	f.goes.Go(f.loop)
+	return f, nil
+}
+
+// Stop stops the follower.
+func (f *Follower) Stop() {
+	f.cancel()
+	f.goes.Wait()
+}
+
+// Finalized returns the finalized checkpoint.
+func (f *Follower) Finalized() thor.Bytes32 {
+	return f.engine.Load().Finalized()
+}
+
+// Justified returns the justified checkpoint.
+func (f *Follower) Justified() (thor.Bytes32, error) {
+	return f.engine.Load().Justified()
+}
+
+func (f *Follower) loop() {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.sync(); err != nil {
+				logger.Warn("failed to follow the writer", "err", err)
+			}
+		}
+	}
+}
+
+// sync reloads databases if the best block of the writer is different from the local one, and the reload
+// interval has elapsed since the last reload.
+// If the writer is unreachable, databases are reloaded anyway, since they might still be written.
+func (f *Follower) sync() error {
+	if time.Since(f.lastReload) < f.reloadInterval {
+		return nil
+	}
+	if best, err := f.writer.Block("best"); err != nil {
+		logger.Debug("failed to get the best block of the writer", "err", err)
+	} else if best.ID == f.repo.BestBlockSummary().Header.ID() {
+		return nil
+	}
+
+	if err := f.db.Reload(); err != nil {
+		return errors.Wrap(err, "reload main db")
+	}
+	f.lastReload = time.Now()
+	if err := f.repo.Refresh(); err != nil {
+		return errors.Wrap(err, "refresh repository")
+	}
+	return f.reloadEngine()
+}
+
+// reloadEngine recreates the bft engine, which loads the finalized checkpoint saved by the writer.
+// Nothing of the engine is cached across reloads, since it might be computed from data not yet written.
+func (f *Follower) reloadEngine() error {
+	engine, err := bft.NewEngine(f.repo, f.db, f.forkConfig, thor.Address{})
+	if err != nil {
+		return errors.Wrap(err, "load bft engine")
+	}
+	f.engine.Store(engine)
+	return nil
+}
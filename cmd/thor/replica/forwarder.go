@@ -0,0 +1,143 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package replica
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+const forwardTimeout = 10 * time.Second
+
+// TxForwarder stands in for the tx pool of a replica, which has no pool of its own.
+// Transactions submitted are forwarded to the API of the writer node, and pending transactions are
+// never visible.
+type TxForwarder struct {
+	url    string
+	client *http.Client
+	feed   event.Feed // never sent
+}
+
+// NewTxForwarder creates a forwarder to the API of the writer node at the given url.
+func NewTxForwarder(url string) *TxForwarder {
+	return &TxForwarder{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: forwardTimeout},
+	}
+}
+
+// AddLocal forwards the tx to the writer node. Errors responded by the writer are returned with the same status.
+func (f *TxForwarder) AddLocal(newTx *tx.Transaction) error {
+	return f.forward(newTx, nil)
+}
+
+// ReplaceLocal forwards the tx along with the id of the tx to replace to the writer node.
+func (f *TxForwarder) ReplaceLocal(newTx *tx.Transaction, replaces thor.Bytes32) error {
+	return f.forward(newTx, &replaces)
+}
+
+func (f *TxForwarder) forward(newTx *tx.Transaction, replaces *thor.Bytes32) error {
+	raw, err := newTx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return f.post("/transactions", &api.SendTxRequest{RawTx: api.RawTx{Raw: hexutil.Encode(raw)}, Replaces: replaces}, nil)
+}
+
+// AddBundle forwards the bundle to the writer node, and returns the bundle id responded.
+func (f *TxForwarder) AddBundle(txs tx.Transactions) (thor.Bytes32, error) {
+	req := &api.SendBundleRequest{Txs: make([]*api.RawTx, 0, len(txs))}
+	for _, trx := range txs {
+		raw, err := trx.MarshalBinary()
+		if err != nil {
+			return thor.Bytes32{}, err
+		}
+		req.Txs = append(req.Txs, &api.RawTx{Raw: hexutil.Encode(raw)})
+	}
+
+	var result api.SendBundleResult
+	if err := f.post("/transactions/bundles", req, &result); err != nil {
+		return thor.Bytes32{}, err
+	}
+	if result.ID == nil {
+		return thor.Bytes32{}, restutil.HTTPError(errors.New("forward bundle: missing id in response"), http.StatusBadGateway)
+	}
+	return *result.ID, nil
+}
+
+// post sends the request to the path of the writer API, and decodes the response into result if not nil.
+func (f *TxForwarder) post(path string, req, result any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := f.client.Post(f.url+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return restutil.HTTPError(errors.Wrap(err, "forward tx"), http.StatusBadGateway)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return restutil.HTTPError(errors.New(strings.TrimSpace(string(msg))), resp.StatusCode)
+	}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return restutil.HTTPError(errors.Wrap(err, "decode response"), http.StatusBadGateway)
+		}
+	}
+	return nil
+}
+
+// Get always returns nil, since the replica has no pending tx.
+func (f *TxForwarder) Get(thor.Bytes32) *tx.Transaction {
+	return nil
+}
+
+// Dump always returns nil, since the replica has no pending tx.
+func (f *TxForwarder) Dump() tx.Transactions {
+	return nil
+}
+
+// Len always returns 0, since the replica has no pending tx.
+func (f *TxForwarder) Len() int {
+	return 0
+}
+
+// GetStatus always returns nil, since status of txs is recorded by the pool of the writer.
+func (f *TxForwarder) GetStatus(thor.Bytes32) []txpool.TxTransition {
+	return nil
+}
+
+// GetBundleStatus always returns nil, since bundles are kept by the pool of the writer.
+func (f *TxForwarder) GetBundleStatus(thor.Bytes32) []txpool.TxTransition {
+	return nil
+}
+
+// GetDependencies always returns nil, since the replica has no pending tx.
+func (f *TxForwarder) GetDependencies(thor.Bytes32) *txpool.TxDeps {
+	return nil
+}
+
+// SubscribeTxEvent returns a subscription which never fires, since the replica has no pending tx.
+func (f *TxForwarder) SubscribeTxEvent(ch chan *txpool.TxEvent) event.Subscription {
+	return f.feed.Subscribe(ch)
+}
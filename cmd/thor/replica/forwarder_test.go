@@ -0,0 +1,127 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package replica
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/restutil"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestTxForwarder(t *testing.T) {
+	trx := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Nonce(1).Build(), genesis.DevAccounts()[0].PrivateKey)
+
+	var (
+		received *tx.Transaction
+		replaces *thor.Bytes32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transactions", r.URL.Path)
+
+		var body api.SendTxRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		replaces = body.Replaces
+		raw, err := hexutil.Decode(body.Raw)
+		require.NoError(t, err)
+		received = new(tx.Transaction)
+		require.NoError(t, received.UnmarshalBinary(raw))
+
+		if received.Nonce() == 2 {
+			http.Error(w, "tx rejected: known tx", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	f := NewTxForwarder(srv.URL + "/")
+
+	assert.NoError(t, f.AddLocal(trx))
+	assert.Equal(t, trx.ID(), received.ID())
+	assert.Nil(t, replaces)
+
+	replacement := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Nonce(1).GasPriceCoef(10).Build(), genesis.DevAccounts()[0].PrivateKey)
+	assert.NoError(t, f.ReplaceLocal(replacement, trx.ID()))
+	assert.Equal(t, replacement.ID(), received.ID())
+	assert.Equal(t, trx.ID(), *replaces)
+
+	rejected := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Nonce(2).Build(), genesis.DevAccounts()[0].PrivateKey)
+	err := f.AddLocal(rejected)
+	assert.Equal(t, "tx rejected: known tx", err.Error())
+	assert.Equal(t, http.StatusBadRequest, statusOf(err))
+
+	assert.Nil(t, f.Get(trx.ID()))
+	assert.Zero(t, f.Len())
+}
+
+func TestTxForwarder_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	trx := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Build(), genesis.DevAccounts()[0].PrivateKey)
+	err := NewTxForwarder(srv.URL).AddLocal(trx)
+	assert.Equal(t, http.StatusBadGateway, statusOf(err))
+}
+
+func TestTxForwarder_AddBundle(t *testing.T) {
+	txs := tx.Transactions{
+		tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Nonce(1).Build(), genesis.DevAccounts()[0].PrivateKey),
+		tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Nonce(1).Build(), genesis.DevAccounts()[1].PrivateKey),
+	}
+	bundleID := thor.Bytes32{1}
+
+	var received tx.Transactions
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transactions/bundles", r.URL.Path)
+
+		var body api.SendBundleRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for _, raw := range body.Txs {
+			trx, err := raw.Decode()
+			require.NoError(t, err)
+			received = append(received, trx)
+		}
+		if len(received) == 1 {
+			http.Error(w, "tx rejected: bundle member 0: already in the pool", http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(&api.SendBundleResult{ID: &bundleID})
+	}))
+	defer srv.Close()
+
+	f := NewTxForwarder(srv.URL)
+
+	id, err := f.AddBundle(txs)
+	assert.NoError(t, err)
+	assert.Equal(t, bundleID, id)
+	assert.Equal(t, []thor.Bytes32{txs[0].ID(), txs[1].ID()}, []thor.Bytes32{received[0].ID(), received[1].ID()})
+
+	received = nil
+	_, err = f.AddBundle(txs[:1])
+	assert.Equal(t, "tx rejected: bundle member 0: already in the pool", err.Error())
+	assert.Equal(t, http.StatusForbidden, statusOf(err))
+	assert.Nil(t, f.GetBundleStatus(bundleID))
+}
+
+// statusOf returns the status the API responds with the error.
+func statusOf(err error) int {
+	rec := httptest.NewRecorder()
+	restutil.WrapHandlerFunc(func(http.ResponseWriter, *http.Request) error {
+		return err
+	})(rec, httptest.NewRequest(http.MethodPost, "/transactions", nil))
+	return rec.Code
+}
@@ -62,7 +62,13 @@ func NewCore(
 	}
 }
 
-func (c *Core) Pack(pendingTxs tx.Transactions, onDemand bool) ([]*tx.Transaction, error) {
+// Pack packs a new block with the bundles and pending txs, and returns txs and bundles to be removed from the pool,
+// the latter with the errors of adopting them.
+func (c *Core) Pack(
+	bundles []*txpool.Bundle,
+	pendingTxs tx.Transactions,
+	onDemand bool,
+) ([]*tx.Transaction, map[thor.Bytes32]error, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -75,7 +81,10 @@ func (c *Core) Pack(pendingTxs tx.Transactions, onDemand bool) ([]*tx.Transactio
 		now = best.Header.Timestamp() + c.options.BlockInterval
 	}
 
-	var txsToRemove []*tx.Transaction
+	var (
+		txsToRemove   []*tx.Transaction
+		bundlesToDrop = make(map[thor.Bytes32]error)
+	)
 
 	if c.options.GasLimit == 0 {
 		suggested := c.bandwidth.SuggestGasLimit()
@@ -84,10 +93,18 @@ func (c *Core) Pack(pendingTxs tx.Transactions, onDemand bool) ([]*tx.Transactio
 
 	flow, _, err := c.packer.Mock(best, now, c.options.GasLimit)
 	if err != nil {
-		return nil, errors.WithMessage(err, "mock packer")
+		return nil, nil, errors.WithMessage(err, "mock packer")
 	}
 
 	startTime := mclock.Now()
+	for _, bundle := range bundles {
+		if err := flow.AdoptBundle(bundle.Txs); err != nil {
+			if packer.IsGasLimitReached(err) || packer.IsTxNotAdoptableNow(err) {
+				continue
+			}
+			bundlesToDrop[bundle.ID] = err
+		}
+	}
 	for _, tx := range pendingTxs {
 		if err := flow.Adopt(tx); err != nil {
 			if packer.IsGasLimitReached(err) {
@@ -102,33 +119,33 @@ func (c *Core) Pack(pendingTxs tx.Transactions, onDemand bool) ([]*tx.Transactio
 
 	b, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey, 0, false)
 	if err != nil {
-		return nil, errors.WithMessage(err, "pack")
+		return nil, nil, errors.WithMessage(err, "pack")
 	}
 	execElapsed := mclock.Now() - startTime
 
 	// If there is no tx packed in the on-demanded block then skip
 	if onDemand && len(b.Transactions()) == 0 {
-		return nil, nil
+		return nil, bundlesToDrop, nil
 	}
 
 	if _, err := stage.Commit(); err != nil {
-		return nil, errors.WithMessage(err, "commit state")
+		return nil, nil, errors.WithMessage(err, "commit state")
 	}
 
 	if !c.options.SkipLogs {
 		w := c.logDB.NewWriter()
 		if err := w.Write(b, receipts); err != nil {
-			return nil, errors.WithMessage(err, "write logs")
+			return nil, nil, errors.WithMessage(err, "write logs")
 		}
 
 		if err := w.Commit(); err != nil {
-			return nil, errors.WithMessage(err, "commit logs")
+			return nil, nil, errors.WithMessage(err, "commit logs")
 		}
 	}
 
 	// ignore fork when solo
 	if err := c.repo.AddBlock(b, receipts, 0, true); err != nil {
-		return nil, errors.WithMessage(err, "commit block")
+		return nil, nil, errors.WithMessage(err, "commit block")
 	}
 	realElapsed := mclock.Now() - startTime
 	commitElapsed := mclock.Now() - startTime - execElapsed
@@ -146,7 +163,7 @@ func (c *Core) Pack(pendingTxs tx.Transactions, onDemand bool) ([]*tx.Transactio
 	)
 	logger.Debug(b.String())
 
-	return txsToRemove, nil
+	return txsToRemove, bundlesToDrop, nil
 }
 
 func (c *Core) IsExecutable(trx *tx.Transaction) (bool, error) {
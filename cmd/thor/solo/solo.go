@@ -23,6 +23,7 @@ import (
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
 )
 
 var (
@@ -53,6 +54,10 @@ type TxPool interface {
 	Executables() tx.Transactions
 	// Remove removes a transaction from the pool
 	Remove(txHash thor.Bytes32, txID thor.Bytes32) bool
+	// Bundles returns the pending bundles
+	Bundles() []*txpool.Bundle
+	// DropBundle removes a bundle from the pool with the reason
+	DropBundle(id thor.Bytes32, reason string) bool
 }
 
 // New returns Solo instance
@@ -102,12 +107,15 @@ func (s *Solo) loop(ctx context.Context) {
 			return
 		case <-time.After(time.Duration(1) * time.Second):
 			if left := uint64(time.Now().Unix()) % s.options.BlockInterval; left == 0 {
-				if txs, err := s.core.Pack(s.txPool.Executables(), false); err != nil {
+				if txs, bundleErrs, err := s.core.Pack(s.txPool.Bundles(), s.txPool.Executables(), false); err != nil {
 					logger.Error("failed to pack block", "err", err)
 				} else {
 					for _, tx := range txs {
 						s.txPool.Remove(tx.Hash(), tx.ID())
 					}
+					for id, err := range bundleErrs {
+						s.txPool.DropBundle(id, err.Error())
+					}
 				}
 			}
 		}
@@ -149,7 +157,7 @@ func (s *Solo) init(ctx context.Context) error {
 		case <-time.After(time.Duration(int64(s.options.BlockInterval)-time.Now().Unix()%int64(s.options.BlockInterval)) * time.Second):
 		}
 	}
-	if _, err := s.core.Pack(tx.Transactions{baseGasPriceTx}, false); err != nil {
+	if _, _, err := s.core.Pack(nil, tx.Transactions{baseGasPriceTx}, false); err != nil {
 		return errors.WithMessage(err, "failed to pack base gas price transaction")
 	}
 
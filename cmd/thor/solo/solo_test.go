@@ -8,10 +8,12 @@ package solo
 import (
 	"context"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/chain"
@@ -20,6 +22,7 @@ import (
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 )
 
@@ -61,3 +64,28 @@ func TestInitSolo(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, baseGasPrice, currentBGP)
 }
+
+func TestPackDropsRevertedBundle(t *testing.T) {
+	solo := newSolo()
+	require.NoError(t, solo.init(context.Background()))
+
+	to := thor.BytesToAddress([]byte("to"))
+	transfer, err := solo.newTx([]*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}, genesis.DevAccounts()[0])
+	require.NoError(t, err)
+	// transfers more than the balance, to be reverted
+	reverted, err := solo.newTx([]*tx.Clause{tx.NewClause(&to).WithValue(new(big.Int).Lsh(big.NewInt(1), 200))}, genesis.DevAccounts()[1])
+	require.NoError(t, err)
+
+	id, err := solo.txPool.AddBundle(tx.Transactions{transfer, reverted})
+	require.NoError(t, err)
+
+	_, bundleErrs, err := solo.core.Pack(solo.txPool.Bundles(), nil, true)
+	require.NoError(t, err)
+	require.Contains(t, bundleErrs, id)
+	assert.Equal(t, "bundle member 1: tx not adoptable forever: reverted", bundleErrs[id].Error())
+
+	for id, err := range bundleErrs {
+		solo.txPool.DropBundle(id, err.Error())
+	}
+	assert.Empty(t, solo.txPool.Bundles())
+}
@@ -72,7 +72,7 @@ func (o *OnDemandTxPool) AddLocal(newTx *tx.Transaction) error {
 	})
 
 	if executable {
-		toRemove, err := o.engine.Pack(tx.Transactions{newTx}, true)
+		toRemove, _, err := o.engine.Pack(nil, tx.Transactions{newTx}, true)
 		if err != nil {
 			return err
 		}
@@ -84,6 +84,82 @@ func (o *OnDemandTxPool) AddLocal(newTx *tx.Transaction) error {
 	return nil
 }
 
+// ReplaceLocal adds the tx in place of the pending one of the given id. Since executable txs are packed once added,
+// only non-executable txs can be replaced, and fees are not required to be bumped.
+func (o *OnDemandTxPool) ReplaceLocal(newTx *tx.Transaction, replaces thor.Bytes32) error {
+	o.mu.Lock()
+	old, ok := o.txsByID[replaces]
+	o.mu.Unlock()
+	if !ok {
+		return restutil.Forbidden(errors.New("tx rejected: tx to replace not found"))
+	}
+	oldOrigin, _ := old.Origin()
+	if newOrigin, _ := newTx.Origin(); newOrigin != oldOrigin {
+		return restutil.Forbidden(errors.New("tx rejected: origin mismatch with tx to replace"))
+	}
+
+	if err := o.AddLocal(newTx); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	delete(o.txsByID, replaces)
+	o.mu.Unlock()
+	return nil
+}
+
+// AddBundle packs the bundle at once, and rejects it if it can't be adopted as a whole.
+func (o *OnDemandTxPool) AddBundle(txs tx.Transactions) (thor.Bytes32, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(txs) == 0 {
+		return thor.Bytes32{}, restutil.BadRequest(errors.New("bad tx: empty bundle"))
+	}
+	if len(txs) > txpool.MaxBundleSize {
+		return thor.Bytes32{}, restutil.Forbidden(errors.New("tx rejected: bundle too large"))
+	}
+	for _, trx := range txs {
+		if trx.ChainTag() != o.engine.repo.ChainTag() {
+			return thor.Bytes32{}, restutil.BadRequest(errors.New("bad tx: chain tag mismatch"))
+		}
+	}
+
+	bundle := &txpool.Bundle{ID: txpool.BundleID(txs), Txs: txs}
+	_, bundleErrs, err := o.engine.Pack([]*txpool.Bundle{bundle}, nil, true)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	if err := bundleErrs[bundle.ID]; err != nil {
+		return thor.Bytes32{}, restutil.Forbidden(errors.New("tx rejected: " + err.Error()))
+	}
+	// not adoptable now, e.g. some member reverted
+	if packed, err := o.engine.repo.NewBestChain().HasTransaction(txs[0].ID(), txs[0].BlockRef().Number()); err != nil {
+		return thor.Bytes32{}, err
+	} else if !packed {
+		return thor.Bytes32{}, restutil.Forbidden(errors.New("tx rejected: bundle not adoptable"))
+	}
+	return bundle.ID, nil
+}
+
+// GetBundleStatus always returns nil, since bundles are packed once added.
+func (o *OnDemandTxPool) GetBundleStatus(thor.Bytes32) []txpool.TxTransition {
+	return nil
+}
+
+// GetDependencies always returns nil, since txs are packed once added, and never pending.
+func (o *OnDemandTxPool) GetDependencies(thor.Bytes32) *txpool.TxDeps {
+	return nil
+}
+
+// Bundles always returns nil, since bundles are packed once added.
+func (o *OnDemandTxPool) Bundles() []*txpool.Bundle {
+	return nil
+}
+
+func (o *OnDemandTxPool) DropBundle(thor.Bytes32, string) bool {
+	return false
+}
+
 func (o *OnDemandTxPool) Dump() tx.Transactions {
 	o.mu.Lock()
 	defer o.mu.Unlock()
@@ -106,6 +182,11 @@ func (o *OnDemandTxPool) SubscribeTxEvent(ch chan *txpool.TxEvent) event.Subscri
 	return o.scope.Track(o.txFeed.Subscribe(ch))
 }
 
+// GetStatus always returns nil, since txs are packed once added, and the status is not recorded.
+func (o *OnDemandTxPool) GetStatus(thor.Bytes32) []txpool.TxTransition {
+	return nil
+}
+
 func (o *OnDemandTxPool) Executables() tx.Transactions {
 	o.mu.Lock()
 	defer o.mu.Unlock()
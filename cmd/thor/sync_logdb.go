@@ -119,9 +119,14 @@ func seekLogDBSyncPosition(repo *chain.Repository, logDB *logdb.LogDB) (uint32,
 	if err != nil {
 		return 0, err
 	}
+	oldest, err := logDB.OldestBlockNum()
+	if err != nil {
+		return 0, err
+	}
 
 	if block.Number(newestID) == 0 {
-		return 0, nil
+		// all logs may have been pruned
+		return min(oldest, best.Number()), nil
 	}
 
 	if newestID == best.ID() {
@@ -139,6 +144,10 @@ func seekLogDBSyncPosition(repo *chain.Repository, logDB *logdb.LogDB) (uint32,
 	}
 
 	for header.Number() > 0 {
+		if header.Number() < oldest {
+			// logs before the oldest block are pruned
+			return oldest, nil
+		}
 		has, err := logDB.HasBlockID(header.ID())
 		if err != nil {
 			return 0, err
@@ -157,9 +166,19 @@ func seekLogDBSyncPosition(repo *chain.Repository, logDB *logdb.LogDB) (uint32,
 }
 
 func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository, logDB *logdb.LogDB) error {
+	// logs before the oldest block are pruned
+	oldest, err := logDB.OldestBlockNum()
+	if err != nil {
+		return err
+	}
+	startBlockNum := max(oldest, 1)
+	if endBlockNum < startBlockNum {
+		return nil
+	}
+
 	fmt.Println(">> Verifying log db <<")
 	pb := pb.New64(int64(endBlockNum)).
-		Set64(0).
+		Set64(int64(startBlockNum - 1)).
 		SetMaxWidth(90).
 		Start()
 	defer func() { pb.NotPrint = true }()
@@ -170,7 +189,7 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 		best        = repo.BestBlockSummary()
 		evLogs      []*logdb.Event
 		trLogs      []*logdb.Transfer
-		logLimit    = uint32(0)
+		logLimit    = startBlockNum - 1
 		splitEvLogs = func(id thor.Bytes32) (logs []*logdb.Event) {
 			if len(evLogs) == 0 {
 				return
@@ -218,7 +237,7 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 	defer goes.Wait()
 	goes.Go(func() {
 		defer close(ch)
-		pumpErr = pumpBlockAndReceipts(ctx, repo, best.Header.ID(), 1, endBlockNum, ch)
+		pumpErr = pumpBlockAndReceipts(ctx, repo, best.Header.ID(), startBlockNum, endBlockNum, ch)
 	})
 
 	defer cancel()
@@ -276,6 +295,22 @@ func verifyLogDBPerBlock(
 	eventLogs []*logdb.Event,
 	transferLogs []*logdb.Transfer,
 ) error {
+	expectedEvLogs, expectedTrLogs := expectedBlockLogs(block, receipts)
+	if !equalEvents(eventLogs, expectedEvLogs) {
+		fmt.Println("\nDiff event logs")
+		fmt.Println(jsonDiff(expectedEvLogs, eventLogs))
+		return errors.New("incorrect logs")
+	}
+	if !equalTransfers(transferLogs, expectedTrLogs) {
+		fmt.Println("\nDiff transfer logs")
+		fmt.Println(jsonDiff(expectedTrLogs, transferLogs))
+		return errors.New("incorrect logs")
+	}
+	return nil
+}
+
+// expectedBlockLogs returns logs of the block, as they should be in the log db.
+func expectedBlockLogs(block *block.Block, receipts tx.Receipts) ([]*logdb.Event, []*logdb.Transfer) {
 	convertTopics := func(topics []thor.Bytes32) (r [5]*thor.Bytes32) {
 		for i, t := range topics {
 			topic := t
@@ -294,8 +329,6 @@ func verifyLogDBPerBlock(
 	var expectedTrLogs []*logdb.Transfer
 	txs := block.Transactions()
 
-	evCount = 0
-	trCount = 0
 	for txIndex, r := range receipts {
 		tx := txs[txIndex]
 		origin, _ := tx.Origin()
@@ -339,17 +372,7 @@ func verifyLogDBPerBlock(
 			}
 		}
 	}
-	if !equalEvents(eventLogs, expectedEvLogs) {
-		fmt.Println("\nDiff event logs")
-		fmt.Println(jsonDiff(expectedEvLogs, eventLogs))
-		return errors.New("incorrect logs")
-	}
-	if !equalTransfers(transferLogs, expectedTrLogs) {
-		fmt.Println("\nDiff transfer logs")
-		fmt.Println(jsonDiff(expectedTrLogs, transferLogs))
-		return errors.New("incorrect logs")
-	}
-	return nil
+	return expectedEvLogs, expectedTrLogs
 }
 
 // equalEvents performs a statically typed comparison of two Event slices
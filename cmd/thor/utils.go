@@ -41,6 +41,7 @@ import (
 
 	"github.com/vechain/thor/v2/builtin/staker"
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/chain/freezer"
 	"github.com/vechain/thor/v2/cmd/thor/httpserver"
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/cmd/thor/p2p"
@@ -58,6 +59,10 @@ import (
 
 var devNetGenesisID thor.Bytes32
 
+// snapshotLayers is the count of recent blocks kept as diff layers of the state snapshot,
+// which should cover common reorgs.
+const snapshotLayers = 128
+
 func initLogger(ctx *cli.Context) (*slog.LevelVar, error) {
 	lvl, err := readIntFromUInt64Flag(ctx.Uint64(verbosityFlag.Name))
 	if err != nil {
@@ -301,7 +306,43 @@ func makeInstanceDir(ctx *cli.Context, gene *genesis.Genesis) (string, error) {
 	return instanceDir, nil
 }
 
+// newStater creates the stater shared by all components, with the flat state snapshot enabled unless disabled by flag.
+func newStater(ctx *cli.Context, mainDB *muxdb.MuxDB) (*state.Stater, func(), error) {
+	if ctx.Bool(disableSnapshotFlag.Name) {
+		return state.NewStater(mainDB), func() {}, nil
+	}
+	snaps, err := state.OpenSnapshot(mainDB, snapshotLayers)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open state snapshot")
+	}
+	return state.NewSnapshotStater(mainDB, snaps), func() {
+		log.Info("closing state snapshot...")
+		snaps.Close()
+	}, nil
+}
+
 func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
+	opts := mainDBOptions(ctx)
+	path := filepath.Join(dir, "main.db")
+	db, err := muxdb.Open(path, &opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open main database [%v]", path)
+	}
+	return db, nil
+}
+
+// openMainDBReadOnly opens the main database written by another thor process.
+func openMainDBReadOnly(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
+	opts := mainDBOptions(ctx)
+	path := filepath.Join(dir, "main.db")
+	db, err := muxdb.OpenReadOnly(path, &opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open main database read-only [%v]", path)
+	}
+	return db, nil
+}
+
+func mainDBOptions(ctx *cli.Context) muxdb.Options {
 	cacheMB := normalizeCacheSize(ctx.Int(cacheFlag.Name))
 	log.Debug("cache size(MB)", "size", cacheMB)
 
@@ -331,13 +372,7 @@ func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
 	} else {
 		opts.TrieHistPartitionFactor = 524288
 	}
-
-	path := filepath.Join(dir, "main.db")
-	db, err := muxdb.Open(path, &opts)
-	if err != nil {
-		return nil, errors.Wrapf(err, "open main database [%v]", path)
-	}
-	return db, nil
+	return opts
 }
 
 func normalizeCacheSize(sizeMB int) int {
@@ -389,6 +424,42 @@ func openLogDB(dir string) (*logdb.LogDB, error) {
 	return db, nil
 }
 
+func openLogDBReadOnly(dir string) (*logdb.LogDB, error) {
+	path := filepath.Join(dir, "logs-v2.db")
+	db, err := logdb.NewReadOnly(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open log database read-only [%v]", path)
+	}
+	return db, nil
+}
+
+func attachFreezer(repo *chain.Repository, dir string) (*freezer.Freezer, error) {
+	path := filepath.Join(dir, "ancient")
+	f, err := chain.OpenFreezer(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open freezer [%v]", path)
+	}
+	if err := repo.AttachFreezer(f); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "attach freezer")
+	}
+	return f, nil
+}
+
+// attachFreezerReadOnly attaches the freezer for reading only, which may be owned by another process.
+func attachFreezerReadOnly(repo *chain.Repository, dir string) (*freezer.Freezer, error) {
+	path := filepath.Join(dir, "ancient")
+	f, err := chain.OpenFreezerReadOnly(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open freezer read-only [%v]", path)
+	}
+	if err := repo.AttachFreezer(f); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "attach freezer")
+	}
+	return f, nil
+}
+
 func initChainRepository(gene *genesis.Genesis, mainDB *muxdb.MuxDB, logDB *logdb.LogDB) (*chain.Repository, error) {
 	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(state.NewStater(mainDB))
 	if err != nil {
@@ -582,6 +653,31 @@ func printStartupMessage1(
 	)
 }
 
+// printUpstreamStartupMessage prints the startup message of nodes depending on another node, instead of p2p.
+func printUpstreamStartupMessage(
+	name string,
+	gene *genesis.Genesis,
+	repo *chain.Repository,
+	upstreamName string,
+	upstreamURL string,
+	dataDir string,
+) {
+	bestBlock := repo.BestBlockSummary()
+
+	fmt.Printf(`Starting %v
+    Network      [ %v %v ]
+    Best block   [ %v #%v @%v ]
+    %-12v [ %v ]
+    Instance dir [ %v ]
+`,
+		common.MakeName(name, fullVersion()),
+		gene.ID(), gene.Name(),
+		bestBlock.Header.ID(), bestBlock.Header.Number(), time.Unix(int64(bestBlock.Header.Timestamp()), 0),
+		upstreamName, upstreamURL,
+		dataDir,
+	)
+}
+
 func getOrCreateDevnetID() thor.Bytes32 {
 	if devNetGenesisID.IsZero() {
 		devNetGenesisID = genesis.NewDevnet().ID()
@@ -669,6 +765,20 @@ func parseNodeList(list string) ([]*discover.Node, error) {
 	return nodes, nil
 }
 
+// readTxPoolPolicies reads the min fee bumps required to replace pending txs, and the strategy to order them.
+func readTxPoolPolicies(ctx *cli.Context, opt *txpool.Options) (err error) {
+	if opt.PriorityFeeBump, err = readIntFromUInt64Flag(ctx.Uint64(txPoolPriorityFeeBumpFlag.Name)); err != nil {
+		return errors.Wrap(err, "parse txpool-priority-fee-bump flag")
+	}
+	if opt.MaxFeeBump, err = readIntFromUInt64Flag(ctx.Uint64(txPoolMaxFeeBumpFlag.Name)); err != nil {
+		return errors.Wrap(err, "parse txpool-max-fee-bump flag")
+	}
+	if opt.Ordering, err = txpool.NewOrdering(ctx.String(txPoolOrderingFlag.Name)); err != nil {
+		return errors.Wrap(err, "parse txpool-ordering flag")
+	}
+	return nil
+}
+
 func readIntFromUInt64Flag(val uint64) (int, error) {
 	if val > math.MaxInt {
 		return 0, fmt.Errorf("value %d is too large", val)
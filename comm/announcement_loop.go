@@ -84,8 +84,14 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID thor.Bytes32) {
 		peer.logger.Debug("failed to decode block got by id", "err", err)
 		return
 	}
+	if blk.Header().ID() != newBlockID {
+		peer.logger.Debug("got unrequested block", "id", blk.Header().ID())
+		peer.Penalize(MisbehaviorUnrequestedData)
+		return
+	}
 
 	c.newBlockFeed.Send(&NewBlockEvent{
-		Block: &blk,
+		Block:  &blk,
+		PeerID: peer.ID(),
 	})
 }
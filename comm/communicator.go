@@ -15,12 +15,15 @@ import (
 
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/discv5"
+	"github.com/pkg/errors"
 
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/consensus"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
@@ -39,9 +42,13 @@ type Communicator struct {
 	syncedCh       chan struct{}
 	newBlockFeed   event.Feed
 	announcementCh chan *announcement
+	txAnnounceCh   chan *txAnnouncement
+	peerRemovedCh  chan *Peer
 	feedScope      event.SubscriptionScope
 	goes           co.Goes
 	onceSynced     sync.Once
+	banner         Banner
+	light          *lightServer
 }
 
 // New create a new Communicator instance.
@@ -55,9 +62,17 @@ func New(repo *chain.Repository, txPool *txpool.TxPool) *Communicator {
 		peerSet:        newPeerSet(),
 		syncedCh:       make(chan struct{}),
 		announcementCh: make(chan *announcement),
+		txAnnounceCh:   make(chan *txAnnouncement),
+		peerRemovedCh:  make(chan *Peer),
 	}
 }
 
+// SetBanner sets the banner to ban peers with low score.
+// It should be called before the communicator started.
+func (c *Communicator) SetBanner(banner Banner) {
+	c.banner = banner
+}
+
 // Synced returns a channel indicates if synchronization process passed.
 func (c *Communicator) Synced() <-chan struct{} {
 	return c.syncedCh
@@ -110,6 +125,9 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 			} else {
 				if err := download(ctx, c.repo, peer, best.Number(), handler); err != nil {
 					peer.logger.Debug("synchronization failed", "err", err)
+					if consensus.IsCritical(errors.Cause(err)) {
+						peer.Penalize(MisbehaviorInvalidBlock)
+					}
 					break
 				}
 				peer.logger.Debug("synchronization done")
@@ -128,15 +146,23 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 }
 
 // Protocols returns all supported protocols.
+// The highest version supported by both sides will be negotiated.
 func (c *Communicator) Protocols() []*p2p.Protocol {
-	return []*p2p.Protocol{
-		{
+	protocols := make([]*p2p.Protocol, 0, len(proto.Versions))
+	for i, version := range proto.Versions {
+		protocols = append(protocols, &p2p.Protocol{
 			Name:    proto.Name,
-			Version: proto.Version,
-			Length:  proto.Length,
-			Run:     c.servePeer,
-		},
+			Version: version,
+			Length:  proto.Lengths[i],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return c.servePeer(p, rw, version)
+			},
+		})
 	}
+	if c.light != nil {
+		protocols = append(protocols, c.lightProtocol())
+	}
+	return protocols
 }
 
 // DiscTopic returns the topic for p2p network discovery.
@@ -149,6 +175,7 @@ func (c *Communicator) DiscTopic() discv5.Topic {
 func (c *Communicator) Start() {
 	c.goes.Go(c.txsLoop)
 	c.goes.Go(c.announcementLoop)
+	c.goes.Go(c.txAnnouncementLoop)
 }
 
 // Stop stop the communicator.
@@ -163,8 +190,8 @@ type txsToSync struct {
 	synced bool
 }
 
-func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
-	peer := newPeer(p, rw)
+func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter, version uint) error {
+	peer := newPeer(p, rw, version)
 	c.goes.Go(func() {
 		c.runPeer(peer)
 	})
@@ -211,6 +238,15 @@ func (c *Communicator) runPeer(peer *Peer) {
 	defer func() {
 		c.peerSet.Remove(peer.ID())
 		peer.logger.Debug(fmt.Sprintf("peer removed (%v)", c.peerSet.Len()))
+		// to drop txs pending to be announced to the peer
+		select {
+		case c.peerRemovedCh <- peer:
+		case <-c.ctx.Done():
+		}
+		if score := peer.Score(); score < minPeerScore && c.banner != nil {
+			peer.logger.Debug("ban peer due to low score", "score", score)
+			c.banner.BanNode(peer.ID(), peerBanDuration)
+		}
 	}()
 
 	select {
@@ -259,6 +295,14 @@ func (c *Communicator) BroadcastBlock(blk *block.Block) {
 	}
 }
 
+// ReportMisbehavior penalizes the peer for the misbehavior.
+// Peer with low score will be disconnected and banned for a while.
+func (c *Communicator) ReportMisbehavior(peerID discover.NodeID, m Misbehavior) {
+	if peer := c.peerSet.Find(peerID); peer != nil {
+		peer.Penalize(m)
+	}
+}
+
 // PeerCount returns count of peers.
 func (c *Communicator) PeerCount() int {
 	return c.peerSet.Len()
@@ -277,6 +321,7 @@ func (c *Communicator) PeersStats() []*PeerStats {
 			NetAddr:     peer.RemoteAddr().String(),
 			Inbound:     peer.Inbound(),
 			Duration:    uint64(time.Duration(peer.Duration()) / time.Second),
+			Score:       peer.Score(),
 		})
 	}
 	sort.Slice(stats, func(i, j int) bool {
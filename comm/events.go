@@ -8,12 +8,15 @@ package comm
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/p2p/discover"
+
 	"github.com/vechain/thor/v2/block"
 )
 
 // NewBlockEvent event emitted when received block announcement.
 type NewBlockEvent struct {
 	*block.Block
+	PeerID discover.NodeID // the peer where the block comes from
 }
 
 // HandleBlockStream to handle the stream of downloaded blocks in sync process.
@@ -49,8 +49,12 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(any), txsT
 		}
 
 		peer.MarkBlock(newBlock.Header().ID())
-		peer.UpdateHead(newBlock.Header().ID(), newBlock.Header().TotalScore())
-		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock})
+		if c.isStaleBlock(newBlock.Header().Number()) {
+			peer.Penalize(MisbehaviorStaleHead)
+		} else {
+			peer.UpdateHead(newBlock.Header().ID(), newBlock.Header().TotalScore())
+			c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock, PeerID: peer.ID()})
+		}
 		write(&struct{}{})
 	case proto.MsgNewBlockID:
 		var newBlockID thor.Bytes32
@@ -58,9 +62,13 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(any), txsT
 			return errors.WithMessage(err, "decode msg")
 		}
 		peer.MarkBlock(newBlockID)
-		select {
-		case <-c.ctx.Done():
-		case c.announcementCh <- &announcement{newBlockID, peer}:
+		if c.isStaleBlock(block.Number(newBlockID)) {
+			peer.Penalize(MisbehaviorStaleHead)
+		} else {
+			select {
+			case <-c.ctx.Done():
+			case c.announcementCh <- &announcement{newBlockID, peer}:
+			}
 		}
 		write(&struct{}{})
 	case proto.MsgNewTx:
@@ -71,6 +79,38 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(any), txsT
 		peer.MarkTransaction(newTx.Hash())
 		_ = c.txPool.Add(newTx)
 		write(&struct{}{})
+	case proto.MsgNewTxHash:
+		var hashes []thor.Bytes32
+		if err := msg.Decode(&hashes); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(hashes) > maxTxsByHash {
+			return fmt.Errorf("too many tx hashes (%v)", len(hashes))
+		}
+		for _, hash := range hashes {
+			peer.MarkTransaction(hash)
+		}
+		select {
+		case <-c.ctx.Done():
+		case c.txAnnounceCh <- &txAnnouncement{hashes, peer}:
+		}
+		write(&struct{}{})
+	case proto.MsgGetTxsByHash:
+		var hashes []thor.Bytes32
+		if err := msg.Decode(&hashes); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(hashes) > maxTxsByHash {
+			return fmt.Errorf("too many tx hashes (%v)", len(hashes))
+		}
+		var result tx.Transactions
+		for _, hash := range hashes {
+			if trx := c.txPool.GetByHash(hash); trx != nil {
+				peer.MarkTransaction(hash)
+				result = append(result, trx)
+			}
+		}
+		write(result)
 	case proto.MsgGetBlockByID:
 		var blockID thor.Bytes32
 		if err := msg.Decode(&blockID); err != nil {
@@ -171,3 +211,8 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(any), txsT
 	}
 	return nil
 }
+
+// isStaleBlock returns whether the block number is too far behind the local best block.
+func (c *Communicator) isStaleBlock(num uint32) bool {
+	return num+staleBlockDistance < c.repo.BestBlockSummary().Header.Number()
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func newTestCommunicator(t *testing.T) (*Communicator, *testchain.Chain) {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	pool := txpool.New(chain.Repo(), chain.Stater(), txpool.Options{
+		Limit:           1000,
+		LimitPerAccount: 1000,
+		MaxLifetime:     time.Minute,
+	}, chain.GetForkConfig())
+	t.Cleanup(pool.Close)
+
+	c := New(chain.Repo(), pool)
+	t.Cleanup(c.Stop)
+	return c, chain
+}
+
+func newTestTx(c *Communicator) *tx.Transaction {
+	to := thor.BytesToAddress([]byte("to"))
+	trx := tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(c.repo.ChainTag()).
+		Clause(tx.NewClause(&to)).
+		Gas(21000).
+		Expiration(100).
+		Nonce(rand.Uint64()). //#nosec G404
+		Build()
+	return tx.MustSign(trx, genesis.DevAccounts()[0].PrivateKey)
+}
+
+// callRPC calls the handler with the msg, and returns the result written.
+func callRPC(t *testing.T, c *Communicator, peer *Peer, code uint64, arg any) (result any, err error) {
+	data, err := rlp.EncodeToBytes(arg)
+	require.NoError(t, err)
+
+	msg := &p2p.Msg{Code: code, Size: uint32(len(data)), Payload: bytes.NewReader(data)}
+	err = c.handleRPC(peer, msg, func(r any) { result = r }, &txsToSync{})
+	return
+}
+
+func TestHandleNewTxHash(t *testing.T) {
+	c, _ := newTestCommunicator(t)
+	peer := newPeer(p2p.NewPeer(discover.NodeID{1}, "test", nil), stubMsgReadWriter{}, proto.Version2)
+
+	hashes := []thor.Bytes32{{1}, {2}}
+	go func() {
+		_, err := callRPC(t, c, peer, proto.MsgNewTxHash, hashes)
+		assert.NoError(t, err)
+	}()
+
+	select {
+	case ann := <-c.txAnnounceCh:
+		assert.Equal(t, hashes, ann.hashes)
+		assert.Equal(t, peer, ann.peer)
+	case <-time.After(5 * time.Second):
+		t.Fatal("announcement not received")
+	}
+	for _, hash := range hashes {
+		assert.True(t, peer.IsTransactionKnown(hash))
+	}
+
+	_, err := callRPC(t, c, peer, proto.MsgNewTxHash, make([]thor.Bytes32, maxTxsByHash+1))
+	assert.EqualError(t, err, "too many tx hashes (257)")
+}
+
+func TestHandleGetTxsByHash(t *testing.T) {
+	c, _ := newTestCommunicator(t)
+	peer := newPeer(p2p.NewPeer(discover.NodeID{1}, "test", nil), stubMsgReadWriter{}, proto.Version2)
+
+	trx := newTestTx(c)
+	require.NoError(t, c.txPool.AddLocal(trx))
+
+	// unknown hashes are skipped
+	result, err := callRPC(t, c, peer, proto.MsgGetTxsByHash, []thor.Bytes32{{1}, trx.Hash()})
+	require.NoError(t, err)
+	require.IsType(t, tx.Transactions{}, result)
+	txs := result.(tx.Transactions)
+	require.Len(t, txs, 1)
+	assert.Equal(t, trx.ID(), txs[0].ID())
+	assert.True(t, peer.IsTransactionKnown(trx.Hash()))
+
+	_, err = callRPC(t, c, peer, proto.MsgGetTxsByHash, make([]thor.Bytes32, maxTxsByHash+1))
+	assert.EqualError(t, err, "too many tx hashes (257)")
+}
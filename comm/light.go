@@ -0,0 +1,315 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/p2psrv/rpc"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	lightBufLimit    = 100000 // max request cost budget of a light client
+	lightMinRecharge = 10000  // request cost budget recharged per second
+)
+
+var errLightBudgetExceeded = errors.New("request cost exceeds budget")
+
+// lightServer serves headers and merkle proofs to light clients.
+type lightServer struct {
+	stater    *state.Stater
+	committer bft.Committer
+	maxPeers  int
+
+	lock  sync.Mutex
+	peers int
+}
+
+// costBudget the request cost budget of a light client, which recharges over time.
+type costBudget struct {
+	value   uint64
+	updated mclock.AbsTime
+}
+
+func newCostBudget() *costBudget {
+	return &costBudget{value: lightBufLimit, updated: mclock.Now()}
+}
+
+// Charge deducts the cost from the budget. It returns false if the budget is insufficient.
+func (b *costBudget) Charge(cost uint64) bool {
+	now := mclock.Now()
+	recharged := uint64(time.Duration(now-b.updated) * lightMinRecharge / time.Second)
+	b.value = min(b.value+recharged, lightBufLimit)
+	b.updated = now
+
+	if cost > b.value {
+		return false
+	}
+	b.value -= cost
+	return true
+}
+
+// ServeLight enables the light protocol to serve at most maxPeers light clients.
+// It should be called before the communicator started.
+func (c *Communicator) ServeLight(stater *state.Stater, committer bft.Committer, maxPeers int) {
+	c.light = &lightServer{
+		stater:    stater,
+		committer: committer,
+		maxPeers:  maxPeers,
+	}
+}
+
+func (c *Communicator) lightProtocol() *p2p.Protocol {
+	return &p2p.Protocol{
+		Name:    proto.LightName,
+		Version: proto.LightVersion1,
+		Length:  proto.LightLength,
+		Run:     c.serveLightPeer,
+	}
+}
+
+func (c *Communicator) serveLightPeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+	// full nodes serving light protocol also match each other, but never send light requests,
+	// so the protocol is left idle till the peer disconnects, and any light message is unexpected.
+	if slices.ContainsFunc(p.Caps(), func(c p2p.Cap) bool { return c.Name == proto.Name }) {
+		msg, err := rw.ReadMsg()
+		if err != nil {
+			return err
+		}
+		msg.Discard()
+		return fmt.Errorf("unexpected light message (%v) from full node", msg.Code)
+	}
+
+	light := c.light
+	light.lock.Lock()
+	if light.peers >= light.maxPeers {
+		light.lock.Unlock()
+		return p2p.DiscTooManyPeers
+	}
+	light.peers++
+	metricLightPeers().Add(1)
+	light.lock.Unlock()
+
+	defer func() {
+		light.lock.Lock()
+		light.peers--
+		metricLightPeers().Add(-1)
+		light.lock.Unlock()
+	}()
+
+	r := rpc.New(p, rw)
+	budget := newCostBudget()
+	log := logger.New("peer", p, "proto", proto.LightName)
+	log.Debug("light peer connected")
+	return r.Serve(func(msg *p2p.Msg, write func(any)) error {
+		return c.handleLightRPC(log, msg, write, budget)
+	}, proto.MaxMsgSize)
+}
+
+// peer will be disconnected if error returned
+func (c *Communicator) handleLightRPC(peerLog log.Logger, msg *p2p.Msg, write func(any), budget *costBudget) (err error) {
+	name := proto.LightMsgName(msg.Code)
+	log := peerLog.New("msg", name)
+	log.Trace("received light RPC call")
+	defer func() {
+		if err != nil {
+			log.Debug("failed to handle light RPC call", "err", err)
+		}
+	}()
+
+	charge := func(items int) error {
+		cost := proto.LightRequestCost(msg.Code, items)
+		metricLightRequestCost().AddWithLabel(int64(cost), map[string]string{"msg": name})
+		if !budget.Charge(cost) {
+			return errLightBudgetExceeded
+		}
+		return nil
+	}
+
+	switch msg.Code {
+	case proto.MsgLightGetStatus:
+		if err := msg.Decode(&struct{}{}); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if err := charge(0); err != nil {
+			return err
+		}
+		write(&proto.LightStatus{
+			GenesisBlockID: c.repo.GenesisBlock().Header().ID(),
+			BestBlockID:    c.repo.BestBlockSummary().Header.ID(),
+			BufLimit:       lightBufLimit,
+			MinRecharge:    lightMinRecharge,
+		})
+	case proto.MsgLightGetHeaders:
+		var req proto.GetHeadersRequest
+		if err := msg.Decode(&req); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if req.Count > proto.MaxLightHeaders {
+			return fmt.Errorf("too many headers requested (%v)", req.Count)
+		}
+		if err := charge(int(req.Count)); err != nil {
+			return err
+		}
+
+		result := make([]*block.Header, 0, req.Count)
+		chain := c.repo.NewBestChain()
+		for num := req.From; num-req.From < req.Count; num++ {
+			header, err := chain.GetBlockHeader(num)
+			if err != nil {
+				if !c.repo.IsNotFound(err) {
+					log.Error("failed to get block header", "err", err)
+				}
+				break
+			}
+			result = append(result, header)
+		}
+		write(result)
+	case proto.MsgLightGetCheckpoint:
+		if err := msg.Decode(&struct{}{}); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if err := charge(0); err != nil {
+			return err
+		}
+
+		checkpoint, err := c.lightCheckpoint()
+		if err != nil {
+			return err
+		}
+		write(checkpoint)
+	case proto.MsgLightGetProof:
+		var req proto.GetProofRequest
+		if err := msg.Decode(&req); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(req.Keys) > proto.MaxLightProofKeys {
+			return fmt.Errorf("too many storage keys requested (%v)", len(req.Keys))
+		}
+		if err := charge(len(req.Keys) + 1); err != nil {
+			return err
+		}
+
+		var result []*proto.AccountProof
+		if proof, err := c.proveAccount(&req); err != nil {
+			// state unavailable, e.g. pruned
+			log.Debug("failed to prove account", "err", err)
+		} else {
+			result = append(result, proof)
+		}
+		write(result)
+	case proto.MsgLightGetReceiptProof:
+		var req proto.GetReceiptProofRequest
+		if err := msg.Decode(&req); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if err := charge(0); err != nil {
+			return err
+		}
+
+		var result []*proto.ReceiptProof
+		if proof, err := c.proveReceipt(&req); err != nil {
+			log.Debug("failed to prove receipt", "err", err)
+		} else if proof != nil {
+			result = append(result, proof)
+		}
+		write(result)
+	default:
+		return fmt.Errorf("unknown light message (%v)", msg.Code)
+	}
+	return nil
+}
+
+func (c *Communicator) lightCheckpoint() (*proto.LightCheckpoint, error) {
+	justified, err := c.light.committer.Justified()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get justified")
+	}
+	finalizedSum, err := c.repo.GetBlockSummary(c.light.committer.Finalized())
+	if err != nil {
+		return nil, errors.WithMessage(err, "get finalized")
+	}
+	justifiedSum, err := c.repo.GetBlockSummary(justified)
+	if err != nil {
+		return nil, errors.WithMessage(err, "get justified")
+	}
+	return &proto.LightCheckpoint{
+		Finalized: finalizedSum.Header,
+		Justified: justifiedSum.Header,
+	}, nil
+}
+
+func (c *Communicator) proveAccount(req *proto.GetProofRequest) (*proto.AccountProof, error) {
+	summary, err := c.repo.GetBlockSummary(req.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	st := c.light.stater.NewState(summary.Root())
+
+	var proof proto.AccountProof
+	if proof.AccountProof, err = st.ProveAccount(req.Address); err != nil {
+		return nil, err
+	}
+	for _, key := range req.Keys {
+		storageProof, err := st.ProveStorage(req.Address, key)
+		if err != nil {
+			return nil, err
+		}
+		proof.StorageProofs = append(proof.StorageProofs, storageProof)
+	}
+	return &proof, nil
+}
+
+// proveReceipt returns nil proof if the tx is not in the block.
+func (c *Communicator) proveReceipt(req *proto.GetReceiptProofRequest) (*proto.ReceiptProof, error) {
+	summary, err := c.repo.GetBlockSummary(req.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, id := range summary.Txs {
+		if id == req.TxID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, nil
+	}
+
+	var (
+		txs      tx.Transactions
+		receipts tx.Receipts
+	)
+	if txs, err = c.repo.GetBlockTransactions(req.BlockID); err != nil {
+		return nil, err
+	}
+	if receipts, err = c.repo.GetBlockReceipts(req.BlockID); err != nil {
+		return nil, err
+	}
+
+	proof := proto.ReceiptProof{Index: uint64(index)}
+	if proof.TxProof, err = txs.Prove(index); err != nil {
+		return nil, err
+	}
+	if proof.ReceiptProof, err = receipts.Prove(index); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
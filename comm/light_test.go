@@ -0,0 +1,180 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/p2psrv/rpc"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestCostBudget(t *testing.T) {
+	b := newCostBudget()
+	assert.True(t, b.Charge(lightBufLimit))
+	assert.False(t, b.Charge(1000))
+
+	// recharged over time
+	b.updated -= mclock.AbsTime(time.Second / 10)
+	assert.True(t, b.Charge(lightMinRecharge/10))
+	assert.False(t, b.Charge(1000))
+
+	// never exceeds the limit
+	b.updated -= mclock.AbsTime(time.Hour)
+	assert.False(t, b.Charge(lightBufLimit+1))
+	assert.True(t, b.Charge(lightBufLimit))
+}
+
+// bufferedRW buffers the payload of read msg, as the rlpx transport does.
+type bufferedRW struct {
+	p2p.MsgReadWriter
+}
+
+func (rw bufferedRW) ReadMsg() (p2p.Msg, error) {
+	msg, err := rw.MsgReadWriter.ReadMsg()
+	if err != nil {
+		return msg, err
+	}
+	data, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return msg, err
+	}
+	msg.Payload = bytes.NewReader(data)
+	return msg, nil
+}
+
+// newLightClient serves the light protocol to a client over a pipe, and returns the client's RPC and the serving result.
+func newLightClient(t *testing.T, c *Communicator, caps []p2p.Cap) (*rpc.RPC, <-chan error) {
+	rw, remote := p2p.MsgPipe()
+	t.Cleanup(func() { rw.Close() })
+
+	served := make(chan error, 1)
+	go func() {
+		err := c.serveLightPeer(p2p.NewPeer(discover.NodeID{1}, "client", caps), bufferedRW{rw})
+		// disconnected as the protocol returns
+		rw.Close()
+		served <- err
+	}()
+
+	client := rpc.New(p2p.NewPeer(discover.NodeID{2}, "server", nil), bufferedRW{remote})
+	go client.Serve(func(*p2p.Msg, func(any)) error { return nil }, proto.MaxMsgSize)
+	return client, served
+}
+
+func TestServeLight(t *testing.T) {
+	c, chain := newTestCommunicator(t)
+	c.ServeLight(chain.Stater(), chain.Engine(), 1)
+
+	dev := genesis.DevAccounts()[0]
+	trx := newTestTx(c)
+	require.NoError(t, chain.MintTransactions(dev, trx))
+	best := c.repo.BestBlockSummary()
+
+	client, _ := newLightClient(t, c, []p2p.Cap{{Name: proto.LightName, Version: proto.LightVersion1}})
+	ctx := context.Background()
+
+	status, err := proto.GetLightStatus(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, c.repo.GenesisBlock().Header().ID(), status.GenesisBlockID)
+	assert.Equal(t, best.Header.ID(), status.BestBlockID)
+	assert.Equal(t, uint64(lightBufLimit), status.BufLimit)
+
+	// headers beyond the best are not returned
+	headers, err := proto.GetHeaders(ctx, client, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, headers, 2)
+	assert.Equal(t, best.Header.ID(), headers[1].ID())
+
+	checkpoint, err := proto.GetCheckpoint(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, c.repo.GenesisBlock().Header().ID(), checkpoint.Finalized.ID())
+
+	key := thor.Bytes32{1}
+	proof, err := proto.GetProof(ctx, client, &proto.GetProofRequest{BlockID: best.Header.ID(), Address: dev.Address, Keys: []thor.Bytes32{key}})
+	require.NoError(t, err)
+	require.NotNil(t, proof)
+	account, err := state.VerifyAccountProof(best.Header.StateRoot(), dev.Address, proof.AccountProof)
+	require.NoError(t, err)
+	assert.NotZero(t, account.Balance.Sign())
+	require.Len(t, proof.StorageProofs, 1)
+
+	receiptProof, err := proto.GetReceiptProof(ctx, client, &proto.GetReceiptProofRequest{BlockID: best.Header.ID(), TxID: trx.ID()})
+	require.NoError(t, err)
+	require.NotNil(t, receiptProof)
+	proved, err := tx.VerifyTransactionProof(best.Header.TxsRoot(), int(receiptProof.Index), receiptProof.TxProof)
+	require.NoError(t, err)
+	assert.Equal(t, trx.ID(), proved.ID())
+	receipt, err := tx.VerifyReceiptProof(best.Header.ReceiptsRoot(), int(receiptProof.Index), receiptProof.ReceiptProof)
+	require.NoError(t, err)
+	assert.False(t, receipt.Reverted)
+
+	// not found
+	receiptProof, err = proto.GetReceiptProof(ctx, client, &proto.GetReceiptProofRequest{BlockID: best.Header.ID(), TxID: thor.Bytes32{1}})
+	require.NoError(t, err)
+	assert.Nil(t, receiptProof)
+	proof, err = proto.GetProof(ctx, client, &proto.GetProofRequest{BlockID: thor.Bytes32{1}, Address: dev.Address})
+	require.NoError(t, err)
+	assert.Nil(t, proof)
+
+	// more clients than allowed are rejected
+	_, served := newLightClient(t, c, []p2p.Cap{{Name: proto.LightName, Version: proto.LightVersion1}})
+	assert.Equal(t, p2p.DiscTooManyPeers, <-served)
+}
+
+func TestServeLightRejects(t *testing.T) {
+	c, chain := newTestCommunicator(t)
+	c.ServeLight(chain.Stater(), chain.Engine(), 10)
+	ctx := context.Background()
+
+	lightCaps := []p2p.Cap{{Name: proto.LightName, Version: proto.LightVersion1}}
+
+	// too many headers
+	client, served := newLightClient(t, c, lightCaps)
+	_, err := proto.GetHeaders(ctx, client, 0, proto.MaxLightHeaders+1)
+	assert.Error(t, err)
+	assert.EqualError(t, <-served, fmt.Sprintf("too many headers requested (%v)", proto.MaxLightHeaders+1))
+
+	// budget exceeded
+	client, served = newLightClient(t, c, lightCaps)
+	for range lightBufLimit / proto.LightRequestCost(proto.MsgLightGetReceiptProof, 0) {
+		_, err := proto.GetReceiptProof(ctx, client, &proto.GetReceiptProofRequest{})
+		require.NoError(t, err)
+	}
+	_, err = proto.GetReceiptProof(ctx, client, &proto.GetReceiptProofRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, errLightBudgetExceeded, <-served)
+
+	// status requests are charged as well
+	client, served = newLightClient(t, c, lightCaps)
+	for range 2 * lightBufLimit / proto.LightRequestCost(proto.MsgLightGetStatus, 0) {
+		if _, err = proto.GetLightStatus(ctx, client); err != nil {
+			break
+		}
+	}
+	assert.Error(t, err)
+	assert.Equal(t, errLightBudgetExceeded, <-served)
+
+	// full nodes are not expected to send light requests
+	client, served = newLightClient(t, c, append(lightCaps, p2p.Cap{Name: proto.Name, Version: proto.Version1}))
+	_, err = proto.GetLightStatus(ctx, client)
+	assert.Error(t, err)
+	assert.EqualError(t, <-served, "unexpected light message (0) from full node")
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"github.com/vechain/thor/v2/metrics"
+)
+
+var (
+	metricLightPeers       = metrics.LazyLoadGauge("p2p_light_peers_gauge")
+	metricLightRequestCost = metrics.LazyLoadCounterVec("p2p_light_request_cost_count", []string{"msg"})
+)
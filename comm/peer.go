@@ -6,6 +6,8 @@
 package comm
 
 import (
+	"context"
+	"errors"
 	"math/rand/v2"
 	"sync"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	lru "github.com/hashicorp/golang-lru"
 
+	"github.com/vechain/thor/v2/comm/proto"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/p2psrv/rpc"
 	"github.com/vechain/thor/v2/thor"
@@ -29,7 +32,8 @@ const (
 type Peer struct {
 	*p2p.Peer
 	*rpc.RPC
-	logger log.Logger
+	logger  log.Logger
+	version uint
 
 	createdTime mclock.AbsTime
 	knownTxs    *lru.Cache
@@ -39,9 +43,14 @@ type Peer struct {
 		id         thor.Bytes32
 		totalScore uint64
 	}
+	score struct {
+		sync.Mutex
+		value   int
+		updated mclock.AbsTime
+	}
 }
 
-func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter, version uint) *Peer {
 	dir := "outbound"
 	if peer.Inbound() {
 		dir = "inbound"
@@ -49,17 +58,30 @@ func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
 	ctx := []any{
 		"peer", peer,
 		"dir", dir,
+		"ver", version,
 	}
 	knownTxs, _ := lru.New(maxKnownTxs)
 	knownBlocks, _ := lru.New(maxKnownBlocks)
-	return &Peer{
+	p := &Peer{
 		Peer:        peer,
 		RPC:         rpc.New(peer, rw),
 		logger:      logger.New(ctx...),
+		version:     version,
 		createdTime: mclock.Now(),
 		knownTxs:    knownTxs,
 		knownBlocks: knownBlocks,
 	}
+	if version >= proto.Version3 {
+		p.EnableCompression()
+	}
+	p.score.value = maxPeerScore
+	p.score.updated = p.createdTime
+	return p
+}
+
+// Version returns the negotiated protocol version.
+func (p *Peer) Version() uint {
+	return p.version
 }
 
 // Head returns head block ID and total score.
@@ -78,6 +100,44 @@ func (p *Peer) UpdateHead(id thor.Bytes32, totalScore uint64) {
 	}
 }
 
+// Call overrides rpc.RPC.Call to penalize the peer if it fails to respond in time.
+func (p *Peer) Call(ctx context.Context, msgCode uint64, arg any, result any) error {
+	err := p.RPC.Call(ctx, msgCode, arg, result)
+	if errors.Is(err, context.DeadlineExceeded) {
+		p.Penalize(MisbehaviorRPCTimeout)
+	}
+	return err
+}
+
+// Score returns the reputation score of the peer.
+// The score recovers gradually over time, up to the max score.
+func (p *Peer) Score() int {
+	p.score.Lock()
+	defer p.score.Unlock()
+	return p.currentScore()
+}
+
+func (p *Peer) currentScore() int {
+	recovered := int(time.Duration(mclock.Now()-p.score.updated) / peerScoreRecoveryTime)
+	return min(p.score.value+recovered, maxPeerScore)
+}
+
+// Penalize decreases the score of the peer for the misbehavior.
+// The peer will be disconnected once its score drops below the min score.
+func (p *Peer) Penalize(m Misbehavior) {
+	p.score.Lock()
+	p.score.value = p.currentScore() - m.penalty()
+	p.score.updated = mclock.Now()
+	score := p.score.value
+	p.score.Unlock()
+
+	p.logger.Debug("peer penalized", "reason", m, "score", score)
+	if score < minPeerScore {
+		p.logger.Debug("disconnect peer due to low score")
+		p.Disconnect(p2p.DiscUselessPeer)
+	}
+}
+
 // MarkTransaction marks a transaction to known.
 func (p *Peer) MarkTransaction(hash thor.Bytes32) {
 	// that's 10~100 block intervals
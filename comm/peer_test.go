@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/vechain/thor/v2/comm/proto"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -23,15 +24,28 @@ func (stubMsgReadWriter) ReadMsg() (p2p.Msg, error) { return p2p.Msg{}, nil }
 func (stubMsgReadWriter) WriteMsg(p2p.Msg) error    { return nil }
 
 func TestNewPeerAndHead(t *testing.T) {
-	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{})
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
 	assert.NotNil(t, peer)
 	id, score := peer.Head()
 	assert.Equal(t, thor.Bytes32{}, id)
 	assert.Equal(t, uint64(0), score)
 }
 
+func TestPeerVersion(t *testing.T) {
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version1)
+	assert.Equal(t, proto.Version1, peer.Version())
+
+	peer = newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
+	assert.Equal(t, proto.Version2, peer.Version())
+	assert.False(t, peer.CompressionEnabled())
+
+	peer = newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version3)
+	assert.Equal(t, proto.Version3, peer.Version())
+	assert.True(t, peer.CompressionEnabled())
+}
+
 func TestUpdateHead(t *testing.T) {
-	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{})
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
 	id := thor.Bytes32{1, 2, 3}
 	peer.UpdateHead(id, 10)
 	gotID, gotScore := peer.Head()
@@ -45,7 +59,7 @@ func TestUpdateHead(t *testing.T) {
 }
 
 func TestMarkTransactionAndIsTransactionKnown(t *testing.T) {
-	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{})
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
 	hash := thor.Bytes32{1, 2, 3}
 	peer.MarkTransaction(hash)
 	// Should be known immediately after marking
@@ -56,7 +70,7 @@ func TestMarkTransactionAndIsTransactionKnown(t *testing.T) {
 }
 
 func TestMarkBlockAndIsBlockKnown(t *testing.T) {
-	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{})
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
 	id := thor.Bytes32{4, 5, 6}
 	peer.MarkBlock(id)
 	assert.True(t, peer.IsBlockKnown(id))
@@ -65,16 +79,35 @@ func TestMarkBlockAndIsBlockKnown(t *testing.T) {
 	assert.False(t, peer.IsBlockKnown(id))
 }
 
+func TestPeerScore(t *testing.T) {
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
+	assert.Equal(t, maxPeerScore, peer.Score())
+
+	peer.Penalize(MisbehaviorStaleHead)
+	assert.Equal(t, maxPeerScore-MisbehaviorStaleHead.penalty(), peer.Score())
+
+	peer.Penalize(MisbehaviorInvalidBlock)
+	peer.Penalize(MisbehaviorInvalidBlock)
+	assert.Less(t, peer.Score(), minPeerScore)
+
+	// simulate score recovery over time
+	peer.score.updated = mclock.Now() - mclock.AbsTime(peerScoreRecoveryTime*10)
+	assert.Equal(t, maxPeerScore-MisbehaviorStaleHead.penalty()-2*MisbehaviorInvalidBlock.penalty()+10, peer.Score())
+
+	peer.score.updated = mclock.Now() - mclock.AbsTime(peerScoreRecoveryTime*1000)
+	assert.Equal(t, maxPeerScore, peer.Score())
+}
+
 func TestDuration(t *testing.T) {
-	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{})
+	peer := newPeer(p2p.NewPeer(discover.NodeID{}, "test", nil), stubMsgReadWriter{}, proto.Version2)
 	// Simulate some time passing
 	peer.createdTime = mclock.Now() - 100
 	assert.GreaterOrEqual(t, peer.Duration(), mclock.AbsTime(100))
 }
 
 func TestPeersFilterAndFind(t *testing.T) {
-	peer1 := newPeer(p2p.NewPeer(discover.NodeID{}, "test1", nil), stubMsgReadWriter{})
-	peer2 := newPeer(p2p.NewPeer(discover.NodeID{}, "test2", nil), stubMsgReadWriter{})
+	peer1 := newPeer(p2p.NewPeer(discover.NodeID{}, "test1", nil), stubMsgReadWriter{}, proto.Version2)
+	peer2 := newPeer(p2p.NewPeer(discover.NodeID{}, "test2", nil), stubMsgReadWriter{}, proto.Version2)
 	peers := Peers{peer1, peer2}
 	filtered := peers.Filter(func(p *Peer) bool { return p == peer1 })
 	assert.Equal(t, Peers{peer1}, filtered)
@@ -84,7 +117,7 @@ func TestPeersFilterAndFind(t *testing.T) {
 
 func TestPeerSetAddFindRemoveSliceLen(t *testing.T) {
 	ps := newPeerSet()
-	peer := newPeer(p2p.NewPeer(discover.NodeID{1}, "test", nil), stubMsgReadWriter{})
+	peer := newPeer(p2p.NewPeer(discover.NodeID{1}, "test", nil), stubMsgReadWriter{}, proto.Version2)
 	ps.Add(peer)
 	assert.Equal(t, 1, ps.Len())
 	found := ps.Find(peer.ID())
@@ -93,8 +126,8 @@ func TestPeerSetAddFindRemoveSliceLen(t *testing.T) {
 	assert.Equal(t, peer, removed)
 	assert.Equal(t, 0, ps.Len())
 
-	peer2 := newPeer(p2p.NewPeer(discover.NodeID{2}, "test2", nil), stubMsgReadWriter{})
-	peer3 := newPeer(p2p.NewPeer(discover.NodeID{3}, "test3", nil), stubMsgReadWriter{})
+	peer2 := newPeer(p2p.NewPeer(discover.NodeID{2}, "test2", nil), stubMsgReadWriter{}, proto.Version2)
+	peer3 := newPeer(p2p.NewPeer(discover.NodeID{3}, "test3", nil), stubMsgReadWriter{}, proto.Version2)
 	ps.Add(peer2)
 	ps.Add(peer3)
 	slice := ps.Slice()
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package proto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Constants of light protocol, which serves headers and merkle proofs to light clients.
+const (
+	LightName            = "thorlight"
+	LightVersion1 uint   = 1
+	LightLength   uint64 = 5
+
+	MaxLightHeaders   = 192 // max count of headers per request
+	MaxLightProofKeys = 64  // max count of storage keys per proof request
+)
+
+// Protocol messages of light protocol
+const (
+	MsgLightGetStatus = iota
+	MsgLightGetHeaders
+	MsgLightGetCheckpoint
+	MsgLightGetProof
+	MsgLightGetReceiptProof
+)
+
+// LightMsgName convert light msg code to string.
+func LightMsgName(msgCode uint64) string {
+	switch msgCode {
+	case MsgLightGetStatus:
+		return "MsgLightGetStatus"
+	case MsgLightGetHeaders:
+		return "MsgLightGetHeaders"
+	case MsgLightGetCheckpoint:
+		return "MsgLightGetCheckpoint"
+	case MsgLightGetProof:
+		return "MsgLightGetProof"
+	case MsgLightGetReceiptProof:
+		return "MsgLightGetReceiptProof"
+	default:
+		return fmt.Sprintf("unknown light msg %v", msgCode)
+	}
+}
+
+// RequestCost is the cost of a light request, which is charged from the client's budget.
+type RequestCost struct {
+	Base    uint64
+	PerItem uint64
+}
+
+// lightRequestCosts costs of light requests, roughly proportional to the work to serve them.
+var lightRequestCosts = map[uint64]RequestCost{
+	MsgLightGetStatus:       {Base: 100},
+	MsgLightGetHeaders:      {Base: 100, PerItem: 20},
+	MsgLightGetCheckpoint:   {Base: 500},
+	MsgLightGetProof:        {Base: 1000, PerItem: 500}, // items are the account and storage keys
+	MsgLightGetReceiptProof: {Base: 5000},               // tries of txs and receipts of the block are rebuilt
+}
+
+// LightRequestCost returns the cost of the light request with the given count of items.
+func LightRequestCost(msgCode uint64, items int) uint64 {
+	c := lightRequestCosts[msgCode]
+	return c.Base + c.PerItem*uint64(items)
+}
+
+type (
+	// LightStatus result of MsgLightGetStatus.
+	// The budget of a client is recharged at MinRecharge per second, up to BufLimit.
+	// Requests exceed the remaining budget lead to disconnection.
+	LightStatus struct {
+		GenesisBlockID thor.Bytes32
+		BestBlockID    thor.Bytes32
+		BufLimit       uint64
+		MinRecharge    uint64
+	}
+
+	// GetHeadersRequest arg of MsgLightGetHeaders.
+	GetHeadersRequest struct {
+		From  uint32
+		Count uint32
+	}
+
+	// LightCheckpoint result of MsgLightGetCheckpoint.
+	LightCheckpoint struct {
+		Finalized *block.Header
+		Justified *block.Header
+	}
+
+	// GetProofRequest arg of MsgLightGetProof.
+	GetProofRequest struct {
+		BlockID thor.Bytes32
+		Address thor.Address
+		Keys    []thor.Bytes32
+	}
+
+	// AccountProof merkle proofs of an account and its storage values.
+	AccountProof struct {
+		AccountProof  [][]byte
+		StorageProofs [][][]byte
+	}
+
+	// GetReceiptProofRequest arg of MsgLightGetReceiptProof.
+	GetReceiptProofRequest struct {
+		BlockID thor.Bytes32
+		TxID    thor.Bytes32
+	}
+
+	// ReceiptProof merkle proofs of a tx and its receipt in a block.
+	ReceiptProof struct {
+		Index        uint64
+		TxProof      [][]byte
+		ReceiptProof [][]byte
+	}
+)
+
+// GetLightStatus get light status of remote peer.
+func GetLightStatus(ctx context.Context, rpc RPC) (*LightStatus, error) {
+	var status LightStatus
+	if err := rpc.Call(ctx, MsgLightGetStatus, &struct{}{}, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetHeaders get headers of the best chain from remote peer.
+func GetHeaders(ctx context.Context, rpc RPC, from, count uint32) ([]*block.Header, error) {
+	var headers []*block.Header
+	if err := rpc.Call(ctx, MsgLightGetHeaders, &GetHeadersRequest{from, count}, &headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// GetCheckpoint get BFT checkpoints from remote peer.
+func GetCheckpoint(ctx context.Context, rpc RPC) (*LightCheckpoint, error) {
+	var checkpoint LightCheckpoint
+	if err := rpc.Call(ctx, MsgLightGetCheckpoint, &struct{}{}, &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// GetProof get proofs of account and storage values from remote peer.
+// It returns nil proof if the state is not available.
+func GetProof(ctx context.Context, rpc RPC, req *GetProofRequest) (*AccountProof, error) {
+	var result []*AccountProof
+	if err := rpc.Call(ctx, MsgLightGetProof, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result[0], nil
+}
+
+// GetReceiptProof get proofs of tx and its receipt from remote peer.
+// It returns nil proof if the tx is not found in the block.
+func GetReceiptProof(ctx context.Context, rpc RPC, req *GetReceiptProofRequest) (*ReceiptProof, error) {
+	var result []*ReceiptProof
+	if err := rpc.Call(ctx, MsgLightGetReceiptProof, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result[0], nil
+}
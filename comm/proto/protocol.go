@@ -11,10 +11,22 @@ import (
 
 // Constants
 const (
-	Name              = "thor"
-	Version    uint   = 1
-	Length     uint64 = 8
-	MaxMsgSize        = 10 * 1024 * 1024
+	Name       = "thor"
+	MaxMsgSize = 10 * 1024 * 1024
+)
+
+// Protocol versions
+const (
+	Version1 uint = 1 // txs are broadcast with full bodies
+	Version2 uint = 2 // txs are announced by hash and fetched on demand
+	Version3 uint = 3 // msg payloads are snappy compressed
+)
+
+var (
+	// Versions supported protocol versions, from the newest to the oldest.
+	Versions = []uint{Version3, Version2, Version1}
+	// Lengths number of message codes used by each protocol version.
+	Lengths = []uint64{10, 10, 8}
 )
 
 // Protocol messages of thor
@@ -27,6 +39,8 @@ const (
 	MsgGetBlockIDByNumber
 	MsgGetBlocksFromNumber // fetch blocks from given number (including given number)
 	MsgGetTxs
+	MsgNewTxHash    // since Version2, announce hashes of new txs
+	MsgGetTxsByHash // since Version2, fetch txs by their hashes
 )
 
 // MsgName convert msg code to string.
@@ -48,6 +62,10 @@ func MsgName(msgCode uint64) string {
 		return "MsgGetBlocksFromNumber"
 	case MsgGetTxs:
 		return "MsgGetTxs"
+	case MsgNewTxHash:
+		return "MsgNewTxHash"
+	case MsgGetTxsByHash:
+		return "MsgGetTxsByHash"
 	default:
 		return fmt.Sprintf("unknown msg code(%v)", msgCode)
 	}
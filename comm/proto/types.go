@@ -56,6 +56,11 @@ func NotifyNewTx(ctx context.Context, rpc RPC, tx *tx.Transaction) error {
 	return rpc.Notify(ctx, MsgNewTx, tx)
 }
 
+// NotifyNewTxHash announce hashes of new txs to remote peer.
+func NotifyNewTxHash(ctx context.Context, rpc RPC, hashes []thor.Bytes32) error {
+	return rpc.Notify(ctx, MsgNewTxHash, hashes)
+}
+
 // GetBlockByID query block from remote peer by given block ID.
 // It may return nil block even no error.
 func GetBlockByID(ctx context.Context, rpc RPC, id thor.Bytes32) (rlp.RawValue, error) {
@@ -95,3 +100,13 @@ func GetTxs(ctx context.Context, rpc RPC) (tx.Transactions, error) {
 	}
 	return txs, nil
 }
+
+// GetTxsByHash get txs of given hashes from remote peer.
+// Txs unknown to the remote peer are omitted in the result.
+func GetTxsByHash(ctx context.Context, rpc RPC, hashes []thor.Bytes32) (tx.Transactions, error) {
+	var txs tx.Transactions
+	if err := rpc.Call(ctx, MsgGetTxsByHash, hashes, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
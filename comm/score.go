@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+const (
+	maxPeerScore          = 100
+	minPeerScore          = 0                // peer will be disconnected and banned once its score drops below
+	peerScoreRecoveryTime = time.Minute      // time to recover one point of score
+	peerBanDuration       = 30 * time.Minute // duration of ban for peers with low score
+	staleBlockDistance    = 30               // blocks announced by peer should not be too far behind local best
+)
+
+// Misbehavior kinds of peer misbehavior which decrease the peer's score.
+type Misbehavior int
+
+const (
+	MisbehaviorInvalidBlock    Misbehavior = iota // sent block failed to pass consensus validation
+	MisbehaviorUnrequestedData                    // responded with data that was not requested
+	MisbehaviorStaleHead                          // announced head block far behind the local best
+	MisbehaviorRPCTimeout                         // failed to respond to RPC call in time
+)
+
+// penalty returns the score to be deducted for the misbehavior.
+func (m Misbehavior) penalty() int {
+	switch m {
+	case MisbehaviorInvalidBlock:
+		return 50
+	case MisbehaviorUnrequestedData:
+		return 20
+	case MisbehaviorStaleHead:
+		return 5
+	case MisbehaviorRPCTimeout:
+		return 10
+	default:
+		return 0
+	}
+}
+
+func (m Misbehavior) String() string {
+	switch m {
+	case MisbehaviorInvalidBlock:
+		return "invalid block"
+	case MisbehaviorUnrequestedData:
+		return "unrequested data"
+	case MisbehaviorStaleHead:
+		return "stale head"
+	case MisbehaviorRPCTimeout:
+		return "rpc timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// Banner bans nodes from connecting for a while.
+type Banner interface {
+	BanNode(id discover.NodeID, duration time.Duration)
+}
@@ -6,19 +6,56 @@
 package comm
 
 import (
+	"time"
+
 	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/txpool"
 )
 
+const (
+	maxTxsByHash       = 256                    // max count of txs to be fetched by hash in one call
+	txAnnounceInterval = 100 * time.Millisecond // interval to batch tx hashes to be announced to a peer
+)
+
+type txAnnouncement struct {
+	hashes []thor.Bytes32
+	peer   *Peer
+}
+
+// txsLoop broadcasts executable txs to peers not knowing them.
+// Tx hashes are announced in batches per peer, sent once the interval elapsed or the batch is full.
+// Batches of disconnected peers are dropped.
 func (c *Communicator) txsLoop() {
 	txEvCh := make(chan *txpool.TxEvent, 10)
 	sub := c.txPool.SubscribeTxEvent(txEvCh)
 	defer sub.Unsubscribe()
 
+	var (
+		pendingHashes = make(map[*Peer][]thor.Bytes32)
+		announceTimer <-chan time.Time
+	)
+	announce := func(peer *Peer) {
+		hashes := pendingHashes[peer]
+		delete(pendingHashes, peer)
+		c.goes.Go(func() {
+			if err := proto.NotifyNewTxHash(c.ctx, peer, hashes); err != nil {
+				peer.logger.Debug("failed to announce tx hashes", "err", err)
+			}
+		})
+	}
+
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
+		case <-announceTimer:
+			announceTimer = nil
+			for peer := range pendingHashes {
+				announce(peer)
+			}
+		case peer := <-c.peerRemovedCh:
+			delete(pendingHashes, peer)
 		case txEv := <-txEvCh:
 			if txEv.Executable != nil && *txEv.Executable {
 				tx := txEv.Tx
@@ -28,6 +65,15 @@ func (c *Communicator) txsLoop() {
 
 				for _, peer := range peers {
 					peer.MarkTransaction(tx.Hash())
+					if peer.Version() >= proto.Version2 {
+						pendingHashes[peer] = append(pendingHashes[peer], tx.Hash())
+						if len(pendingHashes[peer]) >= maxTxsByHash {
+							announce(peer)
+						} else if announceTimer == nil {
+							announceTimer = time.After(txAnnounceInterval)
+						}
+						continue
+					}
 					c.goes.Go(func() {
 						if err := proto.NotifyNewTx(c.ctx, peer, tx); err != nil {
 							peer.logger.Debug("failed to broadcast tx", "err", err)
@@ -38,3 +84,65 @@ func (c *Communicator) txsLoop() {
 		}
 	}
 }
+
+// txAnnouncementLoop fetches announced txs which are unknown to the local pool.
+// A tx hash is fetched from only one peer at a time.
+func (c *Communicator) txAnnouncementLoop() {
+	fetchingHashes := map[thor.Bytes32]bool{}
+	fetchDone := make(chan []thor.Bytes32)
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case hashes := <-fetchDone:
+			for _, hash := range hashes {
+				delete(fetchingHashes, hash)
+			}
+		case ann := <-c.txAnnounceCh:
+			toFetch := make([]thor.Bytes32, 0, len(ann.hashes))
+			for _, hash := range ann.hashes {
+				if fetchingHashes[hash] || c.txPool.GetByHash(hash) != nil {
+					continue
+				}
+				fetchingHashes[hash] = true
+				toFetch = append(toFetch, hash)
+			}
+			if len(toFetch) == 0 {
+				continue
+			}
+
+			c.goes.Go(func() {
+				defer func() {
+					select {
+					case fetchDone <- toFetch:
+					case <-c.ctx.Done():
+					}
+				}()
+				c.fetchTxsByHash(ann.peer, toFetch)
+			})
+		}
+	}
+}
+
+func (c *Communicator) fetchTxsByHash(peer *Peer, hashes []thor.Bytes32) {
+	txs, err := proto.GetTxsByHash(c.ctx, peer, hashes)
+	if err != nil {
+		peer.logger.Debug("failed to get txs by hash", "err", err)
+		return
+	}
+
+	requested := make(map[thor.Bytes32]bool, len(hashes))
+	for _, hash := range hashes {
+		requested[hash] = true
+	}
+	for _, tx := range txs {
+		if !requested[tx.Hash()] {
+			peer.logger.Debug("got unrequested tx", "hash", tx.Hash())
+			peer.Penalize(MisbehaviorUnrequestedData)
+			return
+		}
+		peer.MarkTransaction(tx.Hash())
+		_ = c.txPool.Add(tx)
+	}
+}
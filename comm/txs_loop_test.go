@@ -0,0 +1,93 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// readTxHashAnnouncement reads the next message from the peer's remote end, which should be the tx hash announcement.
+func readTxHashAnnouncement(t *testing.T, rw p2p.MsgReadWriter) []thor.Bytes32 {
+	msg, err := rw.ReadMsg()
+	require.NoError(t, err)
+	defer msg.Discard()
+	require.Equal(t, uint64(proto.MsgNewTxHash), msg.Code)
+
+	var data struct {
+		ID     uint32
+		Flags  uint8
+		Hashes []thor.Bytes32
+	}
+	require.NoError(t, msg.Decode(&data))
+	return data.Hashes
+}
+
+func TestTxsLoopAnnounceInBatches(t *testing.T) {
+	c, _ := newTestCommunicator(t)
+
+	rw, remote := p2p.MsgPipe()
+	defer rw.Close()
+	peer := newPeer(p2p.NewPeer(discover.NodeID{1}, "test", nil), rw, proto.Version2)
+	c.peerSet.Add(peer)
+
+	c.goes.Go(c.txsLoop)
+
+	// wait for the loop to subscribe tx events, by probing txs until one is broadcast
+	probes := make(map[thor.Bytes32]bool)
+	require.Eventually(t, func() bool {
+		probe := newTestTx(c)
+		require.NoError(t, c.txPool.AddLocal(probe))
+		probes[probe.Hash()] = true
+		for hash := range probes {
+			if peer.IsTransactionKnown(hash) {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+
+	known := newTestTx(c)
+	peer.MarkTransaction(known.Hash())
+	require.NoError(t, c.txPool.AddLocal(known))
+
+	var txs tx.Transactions
+	for range maxTxsByHash + 10 {
+		trx := newTestTx(c)
+		require.NoError(t, c.txPool.AddLocal(trx))
+		txs = append(txs, trx)
+	}
+
+	// batched up to the max count that the peer accepts, without the hash known by the peer
+	var hashes []thor.Bytes32
+	batches := 0
+	for len(hashes) < len(txs) {
+		batch := readTxHashAnnouncement(t, remote)
+		assert.LessOrEqual(t, len(batch), maxTxsByHash)
+		for _, hash := range batch {
+			if !probes[hash] {
+				hashes = append(hashes, hash)
+			}
+		}
+		batches++
+	}
+	assert.Less(t, batches, len(txs))
+	var expected []thor.Bytes32
+	for _, trx := range txs {
+		expected = append(expected, trx.Hash())
+		assert.True(t, peer.IsTransactionKnown(trx.Hash()))
+	}
+	assert.ElementsMatch(t, expected, hashes)
+}
@@ -46,6 +46,15 @@ func New(repo *chain.Repository, stater *state.Stater, forkConfig *thor.ForkConf
 	}
 }
 
+// cachedValidators returns the validators cached for the block. Nothing is returned if the states are recorded, as
+// all state reads for validators must reach the recording view.
+func (c *Consensus) cachedValidators(id thor.Bytes32) (any, bool) {
+	if c.stater.IsRecording() {
+		return nil, false
+	}
+	return c.validatorsCache.Get(id)
+}
+
 // Process process a block.
 func (c *Consensus) Process(
 	parentSummary *chain.BlockSummary,
@@ -24,7 +24,7 @@ func (c *Consensus) validateAuthorityProposer(header *block.Header, parent *bloc
 
 	authority := builtin.Authority.Native(st)
 	var candidates *poa.Candidates
-	if entry, ok := c.validatorsCache.Get(parent.ID()); ok {
+	if entry, ok := c.cachedValidators(parent.ID()); ok {
 		candidates = entry.(*poa.Candidates).Copy()
 	} else {
 		list, err := authority.AllCandidates()
@@ -33,7 +33,7 @@ func (c *Consensus) validateStakingProposer(
 		return nil, err
 	}
 	var leaders []validation.Leader
-	if cached, ok := c.validatorsCache.Get(header.ParentID()); ok {
+	if cached, ok := c.cachedValidators(header.ParentID()); ok {
 		if cachedLeaders, ok := cached.([]validation.Leader); ok {
 			leaders = cachedLeaders
 		}
@@ -80,9 +80,11 @@ func (b Bucket) NewStore(src Store) Store {
 			snapshot := src.Snapshot()
 			return &struct {
 				Getter
+				IterateFunc
 				ReleaseFunc
 			}{
 				b.NewGetter(snapshot),
+				func(r Range) Iterator { return b.newIterator(snapshot, r) },
 				snapshot.Release,
 			}
 		},
@@ -98,35 +100,38 @@ func (b Bucket) NewStore(src Store) Store {
 				bulk.Write,
 			}
 		},
-		func(r Range) Iterator {
-			iter := src.Iterate(b.newRange(r))
-			return &struct {
-				FirstFunc
-				LastFunc
-				NextFunc
-				PrevFunc
-				KeyFunc
-				ValueFunc
-				ReleaseFunc
-				ErrorFunc
-			}{
-				iter.First,
-				iter.Last,
-				iter.Next,
-				iter.Prev,
-				// strip the bucket
-				func() []byte { return iter.Key()[len(b):] },
-				iter.Value,
-				iter.Release,
-				iter.Error,
-			}
-		},
+		func(r Range) Iterator { return b.newIterator(src, r) },
 		func(ctx context.Context, r Range) error {
 			return src.DeleteRange(ctx, b.newRange(r))
 		},
 	}
 }
 
+// newIterator creates a bucket iterator from the source.
+func (b Bucket) newIterator(src interface{ Iterate(r Range) Iterator }, r Range) Iterator {
+	iter := src.Iterate(b.newRange(r))
+	return &struct {
+		FirstFunc
+		LastFunc
+		NextFunc
+		PrevFunc
+		KeyFunc
+		ValueFunc
+		ReleaseFunc
+		ErrorFunc
+	}{
+		iter.First,
+		iter.Last,
+		iter.Next,
+		iter.Prev,
+		// strip the bucket
+		func() []byte { return iter.Key()[len(b):] },
+		iter.Value,
+		iter.Release,
+		iter.Error,
+	}
+}
+
 func (b Bucket) newRange(r Range) Range {
 	r.Start = slices.Concat([]byte(b), r.Start)
 	if len(r.Limit) == 0 {
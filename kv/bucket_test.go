@@ -245,6 +245,10 @@ func (ds *DummySnapshot) IsNotFound(err error) bool {
 	return err.Error() == "key not found"
 }
 
+func (ds *DummySnapshot) Iterate(_ Range) Iterator {
+	return &DummyIterator{}
+}
+
 func (ds *DummySnapshot) Release() {
 }
 
@@ -23,6 +23,7 @@ type Putter interface {
 // Snapshot is the store's snapshot.
 type Snapshot interface {
 	Getter
+	Iterate(r Range) Iterator
 	Release()
 }
 
@@ -0,0 +1,265 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package lightclient implements a minimal client of the light protocol, which fetches
+// headers and merkle proofs from full nodes and verifies them locally.
+package lightclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var (
+	errGenesisMismatch  = errors.New("genesis mismatch")
+	errStateUnavailable = errors.New("state unavailable")
+	errNotHandshaked    = errors.New("not handshaked")
+)
+
+// Client fetches and verifies headers and proofs from a light server.
+type Client struct {
+	rpc       proto.RPC
+	genesisID thor.Bytes32
+
+	lock    sync.Mutex
+	status  *proto.LightStatus
+	budget  uint64
+	updated time.Time
+}
+
+// New creates a light client over the rpc of a light server.
+func New(rpc proto.RPC, genesisID thor.Bytes32) *Client {
+	return &Client{
+		rpc:       rpc,
+		genesisID: genesisID,
+	}
+}
+
+// Handshake fetches the status of the server, and checks the genesis.
+// It must be called before other requests.
+func (c *Client) Handshake(ctx context.Context) (*proto.LightStatus, error) {
+	status, err := proto.GetLightStatus(ctx, c.rpc)
+	if err != nil {
+		return nil, err
+	}
+	if status.GenesisBlockID != c.genesisID {
+		return nil, errGenesisMismatch
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.status = status
+	c.budget = status.BufLimit
+	c.updated = time.Now()
+	return status, nil
+}
+
+// charge waits until the estimated budget is enough for the cost, and deducts it.
+// The estimation is never above the budget tracked by the server, since the server
+// starts recharging earlier.
+func (c *Client) charge(ctx context.Context, msgCode uint64, items int) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.status == nil {
+		return errNotHandshaked
+	}
+	cost := proto.LightRequestCost(msgCode, items)
+	if cost > c.status.BufLimit {
+		return errors.Errorf("request cost %v exceeds buffer limit", cost)
+	}
+
+	for {
+		now := time.Now()
+		recharged := uint64(now.Sub(c.updated)) * c.status.MinRecharge / uint64(time.Second)
+		c.budget = min(c.budget+recharged, c.status.BufLimit)
+		c.updated = now
+
+		if cost <= c.budget {
+			c.budget -= cost
+			return nil
+		}
+		if c.status.MinRecharge == 0 {
+			return errors.New("budget exhausted")
+		}
+
+		wait := time.Duration((cost - c.budget) * uint64(time.Second) / c.status.MinRecharge)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait + time.Millisecond):
+		}
+	}
+}
+
+// Headers fetches at most count headers following the given verified header, and verifies them.
+// The returned headers are chained from the given one.
+func (c *Client) Headers(ctx context.Context, from *block.Header, count int) ([]*block.Header, error) {
+	count = min(count, proto.MaxLightHeaders)
+	if err := c.charge(ctx, proto.MsgLightGetHeaders, count); err != nil {
+		return nil, err
+	}
+	headers, err := proto.GetHeaders(ctx, c.rpc, from.Number()+1, uint32(count))
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > count {
+		return nil, errors.New("too many headers")
+	}
+
+	parent := from
+	for _, header := range headers {
+		if err := VerifyHeader(parent, header); err != nil {
+			return nil, errors.WithMessage(err, "header "+header.ID().String())
+		}
+		parent = header
+	}
+	return headers, nil
+}
+
+// Checkpoint fetches the finalized and justified headers claimed by the server.
+// They are only sanity checked, and should be cross-checked with other servers or
+// against synced headers before being trusted.
+func (c *Client) Checkpoint(ctx context.Context) (*proto.LightCheckpoint, error) {
+	if err := c.charge(ctx, proto.MsgLightGetCheckpoint, 0); err != nil {
+		return nil, err
+	}
+	checkpoint, err := proto.GetCheckpoint(ctx, c.rpc)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint.Finalized == nil || checkpoint.Justified == nil {
+		return nil, errors.New("incomplete checkpoint")
+	}
+	if checkpoint.Finalized.Number() > checkpoint.Justified.Number() {
+		return nil, errors.New("finalized is ahead of justified")
+	}
+	for _, header := range []*block.Header{checkpoint.Finalized, checkpoint.Justified} {
+		// the genesis is unsigned
+		if header.Number() == 0 {
+			if header.ID() != c.genesisID {
+				return nil, errGenesisMismatch
+			}
+			continue
+		}
+		if _, err := header.Signer(); err != nil {
+			return nil, errors.WithMessage(err, "signer")
+		}
+	}
+	return checkpoint, nil
+}
+
+// Account fetches the account at the state of the verified header, and verifies its proof.
+func (c *Client) Account(ctx context.Context, header *block.Header, addr thor.Address) (*state.Account, error) {
+	acc, _, err := c.Storage(ctx, header, addr, nil)
+	return acc, err
+}
+
+// Storage fetches the account and its storage values at the state of the verified header,
+// and verifies their proofs.
+func (c *Client) Storage(ctx context.Context, header *block.Header, addr thor.Address, keys []thor.Bytes32) (*state.Account, []thor.Bytes32, error) {
+	if len(keys) > proto.MaxLightProofKeys {
+		return nil, nil, errors.Errorf("too many storage keys (%v)", len(keys))
+	}
+	if err := c.charge(ctx, proto.MsgLightGetProof, len(keys)+1); err != nil {
+		return nil, nil, err
+	}
+	proof, err := proto.GetProof(ctx, c.rpc, &proto.GetProofRequest{
+		BlockID: header.ID(),
+		Address: addr,
+		Keys:    keys,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if proof == nil {
+		return nil, nil, errStateUnavailable
+	}
+	if len(proof.StorageProofs) != len(keys) {
+		return nil, nil, errors.New("storage proofs count mismatch")
+	}
+
+	acc, err := state.VerifyAccountProof(header.StateRoot(), addr, proof.AccountProof)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "verify account proof")
+	}
+	values := make([]thor.Bytes32, 0, len(keys))
+	for i, key := range keys {
+		v, err := state.VerifyStorageProof(thor.BytesToBytes32(acc.StorageRoot), key, proof.StorageProofs[i])
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "verify storage proof of "+key.String())
+		}
+		values = append(values, v)
+	}
+	return acc, values, nil
+}
+
+// Receipt fetches the tx and its receipt in the block of the verified header, and verifies their proofs.
+// It returns nil tx and receipt if the tx is not in the block.
+func (c *Client) Receipt(ctx context.Context, header *block.Header, txID thor.Bytes32) (*tx.Transaction, *tx.Receipt, error) {
+	if err := c.charge(ctx, proto.MsgLightGetReceiptProof, 0); err != nil {
+		return nil, nil, err
+	}
+	proof, err := proto.GetReceiptProof(ctx, c.rpc, &proto.GetReceiptProofRequest{
+		BlockID: header.ID(),
+		TxID:    txID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if proof == nil {
+		return nil, nil, nil
+	}
+	if proof.Index > math.MaxUint32 {
+		return nil, nil, errors.New("tx index out of range")
+	}
+
+	trx, err := tx.VerifyTransactionProof(header.TxsRoot(), int(proof.Index), proof.TxProof)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "verify tx proof")
+	}
+	if trx == nil || trx.ID() != txID {
+		return nil, nil, errors.New("tx mismatch")
+	}
+	receipt, err := tx.VerifyReceiptProof(header.ReceiptsRoot(), int(proof.Index), proof.ReceiptProof)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "verify receipt proof")
+	}
+	if receipt == nil {
+		return nil, nil, errors.New("receipt missing")
+	}
+	return trx, receipt, nil
+}
+
+// VerifyHeader verifies the header is the child of the given parent, and properly signed.
+// The signer is not checked against the authority set, which is beyond a light client.
+func VerifyHeader(parent, header *block.Header) error {
+	if header.ParentID() != parent.ID() {
+		return errors.New("parent mismatch")
+	}
+	if header.Timestamp() <= parent.Timestamp() {
+		return errors.New("timestamp not increased")
+	}
+	if header.TotalScore() <= parent.TotalScore() {
+		return errors.New("total score not increased")
+	}
+	if header.GasUsed() > header.GasLimit() {
+		return errors.New("gas used exceeds limit")
+	}
+	if _, err := header.Signer(); err != nil {
+		return errors.WithMessage(err, "signer")
+	}
+	return nil
+}
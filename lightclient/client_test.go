@@ -0,0 +1,214 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package lightclient_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/lightclient"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// bufferedRW buffers the payload of read msg, as the rlpx transport does.
+type bufferedRW struct {
+	p2p.MsgReadWriter
+}
+
+func (rw bufferedRW) ReadMsg() (p2p.Msg, error) {
+	msg, err := rw.MsgReadWriter.ReadMsg()
+	if err != nil {
+		return msg, err
+	}
+	data, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return msg, err
+	}
+	msg.Payload = bytes.NewReader(data)
+	return msg, nil
+}
+
+func newTestChain(t *testing.T) (*testchain.Chain, *tx.Transaction) {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	to := thor.BytesToAddress([]byte("to"))
+	trx := tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(chain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(21000).
+		Nonce(1).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1000))).
+		Build()
+	trx = tx.MustSign(trx, genesis.DevAccounts()[0].PrivateKey)
+
+	require.NoError(t, chain.MintBlock(genesis.DevAccounts()[0], trx))
+	require.NoError(t, chain.MintBlock(genesis.DevAccounts()[0]))
+	return chain, trx
+}
+
+// connect serves the light protocol of chain through a msg pipe, and returns the connected client.
+func connect(t *testing.T, chain *testchain.Chain) *lightclient.Client {
+	communicator := comm.New(chain.Repo(), nil)
+	communicator.ServeLight(chain.Stater(), chain.Engine(), 1)
+
+	var serverProto *p2p.Protocol
+	for _, p := range communicator.Protocols() {
+		if p.Name == proto.LightName {
+			serverProto = p
+		}
+	}
+	require.NotNil(t, serverProto)
+
+	rw1, rw2 := p2p.MsgPipe()
+	t.Cleanup(func() {
+		rw1.Close()
+		rw2.Close()
+	})
+	go serverProto.Run(p2p.NewPeer(discover.NodeID{1}, "client", nil), bufferedRW{rw1})
+
+	clientCh := make(chan *lightclient.Client, 1)
+	clientProto := lightclient.Protocol(chain.GenesisBlock().Header().ID(), func(ctx context.Context, _ *p2p.Peer, c *lightclient.Client) error {
+		clientCh <- c
+		<-ctx.Done()
+		return nil
+	})
+	go clientProto.Run(p2p.NewPeer(discover.NodeID{2}, "server", nil), bufferedRW{rw2})
+
+	return <-clientCh
+}
+
+func TestClient(t *testing.T) {
+	chain, trx := newTestChain(t)
+	client := connect(t, chain)
+	ctx := context.Background()
+
+	genesisHeader := chain.GenesisBlock().Header()
+	best := chain.Repo().BestBlockSummary().Header
+
+	t.Run("headers", func(t *testing.T) {
+		headers, err := client.Headers(ctx, genesisHeader, 10)
+		require.NoError(t, err)
+		require.Len(t, headers, int(best.Number()))
+		assert.Equal(t, best.ID(), headers[len(headers)-1].ID())
+
+		headers, err = client.Headers(ctx, best, 10)
+		require.NoError(t, err)
+		assert.Empty(t, headers)
+	})
+
+	t.Run("checkpoint", func(t *testing.T) {
+		checkpoint, err := client.Checkpoint(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, chain.Engine().Finalized(), checkpoint.Finalized.ID())
+	})
+
+	t.Run("account", func(t *testing.T) {
+		st := chain.Stater().NewState(chain.Repo().BestBlockSummary().Root())
+
+		addr := genesis.DevAccounts()[0].Address
+		acc, err := client.Account(ctx, best, addr)
+		require.NoError(t, err)
+		balance, err := st.GetBalance(addr)
+		require.NoError(t, err)
+		assert.Equal(t, balance, acc.Balance)
+
+		// absent account
+		acc, err = client.Account(ctx, best, thor.BytesToAddress([]byte("absent")))
+		require.NoError(t, err)
+		assert.True(t, acc.IsEmpty())
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		st := chain.Stater().NewState(chain.Repo().BestBlockSummary().Root())
+
+		keys := []thor.Bytes32{thor.KeyExecutorAddress, thor.BytesToBytes32([]byte("absent"))}
+		_, values, err := client.Storage(ctx, best, builtin.Params.Address, keys)
+		require.NoError(t, err)
+		require.Len(t, values, len(keys))
+		for i, key := range keys {
+			want, err := st.GetStorage(builtin.Params.Address, key)
+			require.NoError(t, err)
+			assert.Equal(t, want, values[i])
+		}
+		assert.False(t, values[0].IsZero())
+	})
+
+	t.Run("unavailable state", func(t *testing.T) {
+		// header of a block unknown to the server
+		other, err := testchain.NewDefault()
+		require.NoError(t, err)
+		require.NoError(t, other.MintBlock(genesis.DevAccounts()[1]))
+
+		_, err = client.Account(ctx, other.Repo().BestBlockSummary().Header, genesis.DevAccounts()[0].Address)
+		assert.Error(t, err)
+	})
+
+	t.Run("receipt", func(t *testing.T) {
+		header, err := chain.Repo().NewBestChain().GetBlockHeader(1)
+		require.NoError(t, err)
+
+		gotTx, receipt, err := client.Receipt(ctx, header, trx.ID())
+		require.NoError(t, err)
+		require.NotNil(t, gotTx)
+		assert.Equal(t, trx.ID(), gotTx.ID())
+		assert.False(t, receipt.Reverted)
+
+		// not in block
+		gotTx, receipt, err = client.Receipt(ctx, best, trx.ID())
+		require.NoError(t, err)
+		assert.Nil(t, gotTx)
+		assert.Nil(t, receipt)
+	})
+}
+
+func TestVerifyHeader(t *testing.T) {
+	chain, _ := newTestChain(t)
+	repo := chain.Repo()
+
+	header1, err := repo.NewBestChain().GetBlockHeader(1)
+	require.NoError(t, err)
+	header2, err := repo.NewBestChain().GetBlockHeader(2)
+	require.NoError(t, err)
+
+	assert.NoError(t, lightclient.VerifyHeader(header1, header2))
+	assert.NoError(t, lightclient.VerifyHeader(chain.GenesisBlock().Header(), header1))
+
+	assert.Error(t, lightclient.VerifyHeader(chain.GenesisBlock().Header(), header2))
+	assert.Error(t, lightclient.VerifyHeader(header2, header1))
+}
+
+func TestHandshakeGenesisMismatch(t *testing.T) {
+	chain, _ := newTestChain(t)
+
+	communicator := comm.New(chain.Repo(), nil)
+	communicator.ServeLight(chain.Stater(), chain.Engine(), 1)
+	serverProto := communicator.Protocols()[len(communicator.Protocols())-1]
+
+	rw1, rw2 := p2p.MsgPipe()
+	defer rw1.Close()
+	defer rw2.Close()
+	go serverProto.Run(p2p.NewPeer(discover.NodeID{1}, "client", nil), bufferedRW{rw1})
+
+	clientProto := lightclient.Protocol(thor.Bytes32{1}, func(context.Context, *p2p.Peer, *lightclient.Client) error {
+		return nil
+	})
+	assert.Error(t, clientProto.Run(p2p.NewPeer(discover.NodeID{2}, "server", nil), bufferedRW{rw2}))
+}
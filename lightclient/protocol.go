@@ -0,0 +1,51 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package lightclient
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/p2psrv/rpc"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Protocol returns the p2p protocol to connect light servers, for use in a p2p server.
+// For each connected server, run is called with a handshaked client, and the server is
+// disconnected once run returns.
+func Protocol(genesisID thor.Bytes32, run func(ctx context.Context, peer *p2p.Peer, c *Client) error) *p2p.Protocol {
+	return &p2p.Protocol{
+		Name:    proto.LightName,
+		Version: proto.LightVersion1,
+		Length:  proto.LightLength,
+		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) error {
+			r := rpc.New(peer, rw)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go func() {
+				<-r.Done()
+				cancel()
+			}()
+
+			go func() {
+				// light servers never call clients
+				r.Serve(func(msg *p2p.Msg, _ func(any)) error {
+					return errors.Errorf("unexpected light msg (%v)", msg.Code)
+				}, proto.MaxMsgSize)
+			}()
+
+			c := New(r, genesisID)
+			if _, err := c.Handshake(ctx); err != nil {
+				return err
+			}
+			return run(ctx, peer, c)
+		},
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// backupStepPages is the count of pages copied per backup step.
+const backupStepPages = 1024
+
+// Backup copies a point-in-time view of the log db into a new database file at path, using the SQLite
+// online backup API, while the log db keeps being written.
+// The progress func, if not nil, is called after each step with the count of pages copied and the total.
+func (db *LogDB) Backup(ctx context.Context, path string, progress func(copied, total int)) (err error) {
+	if _, err := os.Stat(path); err == nil {
+		return errors.New("backup file exists")
+	}
+
+	// a private connection, to read from a snapshot without blocking writers
+	srcDB, err := sql.Open("sqlite3", "file:"+db.path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer srcDB.Close()
+	srcConn, err := srcDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	// the backup reuses the read transaction, so that it's never restarted by concurrent writes
+	if _, err := srcConn.ExecContext(ctx, "BEGIN"); err != nil {
+		return err
+	}
+	defer srcConn.ExecContext(context.Background(), "ROLLBACK")
+	var n int
+	if err := srcConn.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master").Scan(&n); err != nil {
+		return err
+	}
+
+	dstDB, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer dstDB.Close()
+	dstConn, err := dstDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+
+	return srcConn.Raw(func(src any) error {
+		return dstConn.Raw(func(dst any) error {
+			bk, err := dst.(*sqlite3.SQLiteConn).Backup("main", src.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			defer bk.Close()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				default:
+				}
+				done, err := bk.Step(backupStepPages)
+				if err != nil {
+					return err
+				}
+				if progress != nil {
+					total := bk.PageCount()
+					progress(total-bk.Remaining(), total)
+				}
+				if done {
+					return bk.Finish()
+				}
+			}
+		})
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -24,6 +25,8 @@ const (
 	refIDQuery = "(SELECT id FROM ref WHERE data=?)"
 )
 
+var errReadOnly = errors.New("log db opened read-only")
+
 type LogDB struct {
 	path          string
 	driverVersion string
@@ -45,7 +48,7 @@ func New(path string) (logDB *LogDB, err error) {
 		}
 	}()
 
-	if _, err := db.Exec(refTableScheme + eventTableSchema + transferTableSchema); err != nil {
+	if _, err := db.Exec(refTableScheme + eventTableSchema + transferTableSchema + metaTableSchema); err != nil {
 		return nil, err
 	}
 
@@ -74,6 +77,33 @@ func New(path string) (logDB *LogDB, err error) {
 	}, nil
 }
 
+// NewReadOnly opens the log db at given path for reading only, which may be written by another process
+// at the same time. Writers of the returned db always fail.
+func NewReadOnly(path string) (logDB *LogDB, err error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	// fail early if absent or not a log db
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='event'").Scan(&n); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if n == 0 {
+		_ = db.Close()
+		return nil, errors.New("log db not initialized")
+	}
+
+	driverVer, _, _ := sqlite3.Version()
+	return &LogDB{
+		path:          path,
+		driverVersion: driverVer,
+		db:            db,
+		stmtCache:     newStmtCache(db),
+	}, nil
+}
+
 // NewMem create a log db in ram.
 // Unlike the file-based database, this implementation:
 // 1. Uses synchronous=off for faster writes in memory
@@ -97,7 +127,7 @@ func NewMem() (*LogDB, error) {
 		return nil, err
 	}
 
-	if _, err := db.Exec(refTableScheme + eventTableSchema + transferTableSchema); err != nil {
+	if _, err := db.Exec(refTableScheme + eventTableSchema + transferTableSchema + metaTableSchema); err != nil {
 		db.Close()
 		return nil, err
 	}
@@ -122,9 +152,11 @@ func NewMem() (*LogDB, error) {
 
 // Close close the log db.
 func (db *LogDB) Close() (err error) {
-	err = db.wconn.Close()
-	if err1 := db.wconnSyncOff.Close(); err == nil {
-		err = err1
+	if db.wconn != nil {
+		err = db.wconn.Close()
+		if err1 := db.wconnSyncOff.Close(); err == nil {
+			err = err1
+		}
 	}
 	db.stmtCache.Clear()
 	if err1 := db.db.Close(); err == nil {
@@ -137,6 +169,33 @@ func (db *LogDB) Path() string {
 	return db.path
 }
 
+// TableStats is the statistics of a table.
+type TableStats struct {
+	Name string
+	Rows int64
+}
+
+// Stats returns row counts of tables, and the size of the database in bytes.
+func (db *LogDB) Stats(ctx context.Context) ([]*TableStats, int64, error) {
+	var stats []*TableStats
+	for _, name := range []string{"ref", "event", "transfer"} {
+		s := TableStats{Name: name}
+		if err := db.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+name).Scan(&s.Rows); err != nil {
+			return nil, 0, err
+		}
+		stats = append(stats, &s)
+	}
+
+	var pageCount, pageSize int64
+	if err := db.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return nil, 0, err
+	}
+	if err := db.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return nil, 0, err
+	}
+	return stats, pageCount * pageSize, nil
+}
+
 func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Event, error) {
 	const query = `SELECT e.seq, r0.data, e.blockTime, r1.data, r2.data, e.clauseIndex, r3.data, r4.data, r5.data, r6.data, r7.data, r8.data, e.data
 FROM (%v) e
@@ -508,6 +567,30 @@ func (w *Writer) Truncate(blockNum uint32) error {
 	return nil
 }
 
+// DeleteRange deletes logs of blocks in the range [from, to].
+func (w *Writer) DeleteRange(from, to uint32) error {
+	if from > to {
+		return nil
+	}
+	start, err := newSequence(from, 0, 0)
+	if err != nil {
+		return err
+	}
+	// the largest sequence of the block 'to'
+	end, err := newSequence(to, txIndexMask, logIndexMask)
+	if err != nil {
+		return err
+	}
+
+	if err := w.exec("DELETE FROM event WHERE seq >= ? AND seq <= ?", start, end); err != nil {
+		return err
+	}
+	if err := w.exec("DELETE FROM transfer WHERE seq >= ? AND seq <= ?", start, end); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Write writes all logs of the given block.
 func (w *Writer) Write(b *block.Block, receipts tx.Receipts) error {
 	var (
@@ -689,15 +772,29 @@ func (w *Writer) UncommittedCount() int {
 	return w.uncommittedCount
 }
 
-func (w *Writer) exec(query string, args ...any) (err error) {
-	if w.tx == nil {
-		if w.tx, err = w.conn.BeginTx(context.Background(), nil); err != nil {
-			return
-		}
+func (w *Writer) exec(query string, args ...any) error {
+	stmt, err := w.stmt(query)
+	if err != nil {
+		return err
 	}
-	if _, err = w.tx.Stmt(w.stmtCache.MustPrepare(query)).Exec(args...); err != nil {
-		return
+	if _, err := stmt.Exec(args...); err != nil {
+		return err
 	}
 	w.uncommittedCount++
 	return nil
 }
+
+// stmt returns the prepared statement of the query, bound to the transaction, which is begun if not yet.
+func (w *Writer) stmt(query string) (*sql.Stmt, error) {
+	if w.tx == nil {
+		if w.conn == nil {
+			return nil, errReadOnly
+		}
+		tx, err := w.conn.BeginTx(context.Background(), nil)
+		if err != nil {
+			return nil, err
+		}
+		w.tx = tx
+	}
+	return w.tx.Stmt(w.stmtCache.MustPrepare(query)), nil
+}
@@ -497,6 +497,37 @@ func TestLogDB_HasBlockID(t *testing.T) {
 	assert.True(t, has)
 }
 
+func TestLogDB_Stats(t *testing.T) {
+	db, err := NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	b0 := new(block.Builder).Build()
+	b := new(block.Builder).
+		ParentID(b0.Header().ID()).
+		Transaction(newTx(tx.TypeLegacy)).
+		Transaction(newTx(tx.TypeLegacy)).
+		Build()
+
+	w := db.NewWriter()
+	if err := w.Write(b, tx.Receipts{newReceipt(), newEventOnlyReceipt()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, size, err := db.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, size > 0)
+	assert.Equal(t, []*TableStats{{"ref", stats[0].Rows}, {"event", 2}, {"transfer", 1}}, stats)
+	assert.True(t, stats[0].Rows > 0)
+}
+
 func TestRemoveLeadingZeros(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -527,3 +558,86 @@ func TestRemoveLeadingZeros(t *testing.T) {
 		})
 	}
 }
+
+func TestLogDB_DeleteRange(t *testing.T) {
+	db, err := NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var (
+		b = new(block.Builder).Build()
+		w = db.NewWriter()
+	)
+	for range 5 {
+		b = new(block.Builder).
+			ParentID(b.Header().ID()).
+			Transaction(newTx(tx.TypeLegacy)).
+			Build()
+		if err := w.Write(b, tx.Receipts{newReceipt()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// blocks #2 to #6, delete logs of #3 to #5
+	if err := w.DeleteRange(3, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := db.FilterEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, uint32(2), events[0].BlockNumber)
+		assert.Equal(t, uint32(6), events[1].BlockNumber)
+	}
+	transfers, err := db.FilterTransfers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, transfers, 2) {
+		assert.Equal(t, uint32(2), transfers[0].BlockNumber)
+		assert.Equal(t, uint32(6), transfers[1].BlockNumber)
+	}
+}
+
+func TestLogDB_NewReadOnly(t *testing.T) {
+	path := t.TempDir() + "/logs.db"
+
+	_, err := NewReadOnly(path)
+	assert.Error(t, err, "absent db")
+
+	db, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ro, err := NewReadOnly(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+
+	b := new(block.Builder).Transaction(newTx(tx.TypeLegacy)).Build()
+	w := db.NewWriter()
+	if err := w.Write(b, tx.Receipts{newReceipt()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// logs written by the other instance are visible
+	events, err := ro.FilterEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, events, 1)
+
+	assert.Equal(t, errReadOnly, ro.NewWriter().Write(b, tx.Receipts{newReceipt()}))
+}
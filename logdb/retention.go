@@ -0,0 +1,139 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// the meta key of the oldest block number whose logs are retained.
+const oldestBlockNumKey = "oldestBlockNum"
+
+// OldestBlockNum returns the number of the oldest block whose logs are retained.
+// Logs of blocks before it have been pruned. It's zero if never pruned.
+func (db *LogDB) OldestBlockNum() (uint32, error) {
+	var num uint32
+	if err := db.stmtCache.MustPrepare("SELECT value FROM meta WHERE key=?").QueryRow(oldestBlockNumKey).Scan(&num); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return num, nil
+}
+
+// Prune deletes at most limit events and limit transfers of blocks before the given block number, in
+// ascending order, and records the block number as the oldest retained one.
+// Refs of block and tx IDs of the deleted logs are deleted as well, once no longer referenced. They're
+// the refs growing with the chain, while refs of addresses and topics are mostly reused, and left in place.
+//
+// It returns the count of deleted logs, and is expected to be called repeatedly until nothing deleted.
+func (w *Writer) Prune(before uint32, limit int) (int, error) {
+	end, err := newSequence(before, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	// the oldest block is recorded first, so that queries fail as soon as any log of it's pruned
+	if err := w.exec(
+		"INSERT INTO meta(key, value) VALUES(?, ?) ON CONFLICT(key) DO UPDATE SET value=MAX(value, excluded.value)",
+		oldestBlockNumKey, before); err != nil {
+		return 0, err
+	}
+
+	var (
+		deleted int
+		refs    = make(map[int64]uint32) // ref id => block number
+	)
+	for _, table := range []string{"event", "transfer"} {
+		if err := w.collectRefs(table, end, limit, refs); err != nil {
+			return 0, err
+		}
+
+		stmt, err := w.stmt(fmt.Sprintf(
+			"DELETE FROM %[1]v WHERE seq IN (SELECT seq FROM %[1]v WHERE seq < ? ORDER BY seq LIMIT ?)", table))
+		if err != nil {
+			return 0, err
+		}
+		res, err := stmt.Exec(end, limit)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		w.uncommittedCount++
+		deleted += int(n)
+	}
+
+	if err := w.deleteOrphanRefs(refs); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// collectRefs collects refs of block and tx IDs of logs to be deleted by Prune.
+func (w *Writer) collectRefs(table string, end sequence, limit int, refs map[int64]uint32) error {
+	stmt, err := w.stmt(fmt.Sprintf("SELECT seq, blockID, txID FROM %v WHERE seq < ? ORDER BY seq LIMIT ?", table))
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.Query(end, limit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			seq           sequence
+			blockID, txID int64
+		)
+		if err := rows.Scan(&seq, &blockID, &txID); err != nil {
+			return err
+		}
+		refs[blockID] = seq.BlockNumber()
+		refs[txID] = seq.BlockNumber()
+	}
+	return rows.Err()
+}
+
+// deleteOrphanRefs deletes refs of block and tx IDs, which are no longer referenced.
+//
+// A block or tx ID is referenced as blockID or txID by logs of its block only, so only logs in the range
+// of the block are checked, while the topic columns are checked by indexes. Other columns never reference
+// them, as addresses are 20 bytes, and topic4 is never set since EVM logs have at most 4 topics.
+func (w *Writer) deleteOrphanRefs(refs map[int64]uint32) error {
+	const query = `DELETE FROM ref WHERE id=?1
+	AND NOT EXISTS (SELECT 1 FROM event WHERE seq >= ?2 AND seq <= ?3 AND (blockID=?1 OR txID=?1))
+	AND NOT EXISTS (SELECT 1 FROM transfer WHERE seq >= ?2 AND seq <= ?3 AND (blockID=?1 OR txID=?1))
+	AND NOT EXISTS (SELECT 1 FROM event WHERE topic0=?1)
+	AND NOT EXISTS (SELECT 1 FROM event WHERE topic1=?1)
+	AND NOT EXISTS (SELECT 1 FROM event WHERE topic2=?1)
+	AND NOT EXISTS (SELECT 1 FROM event WHERE topic3=?1)`
+
+	for id, blockNum := range refs {
+		start, err := newSequence(blockNum, 0, 0)
+		if err != nil {
+			return err
+		}
+		// the largest sequence of the block
+		end, err := newSequence(blockNum, txIndexMask, logIndexMask)
+		if err != nil {
+			return err
+		}
+		stmt, err := w.stmt(query)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.Exec(id, start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
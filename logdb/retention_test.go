@@ -0,0 +1,113 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestLogDB_Prune(t *testing.T) {
+	db, err := NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldest, err := db.OldestBlockNum()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), oldest)
+
+	var (
+		b          = new(block.Builder).Build()
+		blocks     []*block.Block
+		w          = db.NewWriter()
+		shared     thor.Bytes32
+		prunedAddr thor.Address
+	)
+	for i := range 10 {
+		b = new(block.Builder).
+			ParentID(b.Header().ID()).
+			Transaction(newTx(tx.TypeLegacy)).
+			Transaction(newTx(tx.TypeLegacy)).
+			Build()
+		receipts := tx.Receipts{newReceipt(), newEventOnlyReceipt()}
+		if i == 0 {
+			prunedAddr = receipts[0].Outputs[0].Transfers[0].Sender
+		}
+		if i == 8 {
+			// a retained event has the ID of a pruned tx as topic, which shares the ref
+			for _, pruned := range blocks[:5] {
+				if id := pruned.Transactions()[0].ID(); id[0] != 0 {
+					shared = id
+					receipts[1].Outputs[0].Events[0].Topics[0] = id
+					break
+				}
+			}
+		}
+		require.NoError(t, w.Write(b, receipts))
+		blocks = append(blocks, b)
+	}
+	require.NoError(t, w.Commit())
+
+	refExists := func(data []byte) bool {
+		var n int
+		require.NoError(t, db.db.QueryRow("SELECT COUNT(*) FROM ref WHERE data=?", data).Scan(&n))
+		return n > 0
+	}
+
+	before := blocks[5].Header().Number()
+	var total int
+	for {
+		n, err := w.Prune(before, 3)
+		require.NoError(t, err)
+		require.NoError(t, w.Commit())
+		if n == 0 {
+			break
+		}
+		total += n
+	}
+	// 5 blocks, each has 2 events and 1 transfer
+	assert.Equal(t, 15, total)
+
+	oldest, err = db.OldestBlockNum()
+	require.NoError(t, err)
+	assert.Equal(t, before, oldest)
+
+	events, err := db.FilterEvents(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, events, 10)
+	assert.Equal(t, before, events[0].BlockNumber)
+	transfers, err := db.FilterTransfers(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, transfers, 5)
+	assert.Equal(t, before, transfers[0].BlockNumber)
+
+	// refs of block and tx IDs of pruned blocks are deleted, unless still referenced
+	require.False(t, shared.IsZero())
+	for i, b := range blocks {
+		retained := i >= 5
+		assert.Equal(t, retained, refExists(b.Header().ID().Bytes()), "block %v", i)
+		for _, tx := range b.Transactions() {
+			assert.Equal(t, retained || tx.ID() == shared, refExists(tx.ID().Bytes()), "tx of block %v", i)
+		}
+	}
+	// refs of addresses are left in place
+	assert.True(t, refExists(prunedAddr.Bytes()))
+
+	// the oldest block never goes back
+	_, err = w.Prune(before-2, 3)
+	require.NoError(t, err)
+	require.NoError(t, w.Commit())
+	oldest, err = db.OldestBlockNum()
+	require.NoError(t, err)
+	assert.Equal(t, before, oldest)
+}
@@ -50,4 +50,10 @@ CREATE INDEX IF NOT EXISTS event_i4 ON event(topic3, topic0, address) WHERE topi
 CREATE INDEX IF NOT EXISTS transfer_i0 ON transfer(txOrigin);
 CREATE INDEX IF NOT EXISTS transfer_i1 ON transfer(sender);
 CREATE INDEX IF NOT EXISTS transfer_i2 ON transfer(recipient);`
+
+	// creates meta table, to store properties of the database
+	metaTableSchema = `CREATE TABLE IF NOT EXISTS meta (
+	key TEXT PRIMARY KEY NOT NULL,
+	value INTEGER NOT NULL
+);`
 )
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logscan
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/thor"
+)
+
+var logger = log.WithContext("pkg", "logscan")
+
+// sectionConfirmations is the count of blocks a section should be behind the best block before indexed,
+// to rarely rebuild the section on forks.
+const sectionConfirmations = 180
+
+// Indexer is a background task to build section log blooms of the canonical chain.
+type Indexer struct {
+	repo   *chain.Repository
+	ctx    context.Context
+	cancel func()
+	goes   co.Goes
+}
+
+// NewIndexer creates and starts the indexer.
+func NewIndexer(repo *chain.Repository) *Indexer {
+	ctx, cancel := context.WithCancel(context.Background())
+	i := &Indexer{
+		repo:   repo,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	i.goes.Go(func() {
+		if err := i.loop(); err != nil && errors.Cause(err) != context.Canceled {
+			logger.Warn("indexer interrupted", "error", err)
+		}
+	})
+	return i
+}
+
+// Stop stops the indexer.
+func (i *Indexer) Stop() {
+	i.cancel()
+	i.goes.Wait()
+}
+
+func (i *Indexer) loop() error {
+	var (
+		ticker = i.repo.NewTicker()
+		next   uint32 // the next section to index
+	)
+	for {
+		best := i.repo.BestBlockSummary().Header
+		for {
+			end := (next+1)*chain.LogBloomSectionSize - 1
+			if end+sectionConfirmations > best.Number() {
+				break
+			}
+			if err := i.index(best.ID(), end); err != nil {
+				return errors.Wrapf(err, "index section %v", next)
+			}
+			next++
+
+			select {
+			case <-i.ctx.Done():
+				return i.ctx.Err()
+			default:
+			}
+		}
+
+		select {
+		case <-i.ctx.Done():
+			return i.ctx.Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+// index builds the bloom of the section ending at the given block on the chain, if not built yet.
+func (i *Indexer) index(headID thor.Bytes32, end uint32) error {
+	endID, err := i.repo.NewChain(headID).GetBlockID(end)
+	if err != nil {
+		return err
+	}
+	if _, err := i.repo.GetSectionLogBloom(endID); err == nil {
+		return nil
+	} else if !i.repo.IsNotFound(err) {
+		return err
+	}
+	if err := i.repo.BuildSectionLogBloom(endID); err != nil {
+		return err
+	}
+	logger.Debug("section log bloom built", "end", end)
+	return nil
+}
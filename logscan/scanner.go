@@ -0,0 +1,387 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package logscan answers log queries without the log db, by scanning log blooms and then receipts
+// of the canonical chain.
+package logscan
+
+import (
+	"context"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Scanner filters logs, in the same way as the log db does.
+type Scanner struct {
+	repo *chain.Repository
+}
+
+// New creates a scanner.
+func New(repo *chain.Repository) *Scanner {
+	return &Scanner{repo}
+}
+
+// OldestBlockNum always returns zero, since receipts of all blocks are kept.
+func (s *Scanner) OldestBlockNum() (uint32, error) {
+	return 0, nil
+}
+
+// FilterEvents filters events.
+func (s *Scanner) FilterEvents(ctx context.Context, filter *logdb.EventFilter) ([]*logdb.Event, error) {
+	if filter == nil {
+		filter = &logdb.EventFilter{}
+	}
+	criteriaSet := filter.CriteriaSet
+	if len(criteriaSet) == 0 {
+		criteriaSet = []*logdb.EventCriteria{{}}
+	}
+	keySets := make([][][]byte, 0, len(criteriaSet))
+	for _, c := range criteriaSet {
+		keySets = append(keySets, chain.EventBloomKeys(c.Address, c.Topics))
+	}
+
+	var events []*logdb.Event
+	c := newCollector(filter.Options)
+	err := s.scan(ctx, filter.Range, filter.Order, keySets, keySets, func(summary *chain.BlockSummary) (bool, error) {
+		receipts, txs, err := s.loadBody(summary)
+		if err != nil {
+			return false, err
+		}
+		var (
+			matched []*logdb.Event
+			count   uint32
+		)
+		for i, r := range receipts {
+			for clauseIndex, output := range r.Outputs {
+				for _, ev := range output.Events {
+					logIndex := count
+					count++
+					if !matchEvent(criteriaSet, ev) {
+						continue
+					}
+					origin, err := txs[i].Origin()
+					if err != nil {
+						return false, err
+					}
+					event := &logdb.Event{
+						BlockNumber: summary.Header.Number(),
+						LogIndex:    logIndex,
+						BlockID:     summary.Header.ID(),
+						BlockTime:   summary.Header.Timestamp(),
+						TxID:        txs[i].ID(),
+						TxIndex:     uint32(i),
+						TxOrigin:    origin,
+						ClauseIndex: uint32(clauseIndex),
+						Address:     ev.Address,
+						Data:        ev.Data,
+					}
+					for j := range ev.Topics {
+						event.Topics[j] = &ev.Topics[j]
+					}
+					matched = append(matched, event)
+				}
+			}
+		}
+		if filter.Order == logdb.DESC {
+			reverse(matched)
+		}
+		for _, ev := range matched {
+			if c.Full() {
+				break
+			}
+			if c.Take() {
+				events = append(events, ev)
+			}
+		}
+		return c.Full(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FilterTransfers filters transfers.
+func (s *Scanner) FilterTransfers(ctx context.Context, filter *logdb.TransferFilter) ([]*logdb.Transfer, error) {
+	if filter == nil {
+		filter = &logdb.TransferFilter{}
+	}
+	criteriaSet := filter.CriteriaSet
+	if len(criteriaSet) == 0 {
+		criteriaSet = []*logdb.TransferCriteria{{}}
+	}
+	var (
+		blockKeySets   = make([][][]byte, 0, len(criteriaSet))
+		sectionKeySets = make([][][]byte, 0, len(criteriaSet))
+	)
+	for _, c := range criteriaSet {
+		blockKeySets = append(blockKeySets, chain.TransferBloomKeys(c.TxOrigin, c.Sender, c.Recipient, false))
+		sectionKeySets = append(sectionKeySets, chain.TransferBloomKeys(c.TxOrigin, c.Sender, c.Recipient, true))
+	}
+
+	var transfers []*logdb.Transfer
+	c := newCollector(filter.Options)
+	err := s.scan(ctx, filter.Range, filter.Order, blockKeySets, sectionKeySets, func(summary *chain.BlockSummary) (bool, error) {
+		receipts, txs, err := s.loadBody(summary)
+		if err != nil {
+			return false, err
+		}
+		var (
+			matched []*logdb.Transfer
+			count   uint32
+		)
+		for i, r := range receipts {
+			var origin *thor.Address
+			for clauseIndex, output := range r.Outputs {
+				for _, tr := range output.Transfers {
+					logIndex := count
+					count++
+					if origin == nil {
+						o, err := txs[i].Origin()
+						if err != nil {
+							return false, err
+						}
+						origin = &o
+					}
+					if !matchTransfer(criteriaSet, *origin, tr) {
+						continue
+					}
+					matched = append(matched, &logdb.Transfer{
+						BlockNumber: summary.Header.Number(),
+						LogIndex:    logIndex,
+						BlockID:     summary.Header.ID(),
+						BlockTime:   summary.Header.Timestamp(),
+						TxID:        txs[i].ID(),
+						TxIndex:     uint32(i),
+						TxOrigin:    *origin,
+						ClauseIndex: uint32(clauseIndex),
+						Sender:      tr.Sender,
+						Recipient:   tr.Recipient,
+						Amount:      tr.Amount,
+					})
+				}
+			}
+		}
+		if filter.Order == logdb.DESC {
+			reverse(matched)
+		}
+		for _, tr := range matched {
+			if c.Full() {
+				break
+			}
+			if c.Take() {
+				transfers = append(transfers, tr)
+			}
+		}
+		return c.Full(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// scan visits blocks of the canonical chain in the range, which may contain logs matching any of key sets.
+// Section blooms are tested with sectionKeySets, and block blooms with blockKeySets. Blocks without bloom
+// are always visited. The scan stops once visit returns true.
+func (s *Scanner) scan(
+	ctx context.Context,
+	rng *logdb.Range,
+	order logdb.Order,
+	blockKeySets, sectionKeySets [][][]byte,
+	visit func(summary *chain.BlockSummary) (bool, error),
+) error {
+	var (
+		best      = s.repo.BestBlockSummary().Header
+		bestChain = s.repo.NewChain(best.ID())
+		from      = uint32(1) // block 0 has no log
+		to        = best.Number()
+	)
+	if rng != nil {
+		from = max(rng.From, from)
+		if rng.To >= rng.From {
+			to = min(rng.To, to)
+		}
+	}
+	if from > to {
+		return nil
+	}
+
+	desc := order == logdb.DESC
+	// the section whose bloom is tested, to not test it again
+	testedSection := uint32(1<<32 - 1)
+	for n := from; n <= to; {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		num := n
+		if desc {
+			num = to - (n - from)
+		}
+
+		if section := num / chain.LogBloomSectionSize; section != testedSection {
+			testedSection = section
+			skip, err := s.skipSection(bestChain, best.Number(), section, sectionKeySets)
+			if err != nil {
+				return err
+			}
+			if skip {
+				// jump to the next section in the scan direction
+				if desc {
+					n += num - section*chain.LogBloomSectionSize + 1
+				} else {
+					n += (section+1)*chain.LogBloomSectionSize - num
+				}
+				continue
+			}
+		}
+
+		id, err := bestChain.GetBlockID(num)
+		if err != nil {
+			return err
+		}
+		mayContain, err := s.mayContain(id, blockKeySets)
+		if err != nil {
+			return err
+		}
+		if mayContain {
+			summary, err := s.repo.GetBlockSummary(id)
+			if err != nil {
+				return err
+			}
+			if done, err := visit(summary); err != nil || done {
+				return err
+			}
+		}
+		n++
+	}
+	return nil
+}
+
+// skipSection returns whether the section bloom tells no matching log in the section.
+func (s *Scanner) skipSection(bestChain *chain.Chain, bestNum uint32, section uint32, keySets [][][]byte) (bool, error) {
+	end := section*chain.LogBloomSectionSize + chain.LogBloomSectionSize - 1
+	if end > bestNum {
+		return false, nil
+	}
+	endID, err := bestChain.GetBlockID(end)
+	if err != nil {
+		return false, err
+	}
+	bloom, err := s.repo.GetSectionLogBloom(endID)
+	if err != nil {
+		if s.repo.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, keys := range keySets {
+		if bloom.MayContain(keys) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mayContain returns whether the block may contain logs matching any of key sets.
+func (s *Scanner) mayContain(id thor.Bytes32, keySets [][][]byte) (bool, error) {
+	bloom, err := s.repo.GetBlockLogBloom(id)
+	if err != nil {
+		if s.repo.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	for _, keys := range keySets {
+		if bloom.MayContain(keys) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Scanner) loadBody(summary *chain.BlockSummary) (tx.Receipts, tx.Transactions, error) {
+	id := summary.Header.ID()
+	receipts, err := s.repo.GetBlockReceipts(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	txs, err := s.repo.GetBlockTransactions(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return receipts, txs, nil
+}
+
+func matchEvent(criteriaSet []*logdb.EventCriteria, ev *tx.Event) bool {
+	for _, c := range criteriaSet {
+		if c.Address != nil && *c.Address != ev.Address {
+			continue
+		}
+		matched := true
+		for i, topic := range c.Topics {
+			if topic != nil && (i >= len(ev.Topics) || *topic != ev.Topics[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTransfer(criteriaSet []*logdb.TransferCriteria, origin thor.Address, tr *tx.Transfer) bool {
+	for _, c := range criteriaSet {
+		if (c.TxOrigin == nil || *c.TxOrigin == origin) &&
+			(c.Sender == nil || *c.Sender == tr.Sender) &&
+			(c.Recipient == nil || *c.Recipient == tr.Recipient) {
+			return true
+		}
+	}
+	return false
+}
+
+// collector applies offset and limit options.
+type collector struct {
+	skip  uint64
+	limit uint64
+	taken uint64
+}
+
+func newCollector(opts *logdb.Options) *collector {
+	if opts == nil {
+		return &collector{limit: ^uint64(0)}
+	}
+	return &collector{skip: opts.Offset, limit: opts.Limit}
+}
+
+// Take returns whether to take the current log.
+func (c *collector) Take() bool {
+	if c.skip > 0 {
+		c.skip--
+		return false
+	}
+	c.taken++
+	return true
+}
+
+// Full returns whether enough logs taken.
+func (c *collector) Full() bool {
+	return c.taken >= c.limit
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
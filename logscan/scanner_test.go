@@ -0,0 +1,118 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logscan
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newTestChain(t *testing.T) *testchain.Chain {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+	chain.Repo().EnableLogBlooms()
+
+	transferABI, ok := builtin.Energy.ABI.MethodByName("transfer")
+	require.True(t, ok)
+
+	accounts := genesis.DevAccounts()
+	for i := range 6 {
+		to := accounts[(i+1)%3].Address
+		data, err := transferABI.EncodeInput(to, big.NewInt(int64(i+1)))
+		require.NoError(t, err)
+		require.NoError(t, chain.MintClauses(accounts[i%3], []*tx.Clause{
+			tx.NewClause(&to).WithValue(big.NewInt(int64(i + 1))),
+			tx.NewClause(&builtin.Energy.Address).WithData(data),
+		}))
+	}
+	return chain
+}
+
+func TestFilterEvents(t *testing.T) {
+	chain := newTestChain(t)
+	scanner := New(chain.Repo())
+
+	transferEvent, ok := builtin.Energy.ABI.EventByName("Transfer")
+	require.True(t, ok)
+
+	var (
+		energy       = builtin.Energy.Address
+		transferSig  = transferEvent.ID()
+		from         = thor.BytesToBytes32(genesis.DevAccounts()[1].Address.Bytes())
+		unknownTopic = thor.BytesToBytes32([]byte("unknown"))
+	)
+
+	filters := []*logdb.EventFilter{
+		nil,
+		{},
+		{Order: logdb.DESC},
+		{Range: &logdb.Range{From: 2, To: 4}},
+		{Range: &logdb.Range{From: 3}, Order: logdb.DESC},
+		{Options: &logdb.Options{Offset: 1, Limit: 3}},
+		{Options: &logdb.Options{Offset: 2, Limit: 2}, Order: logdb.DESC},
+		{CriteriaSet: []*logdb.EventCriteria{{Address: &energy}}},
+		{CriteriaSet: []*logdb.EventCriteria{{Topics: [5]*thor.Bytes32{&transferSig, &from}}}},
+		{CriteriaSet: []*logdb.EventCriteria{{Topics: [5]*thor.Bytes32{nil, nil, &from}}, {Topics: [5]*thor.Bytes32{&unknownTopic}}}},
+		{CriteriaSet: []*logdb.EventCriteria{{Topics: [5]*thor.Bytes32{&unknownTopic}}}},
+	}
+	for i, filter := range filters {
+		expected, err := chain.LogDB().FilterEvents(context.Background(), filter)
+		require.NoError(t, err)
+		actual, err := scanner.FilterEvents(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "filter #%d", i)
+	}
+}
+
+func TestFilterTransfers(t *testing.T) {
+	chain := newTestChain(t)
+	scanner := New(chain.Repo())
+
+	var (
+		acc0    = genesis.DevAccounts()[0].Address
+		acc1    = genesis.DevAccounts()[1].Address
+		unknown = thor.BytesToAddress([]byte("unknown"))
+	)
+
+	filters := []*logdb.TransferFilter{
+		nil,
+		{},
+		{Order: logdb.DESC},
+		{Range: &logdb.Range{From: 2, To: 4}},
+		{Options: &logdb.Options{Offset: 1, Limit: 3}, Order: logdb.DESC},
+		{CriteriaSet: []*logdb.TransferCriteria{{TxOrigin: &acc0}}},
+		{CriteriaSet: []*logdb.TransferCriteria{{Sender: &acc1}, {Recipient: &acc0}}},
+		{CriteriaSet: []*logdb.TransferCriteria{{TxOrigin: &acc0, Recipient: &acc1}}},
+		{CriteriaSet: []*logdb.TransferCriteria{{Sender: &unknown}}},
+	}
+	for i, filter := range filters {
+		expected, err := chain.LogDB().FilterTransfers(context.Background(), filter)
+		require.NoError(t, err)
+		actual, err := scanner.FilterTransfers(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "filter #%d", i)
+	}
+}
+
+func TestScanCanceled(t *testing.T) {
+	chain := newTestChain(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := New(chain.Repo()).FilterEvents(ctx, nil)
+	assert.Equal(t, context.Canceled, err)
+}
@@ -20,6 +20,12 @@ type backend struct {
 	Cache                           Cache
 	HistPtnFactor, DedupedPtnFactor uint32
 	CachedNodeTTL                   uint16
+
+	// Archive, if not nil, is where nodes missed in hist space are read from the archive space,
+	// instead of the deduped space of Store.
+	Archive kv.Getter
+	// Recorder, if not nil, receives nodes read from the Store.
+	Recorder Recorder
 }
 
 // AppendHistNodeKey composes hist node key and appends to buf.
@@ -66,6 +72,18 @@ func (b *backend) AppendDedupedNodeKey(buf []byte, name string, path []byte, ver
 	return buf
 }
 
+// AppendArchiveNodeKey composes archive node key and appends to buf.
+func (b *backend) AppendArchiveNodeKey(buf []byte, name string, path []byte, ver trie.Version) []byte {
+	buf = append(buf, trieArchiveSpace) // space
+	buf = append(buf, name...)          // trie name
+	buf = appendNodePath(buf, path)     // path
+	buf = binary.BigEndian.AppendUint32(buf, ver.Major)
+	if ver.Minor != 0 { // minor ver
+		buf = binary.AppendUvarint(buf, uint64(ver.Minor))
+	}
+	return buf
+}
+
 // DeleteHistoryNodes deletes trie history nodes within partitions of [startMajorVer, limitMajorVer).
 func (b *backend) DeleteHistoryNodes(ctx context.Context, startMajorVer, limitMajorVer uint32) error {
 	startPtn := startMajorVer / b.HistPtnFactor
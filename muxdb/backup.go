@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"context"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/vechain/thor/v2/kv"
+)
+
+// backupProgressInterval is the count of entries copied between progress reports.
+const backupProgressInterval = 10000
+
+// Backup copies a point-in-time view of the DB into a new DB at path, while the DB keeps being written.
+// The progress func, if not nil, is called periodically with the count and size of entries copied.
+func (db *MuxDB) Backup(ctx context.Context, path string, progress func(entries, size uint64)) (err error) {
+	ldb, err := leveldb.OpenFile(path, &opt.Options{
+		ErrorIfExist:        true,
+		BlockSize:           1024 * 32,
+		CompactionTableSize: 4 * opt.MiB,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := ldb.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	snapshot := db.engine.Snapshot()
+	defer snapshot.Release()
+
+	iter := snapshot.Iterate(kv.Range{})
+	defer iter.Release()
+
+	var (
+		batch         leveldb.Batch
+		entries, size uint64
+	)
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		entries++
+		size += uint64(len(iter.Key()) + len(iter.Value()))
+
+		if entries%backupProgressInterval == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err := ldb.Write(&batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+			if progress != nil {
+				progress(entries, size)
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if err := ldb.Write(&batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress(entries, size)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 
@@ -74,6 +75,7 @@ func (ldb *LevelEngine) Snapshot() kv.Snapshot {
 		kv.GetFunc
 		kv.HasFunc
 		kv.IsNotFoundFunc
+		kv.IterateFunc
 		kv.ReleaseFunc
 	}{
 		func(key []byte) ([]byte, error) {
@@ -93,6 +95,12 @@ func (ldb *LevelEngine) Snapshot() kv.Snapshot {
 			return s.Has(key, &readOpt)
 		},
 		ldb.IsNotFound,
+		func(r kv.Range) kv.Iterator {
+			if err != nil {
+				return iterator.NewEmptyIterator(err)
+			}
+			return s.NewIterator((*util.Range)(&r), &scanOpt)
+		},
 		func() {
 			if s != nil {
 				s.Release()
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package engine
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/vechain/thor/v2/kv"
+)
+
+// MemEngine is the in-memory engine. Unlike leveldb on memory storage, it runs no background
+// routine, so it's released by GC without being closed.
+//
+// Snapshots read the live data, and bulk writes are not atomic to concurrent readers.
+type MemEngine struct {
+	db *memdb.DB
+}
+
+// NewMemEngine creates an empty in-memory engine.
+func NewMemEngine() Engine {
+	return &MemEngine{memdb.New(comparer.DefaultComparer, 0)}
+}
+
+func (m *MemEngine) Close() error {
+	return nil
+}
+
+func (m *MemEngine) IsNotFound(err error) bool {
+	return err == memdb.ErrNotFound
+}
+
+func (m *MemEngine) Get(key []byte) ([]byte, error) {
+	val, err := m.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Clone(val), nil
+}
+
+func (m *MemEngine) Has(key []byte) (bool, error) {
+	return m.db.Contains(key), nil
+}
+
+func (m *MemEngine) Put(key, val []byte) error {
+	return m.db.Put(key, val)
+}
+
+func (m *MemEngine) Delete(key []byte) error {
+	return m.db.Delete(key)
+}
+
+func (m *MemEngine) Snapshot() kv.Snapshot {
+	return &struct {
+		kv.Getter
+		kv.IterateFunc
+		kv.ReleaseFunc
+	}{
+		m,
+		m.Iterate,
+		func() {},
+	}
+}
+
+func (m *MemEngine) Bulk() kv.Bulk {
+	type op struct {
+		key, val []byte
+		del      bool
+	}
+	var ops []op
+
+	return &struct {
+		kv.PutFunc
+		kv.DeleteFunc
+		kv.EnableAutoFlushFunc
+		kv.WriteFunc
+	}{
+		func(key, val []byte) error {
+			ops = append(ops, op{key: bytes.Clone(key), val: bytes.Clone(val)})
+			return nil
+		},
+		func(key []byte) error {
+			ops = append(ops, op{key: bytes.Clone(key), del: true})
+			return nil
+		},
+		func() {},
+		func() error {
+			for _, o := range ops {
+				if o.del {
+					if err := m.db.Delete(o.key); err != nil && err != memdb.ErrNotFound {
+						return err
+					}
+				} else if err := m.db.Put(o.key, o.val); err != nil {
+					return err
+				}
+			}
+			ops = nil
+			return nil
+		},
+	}
+}
+
+func (m *MemEngine) Iterate(r kv.Range) kv.Iterator {
+	return m.db.NewIterator((*util.Range)(&r))
+}
+
+func (m *MemEngine) DeleteRange(ctx context.Context, r kv.Range) error {
+	var keys [][]byte
+	iter := m.Iterate(r)
+	for iter.Next() {
+		keys = append(keys, bytes.Clone(iter.Key()))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		// check context every 1000 times.
+		if i%1000 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		if err := m.db.Delete(key); err != nil && err != memdb.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
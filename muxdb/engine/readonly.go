@@ -0,0 +1,339 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/vechain/thor/v2/kv"
+)
+
+var errReadOnly = errors.New("engine opened read-only")
+
+// ReadOnlyLevelEngine reads the leveldb which is owned and written by another process.
+//
+// The leveldb is opened without taking the file lock, and sees writes made before it's opened.
+// Reload reopens it to see later writes. Reads in progress are not interrupted by Reload.
+type ReadOnlyLevelEngine struct {
+	path string
+	opts opt.Options
+
+	lock sync.RWMutex
+	cur  *sharedLevelDB
+}
+
+// sharedLevelDB is the leveldb instance shared by reads, which is closed once retired and released by all reads.
+type sharedLevelDB struct {
+	db   *leveldb.DB
+	refs atomic.Int32
+}
+
+func (s *sharedLevelDB) release() {
+	if s.refs.Add(-1) == 0 {
+		s.db.Close()
+	}
+}
+
+// NewReadOnlyLevelEngine opens the leveldb at path read-only.
+func NewReadOnlyLevelEngine(path string, opts opt.Options) (*ReadOnlyLevelEngine, error) {
+	opts.ReadOnly = true
+	e := &ReadOnlyLevelEngine{path: path, opts: opts}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload reopens the leveldb, to see writes made since opened or last reloaded.
+func (e *ReadOnlyLevelEngine) Reload() error {
+	var (
+		db  *leveldb.DB
+		err error
+	)
+	// files might be removed by the owner during opening, so retry a few times
+	for range 3 {
+		if db, err = leveldb.Open(&lockFreeStorage{e.path}, &e.opts); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	s := &sharedLevelDB{db: db}
+	s.refs.Store(1)
+
+	e.lock.Lock()
+	old := e.cur
+	e.cur = s
+	e.lock.Unlock()
+
+	if old != nil {
+		old.release()
+	}
+	return nil
+}
+
+// acquire returns the current leveldb, which should be released after use.
+func (e *ReadOnlyLevelEngine) acquire() *sharedLevelDB {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	e.cur.refs.Add(1)
+	return e.cur
+}
+
+// read reads through the current leveldb. Tables referred by the opened leveldb might be removed by
+// the owner's compaction, so it reloads and retries a few times on unexpected errors, as tables might be
+// removed again by compactions in progress.
+func (e *ReadOnlyLevelEngine) read(f func(db *leveldb.DB) error) (err error) {
+	for i := range 3 {
+		if i > 0 {
+			if rerr := e.Reload(); rerr != nil {
+				return err
+			}
+		}
+		s := e.acquire()
+		err = f(s.db)
+		s.release()
+		if err == nil || err == leveldb.ErrNotFound {
+			return err
+		}
+	}
+	return err
+}
+
+func (e *ReadOnlyLevelEngine) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.cur != nil {
+		e.cur.release()
+		e.cur = nil
+	}
+	return nil
+}
+
+func (e *ReadOnlyLevelEngine) IsNotFound(err error) bool {
+	return err == leveldb.ErrNotFound
+}
+
+func (e *ReadOnlyLevelEngine) Get(key []byte) (val []byte, err error) {
+	err = e.read(func(db *leveldb.DB) error {
+		val, err = db.Get(key, &readOpt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+func (e *ReadOnlyLevelEngine) Has(key []byte) (has bool, err error) {
+	err = e.read(func(db *leveldb.DB) error {
+		has, err = db.Has(key, &readOpt)
+		return err
+	})
+	return
+}
+
+func (e *ReadOnlyLevelEngine) Put(_, _ []byte) error {
+	return errReadOnly
+}
+
+func (e *ReadOnlyLevelEngine) Delete(_ []byte) error {
+	return errReadOnly
+}
+
+func (e *ReadOnlyLevelEngine) Snapshot() kv.Snapshot {
+	s := e.acquire()
+	snap, err := s.db.GetSnapshot()
+	return &struct {
+		kv.GetFunc
+		kv.HasFunc
+		kv.IsNotFoundFunc
+		kv.IterateFunc
+		kv.ReleaseFunc
+	}{
+		func(key []byte) ([]byte, error) {
+			if err != nil {
+				return nil, err
+			}
+			val, err := snap.Get(key, &readOpt)
+			if err != nil {
+				return nil, err
+			}
+			return val, nil
+		},
+		func(key []byte) (bool, error) {
+			if err != nil {
+				return false, err
+			}
+			return snap.Has(key, &readOpt)
+		},
+		e.IsNotFound,
+		func(r kv.Range) kv.Iterator {
+			if err != nil {
+				return iterator.NewEmptyIterator(err)
+			}
+			return snap.NewIterator((*util.Range)(&r), &scanOpt)
+		},
+		func() {
+			if snap != nil {
+				snap.Release()
+			}
+			s.release()
+		},
+	}
+}
+
+func (e *ReadOnlyLevelEngine) Bulk() kv.Bulk {
+	return &struct {
+		kv.PutFunc
+		kv.DeleteFunc
+		kv.EnableAutoFlushFunc
+		kv.WriteFunc
+	}{
+		func(_, _ []byte) error { return errReadOnly },
+		func(_ []byte) error { return errReadOnly },
+		func() {},
+		func() error { return errReadOnly },
+	}
+}
+
+func (e *ReadOnlyLevelEngine) Iterate(r kv.Range) kv.Iterator {
+	s := e.acquire()
+	return &releasingIterator{s.db.NewIterator((*util.Range)(&r), &scanOpt), s.release}
+}
+
+func (e *ReadOnlyLevelEngine) DeleteRange(_ context.Context, _ kv.Range) error {
+	return errReadOnly
+}
+
+// releasingIterator calls onRelease once released.
+type releasingIterator struct {
+	iterator.Iterator
+	onRelease func()
+}
+
+func (it *releasingIterator) Release() {
+	it.Iterator.Release()
+	if it.onRelease != nil {
+		it.onRelease()
+		it.onRelease = nil
+	}
+}
+
+// lockFreeStorage is the read-only leveldb file storage, which doesn't take the file lock,
+// so that the leveldb can be read while opened by the owner.
+type lockFreeStorage struct {
+	dir string
+}
+
+type nopLocker struct{}
+
+func (nopLocker) Unlock() {}
+
+func (s *lockFreeStorage) Lock() (storage.Locker, error) { return nopLocker{}, nil }
+func (s *lockFreeStorage) Log(string)                    {}
+
+func (s *lockFreeStorage) SetMeta(storage.FileDesc) error { return errReadOnly }
+
+// GetMeta returns the manifest referred by the CURRENT file, which is atomically replaced by the owner.
+func (s *lockFreeStorage) GetMeta() (storage.FileDesc, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, "CURRENT"))
+	if err != nil {
+		return storage.FileDesc{}, err
+	}
+	name := strings.TrimSuffix(string(data), "\n")
+	fd, ok := parseFileName(name)
+	if !ok || fd.Type != storage.TypeManifest || len(name) == len(data) {
+		return storage.FileDesc{}, &storage.ErrCorrupted{Err: errors.New("corrupted or incomplete CURRENT file")}
+	}
+	return fd, nil
+}
+
+func (s *lockFreeStorage) List(ft storage.FileType) ([]storage.FileDesc, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var fds []storage.FileDesc
+	for _, entry := range entries {
+		if fd, ok := parseFileName(entry.Name()); ok && fd.Type&ft != 0 {
+			fds = append(fds, fd)
+		}
+	}
+	return fds, nil
+}
+
+func (s *lockFreeStorage) Open(fd storage.FileDesc) (storage.Reader, error) {
+	f, err := os.Open(filepath.Join(s.dir, fileName(fd, false)))
+	if err != nil && os.IsNotExist(err) && fd.Type == storage.TypeTable {
+		f, err = os.Open(filepath.Join(s.dir, fileName(fd, true)))
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, os.ErrNotExist
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *lockFreeStorage) Create(storage.FileDesc) (storage.Writer, error) { return nil, errReadOnly }
+func (s *lockFreeStorage) Remove(storage.FileDesc) error                   { return errReadOnly }
+func (s *lockFreeStorage) Rename(_, _ storage.FileDesc) error              { return errReadOnly }
+func (s *lockFreeStorage) Close() error                                    { return nil }
+
+// fileName returns the file name of the fd, in the same way as the leveldb file storage.
+func fileName(fd storage.FileDesc, old bool) string {
+	switch fd.Type {
+	case storage.TypeManifest:
+		return fmt.Sprintf("MANIFEST-%06d", fd.Num)
+	case storage.TypeJournal:
+		return fmt.Sprintf("%06d.log", fd.Num)
+	case storage.TypeTable:
+		if old {
+			return fmt.Sprintf("%06d.sst", fd.Num)
+		}
+		return fmt.Sprintf("%06d.ldb", fd.Num)
+	default:
+		return fmt.Sprintf("%06d.tmp", fd.Num)
+	}
+}
+
+func parseFileName(name string) (fd storage.FileDesc, ok bool) {
+	var tail string
+	if _, err := fmt.Sscanf(name, "%d.%s", &fd.Num, &tail); err == nil {
+		switch tail {
+		case "log":
+			fd.Type = storage.TypeJournal
+		case "ldb", "sst":
+			fd.Type = storage.TypeTable
+		case "tmp":
+			fd.Type = storage.TypeTemp
+		default:
+			return fd, false
+		}
+		return fd, true
+	}
+	if n, _ := fmt.Sscanf(name, "MANIFEST-%d%s", &fd.Num, &tail); n == 1 {
+		fd.Type = storage.TypeManifest
+		return fd, true
+	}
+	return fd, false
+}
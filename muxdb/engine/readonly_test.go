@@ -0,0 +1,81 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func TestReadOnlyReadWhileCompacting(t *testing.T) {
+	path := t.TempDir()
+	owner, err := leveldb.OpenFile(path, &opt.Options{WriteBuffer: 64 * 1024})
+	require.NoError(t, err)
+	defer owner.Close()
+
+	const n = 2000
+	key := func(i int) []byte { return fmt.Appendf(nil, "key-%06d", i) }
+	val := func(i int) []byte { return bytes.Repeat(fmt.Appendf(nil, "%06d", i), 16) }
+	write := func() {
+		var batch leveldb.Batch
+		for i := range n {
+			batch.Put(key(i), val(i))
+		}
+		require.NoError(t, owner.Write(&batch, nil))
+	}
+	write()
+	require.NoError(t, owner.CompactRange(util.Range{}))
+
+	// files are not kept opened, so tables removed by the owner's compaction can't be read
+	ro, err := NewReadOnlyLevelEngine(path, opt.Options{OpenFilesCacheCapacity: -1, BlockCacheCapacity: -1})
+	require.NoError(t, err)
+	defer ro.Close()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i = (i + 7) % n {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			v, err := ro.Get(key(i))
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, val(i), v)
+		}
+	}()
+
+	// rewrite and compact, so that tables opened by the reader are replaced
+	for range 5 {
+		write()
+		require.NoError(t, owner.CompactRange(util.Range{}))
+	}
+	close(done)
+	wg.Wait()
+
+	// reads after compaction still succeed, by reloading on failure
+	for i := range n {
+		v, err := ro.Get(key(i))
+		require.NoError(t, err)
+		assert.Equal(t, val(i), v)
+	}
+	has, err := ro.Has(key(0))
+	require.NoError(t, err)
+	assert.True(t, has)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+
+	"github.com/vechain/thor/v2/kv"
+)
+
+// SpaceStats is the statistics of a key space.
+type SpaceStats struct {
+	Name  string
+	Count uint64
+	Size  uint64 // the total size of keys and values
+}
+
+// Inspect walks through the whole DB and collects statistics of key spaces.
+//
+// Trie nodes are grouped by the space and the leading character of the trie name, e.g. "hist/a".
+// Named stores are grouped by the given store names, and the rest goes to "store/?".
+// The callback progress is called periodically with the count of walked keys, if not nil.
+func (db *MuxDB) Inspect(ctx context.Context, storeNames []string, progress func(n uint64)) ([]*SpaceStats, error) {
+	// match longer names first, since names are not delimited
+	names := append([]string(nil), storeNames...)
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	var (
+		stats = make(map[string]*SpaceStats)
+		n     uint64
+	)
+	it := db.engine.Iterate(kv.Range{})
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		name := db.spaceName(key, names)
+		s := stats[name]
+		if s == nil {
+			s = &SpaceStats{Name: name}
+			stats[name] = s
+		}
+		s.Count++
+		s.Size += uint64(len(key) + len(it.Value()))
+
+		if n++; n%100000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if progress != nil {
+				progress(n)
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	list := make([]*SpaceStats, 0, len(stats))
+	for _, s := range stats {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+func (db *MuxDB) spaceName(key []byte, storeNames []string) string {
+	trieName := func(space string, ptnFactor uint32) string {
+		pos := 1
+		if ptnFactor != math.MaxUint32 {
+			pos += 4
+		}
+		if len(key) > pos {
+			return space + "/" + string(key[pos])
+		}
+		return space + "/?"
+	}
+
+	if len(key) == 0 {
+		return "?"
+	}
+	switch key[0] {
+	case trieHistSpace:
+		return trieName("hist", db.trieBackend.HistPtnFactor)
+	case trieDedupedSpace:
+		return trieName("deduped", db.trieBackend.DedupedPtnFactor)
+	case trieArchiveSpace:
+		return trieName("archive", math.MaxUint32)
+	case namedStoreSpace:
+		for _, name := range storeNames {
+			if bytes.HasPrefix(key[1:], []byte(name)) {
+				return "store/" + name
+			}
+		}
+		return "store/?"
+	}
+	return "?"
+}
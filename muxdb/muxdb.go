@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	dberrors "github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/filter"
@@ -28,6 +29,7 @@ const (
 	trieHistSpace    = byte(0) // the key space for historical trie nodes.
 	trieDedupedSpace = byte(1) // the key space for deduped trie nodes.
 	namedStoreSpace  = byte(2) // the key space for named store.
+	trieArchiveSpace = byte(3) // the key space for archived trie nodes.
 
 	metricsSampleInterval = 10 * time.Second
 )
@@ -64,6 +66,8 @@ type Options struct {
 type MuxDB struct {
 	engine      engine.Engine
 	trieBackend *backend
+	base        kv.Getter // the DB read through by an archive overlay
+	recorder    Recorder
 
 	done chan struct{}
 }
@@ -124,6 +128,53 @@ func Open(path string, options *Options) (*MuxDB, error) {
 	}, nil
 }
 
+// OpenReadOnly opens the DB at the given path for reading only, while it may be opened and written by
+// another process. Writes made by the owner since opened are not visible until Reload is called.
+func OpenReadOnly(path string, options *Options) (*MuxDB, error) {
+	engine, err := engine.NewReadOnlyLevelEngine(path, opt.Options{
+		OpenFilesCacheCapacity: options.OpenFilesCacheCapacity,
+		BlockCacheCapacity:     options.ReadCacheMB * opt.MiB,
+		Filter:                 filter.NewBloomFilter(10),
+		ErrorIfMissing:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the config must have been saved by the owner
+	var cfg config
+	data, err := kv.Bucket(string(namedStoreSpace) + propStoreName).NewStore(engine).Get([]byte(configKey))
+	if err == nil {
+		err = json.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		engine.Close()
+		return nil, errors.Wrap(err, "load config")
+	}
+
+	return &MuxDB{
+		engine: engine,
+		trieBackend: &backend{
+			Store: engine,
+			Cache: newCache(
+				options.TrieNodeCacheSizeMB,
+				uint32(options.TrieCachedNodeTTL)),
+			HistPtnFactor:    cfg.HistPtnFactor,
+			DedupedPtnFactor: cfg.DedupedPtnFactor,
+			CachedNodeTTL:    options.TrieCachedNodeTTL,
+		},
+		done: make(chan struct{}),
+	}, nil
+}
+
+// Reload makes writes made by the owner visible, if the DB is opened read-only.
+func (db *MuxDB) Reload() error {
+	if ro, ok := db.engine.(*engine.ReadOnlyLevelEngine); ok {
+		return ro.Reload()
+	}
+	return nil
+}
+
 // NewMem creates a memory-backed DB.
 func NewMem() *MuxDB {
 	storage := storage.NewMemStorage()
@@ -143,9 +194,69 @@ func NewMem() *MuxDB {
 	}
 }
 
+// NewArchiveOverlay creates a memory-backed DB on top of this DB, to rebuild pruned states.
+// Trie nodes missed in the overlay are read from the archive space of this DB, and named stores read
+// through to this DB. Writes never go to this DB.
+func (db *MuxDB) NewArchiveOverlay() *MuxDB {
+	engine := engine.NewMemEngine()
+	return &MuxDB{
+		engine: engine,
+		trieBackend: &backend{
+			Store:            engine,
+			Cache:            &dummyCache{},
+			HistPtnFactor:    db.trieBackend.HistPtnFactor,
+			DedupedPtnFactor: db.trieBackend.DedupedPtnFactor,
+			CachedNodeTTL:    db.trieBackend.CachedNodeTTL,
+			Archive:          db.engine,
+		},
+		base: db.engine,
+		done: make(chan struct{}),
+	}
+}
+
+// Recorder receives data read through a recording view.
+type Recorder interface {
+	// RecordNode is called when a trie node is read.
+	RecordNode(name string, path []byte, ver trie.Version, blob []byte)
+	// RecordEntry is called when an entry of a named store is read.
+	RecordEntry(store string, key, val []byte)
+}
+
+// NewRecordingView creates a view of this DB, which reports trie nodes and named store entries
+// read through it to the recorder. The trie node cache is bypassed, so that every node read is reported.
+// The view shares the underlying storage with this DB, and data written through it, e.g. states committed after
+// executed on the view, are not cached.
+func (db *MuxDB) NewRecordingView(rec Recorder) *MuxDB {
+	back := *db.trieBackend
+	back.Cache = &dummyCache{}
+	back.Recorder = rec
+	return &MuxDB{
+		engine:      db.engine,
+		trieBackend: &back,
+		base:        db.base,
+		recorder:    rec,
+		done:        make(chan struct{}),
+	}
+}
+
+// IsRecording returns whether the DB is a recording view. Caches above the DB should be bypassed
+// when reading a recording view.
+func (db *MuxDB) IsRecording() bool {
+	return db.recorder != nil
+}
+
+// PutTrieNode saves a trie node, e.g. from a witness, as the historical node.
+func (db *MuxDB) PutTrieNode(name string, path []byte, ver trie.Version, blob []byte) error {
+	return db.engine.Put(db.trieBackend.AppendHistNodeKey(nil, name, path, ver), blob)
+}
+
 // Close closes the DB.
 func (db *MuxDB) Close() error {
 	close(db.done)
+	if db.recorder != nil {
+		// the engine is owned by the viewed DB
+		return nil
+	}
 	return db.engine.Close()
 }
 
@@ -166,7 +277,87 @@ func (db *MuxDB) DeleteTrieHistoryNodes(ctx context.Context, startMajorVer, limi
 
 // NewStore creates named kv-store.
 func (db *MuxDB) NewStore(name string) kv.Store {
-	return kv.Bucket(string(namedStoreSpace) + name).NewStore(db.engine)
+	bucket := kv.Bucket(string(namedStoreSpace) + name)
+	store := bucket.NewStore(db.engine)
+	if db.base == nil && db.recorder == nil {
+		return store
+	}
+
+	var getter kv.Getter = store
+	if db.base != nil {
+		getter = newReadThroughGetter(getter, bucket.NewGetter(db.base))
+	}
+	if db.recorder != nil {
+		getter = newRecordingGetter(getter, func(key, val []byte) {
+			db.recorder.RecordEntry(name, key, val)
+		})
+	}
+	return &struct {
+		kv.Getter
+		kv.Putter
+		kv.SnapshotFunc
+		kv.BulkFunc
+		kv.IterateFunc
+		kv.DeleteRangeFunc
+	}{
+		getter,
+		store,
+		func() kv.Snapshot {
+			return &struct {
+				kv.Getter
+				kv.IterateFunc
+				kv.ReleaseFunc
+			}{getter, store.Iterate, func() {}}
+		},
+		store.Bulk,
+		store.Iterate, // entries of the base DB are not iterated, and entries iterated are not recorded
+		store.DeleteRange,
+	}
+}
+
+// newRecordingGetter creates a getter which reports entries got to the record func.
+func newRecordingGetter(src kv.Getter, record func(key, val []byte)) kv.Getter {
+	return &struct {
+		kv.GetFunc
+		kv.HasFunc
+		kv.IsNotFoundFunc
+	}{
+		func(key []byte) ([]byte, error) {
+			val, err := src.Get(key)
+			if err == nil {
+				record(key, val)
+			}
+			return val, err
+		},
+		src.Has,
+		src.IsNotFound,
+	}
+}
+
+// newReadThroughGetter creates a getter which reads from base if the key is missed in top.
+func newReadThroughGetter(top, base kv.Getter) kv.Getter {
+	return &struct {
+		kv.GetFunc
+		kv.HasFunc
+		kv.IsNotFoundFunc
+	}{
+		func(key []byte) ([]byte, error) {
+			val, err := top.Get(key)
+			if err != nil && top.IsNotFound(err) {
+				return base.Get(key)
+			}
+			return val, err
+		},
+		func(key []byte) (bool, error) {
+			if has, err := top.Has(key); err != nil || has {
+				return has, err
+			}
+			return base.Has(key)
+		},
+		func(err error) bool {
+			return top.IsNotFound(err) || base.IsNotFound(err)
+		},
+	}
 }
 
 // IsNotFound returns if the error indicates key not found.
@@ -254,3 +254,189 @@ func TestDeleteTrieHistory(t *testing.T) {
 	err = db.DeleteTrieHistoryNodes(context.Background(), 0, 2)
 	assert.Nil(t, err)
 }
+
+func TestInspect(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	assert.Nil(t, db.NewStore("chain.hdr").Put([]byte("k1"), []byte("v1")))
+	assert.Nil(t, db.NewStore("chain.hdr").Put([]byte("k2"), []byte("v2")))
+	assert.Nil(t, db.NewStore("chain.heads").Put([]byte("k"), nil))
+	assert.Nil(t, db.NewStore("other").Put([]byte("k"), nil))
+
+	tr := db.NewTrie("a", trie.Root{})
+	assert.Nil(t, tr.Update([]byte("key"), []byte("value"), nil))
+	assert.Nil(t, tr.Commit(trie.Version{Major: 1}, false))
+
+	stats, err := db.Inspect(context.Background(), []string{"chain.hdr", "chain.heads"}, nil)
+	assert.Nil(t, err)
+
+	got := make(map[string]uint64)
+	for _, s := range stats {
+		got[s.Name] = s.Count
+	}
+	assert.Equal(t, uint64(2), got["store/chain.hdr"])
+	assert.Equal(t, uint64(1), got["store/chain.heads"])
+	assert.Equal(t, uint64(1), got["store/?"])
+	assert.Equal(t, uint64(1), got["hist/a"])
+}
+
+func TestArchiveOverlay(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	tr := db.NewTrie("a", trie.Root{})
+	assert.Nil(t, tr.Update([]byte("k1"), []byte("v1"), nil))
+	assert.Nil(t, tr.Commit(trie.Version{Major: 1}, false))
+	root1 := trie.Root{Hash: tr.Hash(), Ver: trie.Version{Major: 1}}
+	assert.Nil(t, tr.Archive(context.Background(), 0, nil))
+
+	assert.Nil(t, tr.Update([]byte("k1"), []byte("v2"), nil))
+	assert.Nil(t, tr.Commit(trie.Version{Major: 2}, false))
+	assert.Nil(t, tr.Checkpoint(context.Background(), 0, nil))
+	assert.Nil(t, db.DeleteTrieHistoryNodes(context.Background(), 0, 3))
+
+	overlay := db.NewArchiveOverlay()
+
+	// deduped nodes are never read by the overlay
+	otr := overlay.NewTrie("a", root1)
+	val, _, err := otr.Get([]byte("k1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), val)
+
+	// nodes committed to the overlay stay in the overlay
+	assert.Nil(t, otr.Update([]byte("k2"), []byte("v3"), nil))
+	assert.Nil(t, otr.Commit(trie.Version{Major: 3}, false))
+	root3 := trie.Root{Hash: otr.Hash(), Ver: trie.Version{Major: 3}}
+
+	val, _, err = overlay.NewTrie("a", root3).Get([]byte("k1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), val)
+	_, _, err = db.NewTrie("a", root3).Get([]byte("k2"))
+	assert.Error(t, err)
+
+	// named stores read through
+	assert.Nil(t, db.NewStore("s").Put([]byte("k"), []byte("v")))
+	val, err = overlay.NewStore("s").Get([]byte("k"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v"), val)
+
+	assert.Nil(t, overlay.NewStore("s").Put([]byte("k2"), []byte("v2")))
+	has, err := db.NewStore("s").Has([]byte("k2"))
+	assert.Nil(t, err)
+	assert.False(t, has)
+	_, err = overlay.NewStore("s").Get([]byte("k3"))
+	assert.True(t, overlay.IsNotFound(err))
+
+	stats, err := db.Inspect(context.Background(), nil, nil)
+	assert.Nil(t, err)
+	var archived uint64
+	for _, s := range stats {
+		if s.Name == "archive/a" {
+			archived = s.Count
+		}
+	}
+	assert.NotZero(t, archived)
+}
+
+type testRecorder struct {
+	nodes   map[string][]byte
+	entries map[string][]byte
+}
+
+func (r *testRecorder) RecordNode(name string, path []byte, _ trie.Version, blob []byte) {
+	r.nodes[name+string(path)] = blob
+}
+
+func (r *testRecorder) RecordEntry(store string, key, val []byte) {
+	r.entries[store+string(key)] = val
+}
+
+func TestRecordingView(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	tr := db.NewTrie("a", trie.Root{})
+	for i := range 100 {
+		assert.Nil(t, tr.Update([]byte{byte(i)}, []byte{byte(i)}, nil))
+	}
+	assert.Nil(t, tr.Commit(trie.Version{Major: 1}, false))
+	root := trie.Root{Hash: tr.Hash(), Ver: trie.Version{Major: 1}}
+	assert.Nil(t, db.NewStore("s").Put([]byte("k"), []byte("v")))
+
+	rec := &testRecorder{make(map[string][]byte), make(map[string][]byte)}
+	view := db.NewRecordingView(rec)
+
+	val, _, err := view.NewTrie("a", root).Get([]byte{10})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{10}, val)
+	assert.NotEmpty(t, rec.nodes)
+	assert.Contains(t, rec.nodes, "a")
+
+	val, err = view.NewStore("s").Get([]byte("k"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v"), val)
+	_, err = view.NewStore("s").Get([]byte("x"))
+	assert.True(t, view.IsNotFound(err))
+	assert.Equal(t, map[string][]byte{"sk": []byte("v")}, rec.entries)
+
+	// closing the view leaves the DB open
+	assert.Nil(t, view.Close())
+	_, err = db.NewStore("s").Get([]byte("k"))
+	assert.Nil(t, err)
+
+	// nodes put are readable
+	mem := NewMem()
+	defer mem.Close()
+	for key, blob := range rec.nodes {
+		assert.Nil(t, mem.PutTrieNode("a", []byte(key[1:]), trie.Version{Major: 1}, blob))
+	}
+	val, _, err = mem.NewTrie("a", root).Get([]byte{10})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{10}, val)
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	opts := Options{
+		TrieNodeCacheSizeMB:        16,
+		TrieHistPartitionFactor:    1000,
+		TrieDedupedPartitionFactor: math.MaxUint32,
+	}
+
+	_, err := OpenReadOnly(path, &opts)
+	assert.Error(t, err, "absent db")
+
+	owner, err := Open(path, &opts)
+	assert.Nil(t, err)
+	defer owner.Close()
+
+	store := owner.NewStore("test")
+	assert.Nil(t, store.Put([]byte("k1"), []byte("v1")))
+
+	ro, err := OpenReadOnly(path, &opts)
+	assert.Nil(t, err)
+	defer ro.Close()
+
+	roStore := ro.NewStore("test")
+	val, err := roStore.Get([]byte("k1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), val)
+	assert.Error(t, roStore.Put([]byte("k2"), []byte("v2")))
+
+	// invisible until reloaded
+	assert.Nil(t, store.Put([]byte("k2"), []byte("v2")))
+	_, err = roStore.Get([]byte("k2"))
+	assert.True(t, ro.IsNotFound(err))
+
+	// iterators opened before reloading still work
+	it := roStore.Iterate(kv.Range{})
+	assert.Nil(t, ro.Reload())
+	assert.True(t, it.Next())
+	assert.Equal(t, []byte("v1"), it.Value())
+	it.Release()
+
+	val, err = roStore.Get([]byte("k2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v2"), val)
+}
@@ -53,8 +53,13 @@ func (t *Trie) newDatabaseReader() trie.DatabaseReader {
 				return
 			}
 			defer func() {
-				if err == nil && !t.noFillCache {
-					t.back.Cache.AddNodeBlob(&keyBuf, t.name, path, ver, blob, false)
+				if err == nil {
+					if !t.noFillCache {
+						t.back.Cache.AddNodeBlob(&keyBuf, t.name, path, ver, blob, false)
+					}
+					if t.back.Recorder != nil {
+						t.back.Recorder.RecordNode(t.name, path, ver, blob)
+					}
 				}
 			}()
 
@@ -73,6 +78,12 @@ func (t *Trie) newDatabaseReader() trie.DatabaseReader {
 				return
 			}
 
+			if t.back.Archive != nil {
+				// deduped nodes are not versioned, and may be newer than the requested one
+				keyBuf = t.back.AppendArchiveNodeKey(keyBuf[:0], t.name, path, ver)
+				return t.back.Archive.Get(keyBuf)
+			}
+
 			// then from deduped space
 			keyBuf = t.back.AppendDedupedNodeKey(keyBuf[:0], t.name, path, ver)
 			return snapshot.Get(keyBuf)
@@ -94,6 +105,12 @@ func (t *Trie) Get(key []byte) ([]byte, []byte, error) {
 	return t.trie.Get(key)
 }
 
+// Prove constructs a merkle proof for key.
+// See trie.Trie.Prove for details.
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
+	return t.trie.Prove(key)
+}
+
 // Update associates key with value in the trie. Subsequent calls to
 // Get will return value. If value has length zero, any existing value
 // is deleted from the trie and calls to Get will return nil.
@@ -160,6 +177,21 @@ func (t *Trie) SetNoFillCache(b bool) {
 
 // Checkpoint transfers standalone nodes, whose major version within [baseMajorVer, thisMajorVer], into deduped space.
 func (t *Trie) Checkpoint(ctx context.Context, baseMajorVer uint32, handleLeaf func(*trie.Leaf)) error {
+	return t.transfer(ctx, baseMajorVer, handleLeaf, t.back.AppendDedupedNodeKey)
+}
+
+// Archive copies standalone nodes, whose major version within [baseMajorVer, thisMajorVer], into archive space.
+// Unlike deduped nodes, archived nodes are versioned, so that they are never overwritten by newer ones.
+func (t *Trie) Archive(ctx context.Context, baseMajorVer uint32, handleLeaf func(*trie.Leaf)) error {
+	return t.transfer(ctx, baseMajorVer, handleLeaf, t.back.AppendArchiveNodeKey)
+}
+
+func (t *Trie) transfer(
+	ctx context.Context,
+	baseMajorVer uint32,
+	handleLeaf func(*trie.Leaf),
+	appendKey func(buf []byte, name string, path []byte, ver trie.Version) []byte,
+) error {
 	var (
 		checkContext = newContextChecker(ctx, 5000)
 		bulk         = t.back.Store.Bulk()
@@ -178,7 +210,7 @@ func (t *Trie) Checkpoint(ctx context.Context, baseMajorVer uint32, handleLeaf f
 			return err
 		}
 		if len(blob) > 0 {
-			keyBuf = t.back.AppendDedupedNodeKey(keyBuf[:0], t.name, iter.Path(), ver)
+			keyBuf = appendKey(keyBuf[:0], t.name, iter.Path(), ver)
 			if err := bulk.Put(keyBuf, blob); err != nil {
 				return err
 			}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+// BannedNode is a node which is not allowed to connect until the expiry.
+// It's rlp encode/decodable.
+type BannedNode struct {
+	ID     discover.NodeID
+	Expiry uint64 // unix timestamp in seconds
+}
+
+// thread-safe set of banned nodes.
+type bannedNodes struct {
+	m    map[discover.NodeID]uint64
+	lock sync.Mutex
+}
+
+func newBannedNodes() *bannedNodes {
+	return &bannedNodes{
+		m: make(map[discover.NodeID]uint64),
+	}
+}
+
+func (b *bannedNodes) Add(id discover.NodeID, expiry uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if expiry > b.m[id] {
+		b.m[id] = expiry
+	}
+}
+
+func (b *bannedNodes) Remove(id discover.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.m[id]; ok {
+		delete(b.m, id)
+		return true
+	}
+	return false
+}
+
+// Contains returns whether the node is banned. Expired entries are cleared on access.
+func (b *bannedNodes) Contains(id discover.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	expiry, ok := b.m[id]
+	if !ok {
+		return false
+	}
+	if expiry <= uint64(time.Now().Unix()) {
+		delete(b.m, id)
+		return false
+	}
+	return true
+}
+
+// List returns all unexpired entries.
+func (b *bannedNodes) List() []BannedNode {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := uint64(time.Now().Unix())
+	list := make([]BannedNode, 0, len(b.m))
+	for id, expiry := range b.m {
+		if expiry <= now {
+			delete(b.m, id)
+			continue
+		}
+		list = append(list, BannedNode{id, expiry})
+	}
+	return list
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBannedNodes(t *testing.T) {
+	b := newBannedNodes()
+	now := uint64(time.Now().Unix())
+
+	b.Add(discover.NodeID{1}, now+60)
+	b.Add(discover.NodeID{2}, now-1)
+	assert.True(t, b.Contains(discover.NodeID{1}))
+	assert.False(t, b.Contains(discover.NodeID{2}), "expired ban")
+	assert.False(t, b.Contains(discover.NodeID{3}))
+
+	// shorter ban doesn't override the longer one
+	b.Add(discover.NodeID{1}, now+10)
+	assert.Equal(t, []BannedNode{{discover.NodeID{1}, now + 60}}, b.List())
+
+	assert.True(t, b.Remove(discover.NodeID{1}))
+	assert.False(t, b.Remove(discover.NodeID{1}))
+	assert.Empty(t, b.List())
+}
+
+func TestBannedNodesRLP(t *testing.T) {
+	list := []BannedNode{{discover.NodeID{1}, 100}, {discover.NodeID{2}, 200}}
+	data, err := rlp.EncodeToBytes(list)
+	assert.NoError(t, err)
+
+	var decoded []BannedNode
+	assert.NoError(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, list, decoded)
+}
+
+func TestServerBanNode(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unable to generate private key: %v", err)
+	}
+
+	node := discover.MustParseNode(
+		"enode://1234cf28ab5f0255a3923ac094d0168ce884a9fa5f3998b1844986b4a2b1eac52fcccd8f2916be9b8b0f7798147ee5592ec3c83518925fac50f812577515d6ad@10.3.58.6:30303?discport=30301",
+	)
+	banned := discover.NodeID{1}
+	server := New(&Options{
+		Name:        "testNode",
+		PrivateKey:  privateKey,
+		MaxPeers:    10,
+		KnownNodes:  Nodes{node},
+		BannedNodes: []BannedNode{{banned, uint64(time.Now().Add(time.Hour).Unix())}},
+	})
+	assert.True(t, server.IsBanned(banned))
+
+	server.BanNode(node.ID, time.Hour)
+	assert.True(t, server.IsBanned(node.ID))
+	assert.False(t, server.knownNodes.Contains(node.ID))
+	assert.False(t, server.discoveredNodes.Contains(node.ID))
+	assert.Equal(t, errNodeBanned, server.TryDial(node))
+	assert.Len(t, server.BannedNodes(), 2)
+
+	assert.True(t, server.UnbanNode(node.ID))
+	assert.False(t, server.IsBanned(node.ID))
+}
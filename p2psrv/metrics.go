@@ -11,4 +11,5 @@ var (
 	metricConnectedPeers  = metrics.LazyLoadGauge("p2p_connected_peers_gauge")
 	metricDiscoveredNodes = metrics.LazyLoadCounter("p2p_discovered_node_count")
 	metricDialingNewNode  = metrics.LazyLoadGauge("p2p_dialing_new_node_count")
+	metricBannedNodes     = metrics.LazyLoadCounter("p2p_banned_node_count")
 )
@@ -63,6 +63,12 @@ func (nm *nodeMap) Remove(id discover.NodeID) *discover.Node {
 	return nil
 }
 
+func (nm *nodeMap) Get(id discover.NodeID) *discover.Node {
+	nm.lock.Lock()
+	defer nm.lock.Unlock()
+	return nm.m[id]
+}
+
 func (nm *nodeMap) Contains(id discover.NodeID) bool {
 	nm.lock.Lock()
 	defer nm.lock.Unlock()
@@ -74,3 +80,42 @@ func (nm *nodeMap) Len() int {
 	defer nm.lock.Unlock()
 	return len(nm.m)
 }
+
+func (nm *nodeMap) List() Nodes {
+	nm.lock.Lock()
+	defer nm.lock.Unlock()
+	nodes := make(Nodes, 0, len(nm.m))
+	for _, node := range nm.m {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// thread-safe node ID set.
+type nodeSet struct {
+	m    map[discover.NodeID]struct{}
+	lock sync.Mutex
+}
+
+func newNodeSet() *nodeSet {
+	return &nodeSet{
+		m: make(map[discover.NodeID]struct{}),
+	}
+}
+
+// Add adds the id into the set. It returns false if already contained.
+func (ns *nodeSet) Add(id discover.NodeID) bool {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+	if _, ok := ns.m[id]; ok {
+		return false
+	}
+	ns.m[id] = struct{}{}
+	return true
+}
+
+func (ns *nodeSet) Remove(id discover.NodeID) {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+	delete(ns.m, id)
+}
@@ -40,6 +40,20 @@ type Options struct {
 
 	KnownNodes Nodes
 
+	// StaticNodes are always kept connected, and reconnected on disconnection.
+	StaticNodes Nodes
+
+	// TrustedNodes are static nodes which are allowed to connect even above the peer limit.
+	// They are never banned.
+	TrustedNodes Nodes
+
+	// PeerRecords are connection histories of nodes connected before.
+	// These nodes are preferred for dialing.
+	PeerRecords []*PeerRecord
+
+	// BannedNodes are not allowed to connect until their bans expire.
+	BannedNodes []BannedNode
+
 	// DiscoveryNodes are used to establish connectivity
 	// with the rest of the network using the V5 discovery
 	// protocol.
@@ -0,0 +1,232 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+const (
+	maxPeerRecords     = 1024
+	peerRecordExpiry   = 7 * 24 * time.Hour // records not seen for this long are expired
+	maxPeerDialFailure = 10                 // records failed to dial consecutively for this many times are expired
+	peerRedialInterval = 30 * time.Second   // base interval before redialing a failed node, grows with failures
+)
+
+// PeerRecord records the connection history of a node.
+// It's rlp encode/decodable.
+type PeerRecord struct {
+	ID          discover.NodeID
+	IP          net.IP
+	UDP         uint16
+	TCP         uint16
+	LastSeen    uint64 // unix timestamp of the last time the node was connected
+	LastDialed  uint64 // unix timestamp of the last dial attempt
+	Latency     uint64 // duration in milliseconds to establish the last connection
+	Failures    uint32 // consecutive dial failures
+	Connections uint32 // total successful connections
+}
+
+// Node returns the discover node of the record.
+func (r *PeerRecord) Node() *discover.Node {
+	return discover.NewNode(r.ID, r.IP, r.UDP, r.TCP)
+}
+
+// better returns whether the record is preferred to the other for dialing.
+func (r *PeerRecord) better(other *PeerRecord) bool {
+	if r.Failures != other.Failures {
+		return r.Failures < other.Failures
+	}
+	if r.Latency != other.Latency {
+		return r.Latency < other.Latency
+	}
+	return r.LastSeen > other.LastSeen
+}
+
+// readyToDial returns whether the node can be dialed now, considering backoff of failures.
+func (r *PeerRecord) readyToDial(now uint64) bool {
+	backoff := uint64(peerRedialInterval/time.Second) << min(r.Failures, 7)
+	return r.LastDialed+backoff <= now
+}
+
+// peerStore thread-safe store of peer records.
+type peerStore struct {
+	m    map[discover.NodeID]*PeerRecord
+	lock sync.Mutex
+}
+
+func newPeerStore(records []*PeerRecord) *peerStore {
+	ps := &peerStore{
+		m: make(map[discover.NodeID]*PeerRecord),
+	}
+	for _, r := range records {
+		cpy := *r
+		ps.m[r.ID] = &cpy
+	}
+	ps.Expire()
+	return ps
+}
+
+// getOrCreate must be called with lock held.
+func (ps *peerStore) getOrCreate(node *discover.Node) *PeerRecord {
+	r, ok := ps.m[node.ID]
+	if !ok {
+		if len(ps.m) >= maxPeerRecords {
+			ps.evictWorst()
+		}
+		r = &PeerRecord{ID: node.ID}
+		ps.m[node.ID] = r
+	}
+	// always keep the latest endpoint
+	r.IP, r.UDP, r.TCP = node.IP, node.UDP, node.TCP
+	return r
+}
+
+// evictWorst must be called with lock held.
+func (ps *peerStore) evictWorst() {
+	var worst *PeerRecord
+	for _, r := range ps.m {
+		if worst == nil || worst.better(r) {
+			worst = r
+		}
+	}
+	if worst != nil {
+		delete(ps.m, worst.ID)
+	}
+}
+
+// MarkDialed records a dial attempt to the node, if recorded.
+func (ps *peerStore) MarkDialed(id discover.NodeID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if r, ok := ps.m[id]; ok {
+		r.LastDialed = uint64(time.Now().Unix())
+	}
+}
+
+// MarkConnected records a connection to the node, established by either side.
+// Only nodes once connected are kept in the store.
+func (ps *peerStore) MarkConnected(node *discover.Node) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	r := ps.getOrCreate(node)
+	r.LastSeen = uint64(time.Now().Unix())
+	r.Failures = 0
+	r.Connections++
+}
+
+// MarkLatency records the duration to establish the connection dialed to the node.
+func (ps *peerStore) MarkLatency(node *discover.Node, latency time.Duration) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	ps.getOrCreate(node).Latency = uint64(latency / time.Millisecond)
+}
+
+// Node returns the recorded endpoint of the node, or nil if not recorded.
+func (ps *peerStore) Node(id discover.NodeID) *discover.Node {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if r, ok := ps.m[id]; ok {
+		return r.Node()
+	}
+	return nil
+}
+
+// MarkSeen updates the last seen time of the node, if recorded.
+func (ps *peerStore) MarkSeen(id discover.NodeID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if r, ok := ps.m[id]; ok {
+		r.LastSeen = uint64(time.Now().Unix())
+	}
+}
+
+// MarkFailed records a failed dial to the node, if recorded.
+func (ps *peerStore) MarkFailed(id discover.NodeID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if r, ok := ps.m[id]; ok {
+		r.Failures++
+	}
+}
+
+// Remove removes the record of the node.
+func (ps *peerStore) Remove(id discover.NodeID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	delete(ps.m, id)
+}
+
+// Pick returns the best node which is ready to dial and satisfies the condition.
+func (ps *peerStore) Pick(cond func(id discover.NodeID) bool) *discover.Node {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	now := uint64(time.Now().Unix())
+	var best *PeerRecord
+	for _, r := range ps.m {
+		if !r.readyToDial(now) || !cond(r.ID) {
+			continue
+		}
+		if best == nil || r.better(best) {
+			best = r
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.Node()
+}
+
+// Expire removes records not seen for a long time or failed too many times.
+// It returns the count of removed records.
+func (ps *peerStore) Expire() int {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	deadline := uint64(time.Now().Add(-peerRecordExpiry).Unix())
+	n := 0
+	for id, r := range ps.m {
+		if r.Failures >= maxPeerDialFailure || r.LastSeen < deadline {
+			delete(ps.m, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Records returns copies of all records, sorted from the best to the worst.
+func (ps *peerStore) Records() []*PeerRecord {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	records := make([]*PeerRecord, 0, len(ps.m))
+	for _, r := range ps.m {
+		cpy := *r
+		records = append(records, &cpy)
+	}
+	slices.SortFunc(records, func(a, b *PeerRecord) int {
+		if a.better(b) {
+			return -1
+		}
+		if b.better(a) {
+			return 1
+		}
+		return 0
+	})
+	return records
+}
+
+func (ps *peerStore) Len() int {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	return len(ps.m)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerStoreRecords(t *testing.T) {
+	ps := newPeerStore(nil)
+	node1 := discover.NewNode(discover.NodeID{1}, net.ParseIP("10.0.0.1"), 11235, 11235)
+	node2 := discover.NewNode(discover.NodeID{2}, net.ParseIP("10.0.0.2"), 11235, 11235)
+
+	// only connected nodes are recorded
+	ps.MarkDialed(node1.ID)
+	ps.MarkFailed(node1.ID)
+	assert.Equal(t, 0, ps.Len())
+
+	ps.MarkConnected(node1)
+	ps.MarkLatency(node1, 200*time.Millisecond)
+	ps.MarkConnected(node2)
+	ps.MarkLatency(node2, 100*time.Millisecond)
+	assert.Equal(t, 2, ps.Len())
+
+	records := ps.Records()
+	assert.Equal(t, node2.ID, records[0].ID, "lower latency preferred")
+	assert.Equal(t, uint64(100), records[0].Latency)
+	assert.Equal(t, uint32(1), records[0].Connections)
+
+	ps.MarkFailed(node2.ID)
+	records = ps.Records()
+	assert.Equal(t, node1.ID, records[0].ID, "fewer failures preferred")
+	assert.Equal(t, uint32(1), records[1].Failures)
+
+	ps.Remove(node1.ID)
+	assert.Equal(t, 1, ps.Len())
+}
+
+func TestPeerStorePick(t *testing.T) {
+	ps := newPeerStore(nil)
+	node1 := discover.NewNode(discover.NodeID{1}, net.ParseIP("10.0.0.1"), 11235, 11235)
+	node2 := discover.NewNode(discover.NodeID{2}, net.ParseIP("10.0.0.2"), 11235, 11235)
+	ps.MarkConnected(node1)
+	ps.MarkLatency(node1, 100*time.Millisecond)
+	ps.MarkConnected(node2)
+	ps.MarkLatency(node2, 200*time.Millisecond)
+
+	all := func(discover.NodeID) bool { return true }
+	assert.Equal(t, node1.ID, ps.Pick(all).ID)
+	assert.Equal(t, node2.ID, ps.Pick(func(id discover.NodeID) bool { return id != node1.ID }).ID)
+
+	// failed node should wait for backoff before redial
+	ps.MarkDialed(node1.ID)
+	ps.MarkFailed(node1.ID)
+	assert.Equal(t, node2.ID, ps.Pick(all).ID)
+
+	ps.MarkDialed(node2.ID)
+	ps.MarkFailed(node2.ID)
+	assert.Nil(t, ps.Pick(all))
+}
+
+func TestPeerStoreExpire(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	ps := newPeerStore([]*PeerRecord{
+		{ID: discover.NodeID{1}, LastSeen: now},
+		{ID: discover.NodeID{2}, LastSeen: now - uint64(peerRecordExpiry/time.Second) - 1},
+		{ID: discover.NodeID{3}, LastSeen: now, Failures: maxPeerDialFailure},
+	})
+	assert.Equal(t, 1, ps.Len())
+	assert.Equal(t, discover.NodeID{1}, ps.Records()[0].ID)
+}
+
+func TestPeerStoreEvict(t *testing.T) {
+	ps := newPeerStore(nil)
+	for i := range maxPeerRecords {
+		ps.MarkConnected(discover.NewNode(discover.NodeID{byte(i), byte(i >> 8)}, nil, 0, 0))
+	}
+	worst := discover.NodeID{0xff, 0xff}
+	ps.m[worst] = &PeerRecord{ID: worst, Failures: 5, LastSeen: uint64(time.Now().Unix())}
+	assert.Equal(t, maxPeerRecords+1, ps.Len())
+
+	ps.MarkConnected(discover.NewNode(discover.NodeID{0xfe, 0xff}, nil, 0, 0))
+	assert.Equal(t, maxPeerRecords+1, ps.Len())
+	_, found := ps.m[worst]
+	assert.False(t, found)
+}
+
+func TestPeerRecordRLP(t *testing.T) {
+	records := []*PeerRecord{
+		{ID: discover.NodeID{1}, IP: net.ParseIP("10.0.0.1").To4(), UDP: 1, TCP: 2, LastSeen: 3, LastDialed: 4, Latency: 5, Failures: 6, Connections: 7},
+	}
+	data, err := rlp.EncodeToBytes(records)
+	assert.NoError(t, err)
+
+	var decoded []*PeerRecord
+	assert.NoError(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, records, decoded)
+}
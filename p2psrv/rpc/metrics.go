@@ -0,0 +1,30 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package rpc
+
+import (
+	"github.com/vechain/thor/v2/metrics"
+)
+
+var (
+	// payload bytes transferred, "raw" is the size before compression and "wire" is the size actually transferred
+	metricBytes = metrics.LazyLoadCounterVec("p2p_rpc_bytes_count", []string{"dir", "type"})
+	// wire size in percent of the raw size, of compressed messages
+	metricCompressionRatio = metrics.LazyLoadHistogramVec(
+		"p2p_rpc_compression_ratio",
+		[]string{"dir"},
+		[]int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100},
+	)
+)
+
+// observeBytes records the payload size of a message, and the compression ratio if it's compressed.
+func observeBytes(dir string, raw, wire int) {
+	metricBytes().AddWithLabel(int64(raw), map[string]string{"dir": dir, "type": "raw"})
+	metricBytes().AddWithLabel(int64(wire), map[string]string{"dir": dir, "type": "wire"})
+	if wire != raw && raw > 0 {
+		metricCompressionRatio().ObserveWithLabels(int64(wire*100/raw), map[string]string{"dir": dir})
+	}
+}
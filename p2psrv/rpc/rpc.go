@@ -6,13 +6,16 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
 	"github.com/pkg/errors"
 
 	"github.com/vechain/thor/v2/log"
@@ -20,12 +23,14 @@ import (
 
 const (
 	rpcDefaultTimeout = time.Second * 10
+	minCompressSize   = 1024 // payloads smaller than this are not worth compressing
 )
 
 var (
-	errPeerDisconnected = errors.New("peer disconnected")
-	errMsgTooLarge      = errors.New("msg too large")
-	logger              = log.WithContext("pkg", "rpc")
+	errPeerDisconnected   = errors.New("peer disconnected")
+	errMsgTooLarge        = errors.New("msg too large")
+	errUnexpectedCompress = errors.New("unexpected compressed msg")
+	logger                = log.WithContext("pkg", "rpc")
 )
 
 // HandleFunc to handle received messages from peer.
@@ -39,6 +44,7 @@ type RPC struct {
 	pendings map[uint32]*resultListener
 	lock     sync.Mutex
 	logger   log.Logger
+	compress atomic.Bool
 }
 
 // New create a new RPC instance.
@@ -60,6 +66,18 @@ func New(peer *p2p.Peer, rw p2p.MsgReadWriter) *RPC {
 	}
 }
 
+// EnableCompression enables snappy compression of message payloads.
+// It should be called before serving, and only if the peer is known to support it,
+// which is usually negotiated by the protocol version during handshake.
+func (r *RPC) EnableCompression() {
+	r.compress.Store(true)
+}
+
+// CompressionEnabled returns whether compression is enabled.
+func (r *RPC) CompressionEnabled() bool {
+	return r.compress.Load()
+}
+
 // Done returns a channel to indicates whether peer disconnected.
 func (r *RPC) Done() <-chan struct{} {
 	return r.doneCh
@@ -89,17 +107,27 @@ func (r *RPC) Serve(handleFunc HandleFunc, maxMsgSize uint32) error {
 			return err
 		}
 		var (
-			callID   uint32
-			isResult bool
+			callID uint32
+			flags  uint8
 		)
 		if err := stream.Decode(&callID); err != nil {
 			r.logger.Debug("failed to decode msg call id", "err", err)
 			return err
 		}
-		if err := stream.Decode(&isResult); err != nil {
-			r.logger.Debug("failed to decode msg dir flag", "err", err)
+		if err := stream.Decode(&flags); err != nil {
+			r.logger.Debug("failed to decode msg flags", "err", err)
 			return err
 		}
+		isResult := flags&flagResult != 0
+
+		if flags&flagCompressed != 0 {
+			if err := r.decompress(stream, &msg, maxMsgSize); err != nil {
+				r.logger.Debug("failed to decompress msg", "err", err)
+				return err
+			}
+		} else {
+			observeBytes("in", int(msg.Size), int(msg.Size))
+		}
 
 		if isResult {
 			if err := r.handleResult(callID, &msg); err != nil {
@@ -109,7 +137,7 @@ func (r *RPC) Serve(handleFunc HandleFunc, maxMsgSize uint32) error {
 		} else {
 			if err := handleFunc(&msg, func(result any) {
 				if callID != 0 {
-					p2p.Send(r.rw, msg.Code, &msgData{callID, true, result})
+					r.send(msg.Code, callID, true, result)
 				}
 				// here we skip result for Notify (callID == 0)
 			}); err != nil {
@@ -127,6 +155,56 @@ func (r *RPC) Serve(handleFunc HandleFunc, maxMsgSize uint32) error {
 	}
 }
 
+// decompress replaces the payload of msg with the decompressed one, which is the remaining element of the stream.
+func (r *RPC) decompress(stream *rlp.Stream, msg *p2p.Msg, maxMsgSize uint32) error {
+	if !r.compress.Load() {
+		return errUnexpectedCompress
+	}
+	compressed, err := stream.Bytes()
+	if err != nil {
+		return err
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return err
+	}
+	if size > int(maxMsgSize) {
+		return errMsgTooLarge
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return err
+	}
+	observeBytes("in", len(data), len(compressed))
+
+	msg.Payload = bytes.NewReader(data)
+	msg.Size = uint32(len(data))
+	return nil
+}
+
+// send encodes the payload and sends it to the peer, compressed if enabled and worthwhile.
+func (r *RPC) send(msgCode uint64, callID uint32, isResult bool, payload any) error {
+	var flags uint8
+	if isResult {
+		flags |= flagResult
+	}
+
+	data, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return err
+	}
+	if r.compress.Load() && len(data) >= minCompressSize {
+		compressed := snappy.Encode(nil, data)
+		if len(compressed) < len(data) {
+			observeBytes("out", len(data), len(compressed))
+			// the compressed payload is encoded as rlp string
+			return p2p.Send(r.rw, msgCode, &msgData{callID, flags | flagCompressed, compressed})
+		}
+	}
+	observeBytes("out", len(data), len(data))
+	return p2p.Send(r.rw, msgCode, &msgData{callID, flags, rlp.RawValue(data)})
+}
+
 func (r *RPC) handleResult(callID uint32, msg *p2p.Msg) error {
 	r.lock.Lock()
 	listener, ok := r.pendings[callID]
@@ -177,7 +255,7 @@ func (r *RPC) finalizeCall(id uint32) {
 
 // Notify notifies a message to the peer.
 func (r *RPC) Notify(_ context.Context, msgCode uint64, arg any) error {
-	return p2p.Send(r.rw, msgCode, &msgData{0, false, arg})
+	return r.send(msgCode, 0, false, arg)
 }
 
 // Call send a call to the peer and wait for result.
@@ -197,7 +275,7 @@ func (r *RPC) Call(ctx context.Context, msgCode uint64, arg any, result any) err
 	})
 	defer r.finalizeCall(id)
 
-	if err := p2p.Send(r.rw, msgCode, &msgData{id, false, arg}); err != nil {
+	if err := r.send(msgCode, id, false, arg); err != nil {
 		return err
 	}
 
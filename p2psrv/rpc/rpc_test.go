@@ -0,0 +1,144 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMsgCode = 1
+
+// bufferedRW buffers the payload of read msg, as the rlpx transport does.
+type bufferedRW struct {
+	p2p.MsgReadWriter
+}
+
+func (rw bufferedRW) ReadMsg() (p2p.Msg, error) {
+	msg, err := rw.MsgReadWriter.ReadMsg()
+	if err != nil {
+		return msg, err
+	}
+	data, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return msg, err
+	}
+	msg.Payload = bytes.NewReader(data)
+	return msg, nil
+}
+
+func newRPC(id byte, rw p2p.MsgReadWriter, compress bool) *RPC {
+	rpc := New(p2p.NewPeer(discover.NodeID{id}, "test", nil), bufferedRW{rw})
+	if compress {
+		rpc.EnableCompression()
+	}
+	return rpc
+}
+
+func newPair(t *testing.T, compress1, compress2 bool) (*RPC, *RPC) {
+	rw1, rw2 := p2p.MsgPipe()
+	t.Cleanup(func() {
+		rw1.Close()
+		rw2.Close()
+	})
+
+	rpc1 := newRPC(1, rw1, compress1)
+	rpc2 := newRPC(2, rw2, compress2)
+
+	// echo the received payload
+	echo := func(msg *p2p.Msg, write func(any)) error {
+		var data []byte
+		if err := msg.Decode(&data); err != nil {
+			return err
+		}
+		write(data)
+		return nil
+	}
+	go rpc1.Serve(echo, 1024*1024)
+	go rpc2.Serve(echo, 1024*1024)
+	return rpc1, rpc2
+}
+
+func TestCallCompressed(t *testing.T) {
+	rpc1, rpc2 := newPair(t, true, true)
+	assert.True(t, rpc1.CompressionEnabled())
+
+	for _, size := range []int{10, minCompressSize, 100 * 1024} {
+		arg := bytes.Repeat([]byte{0xab}, size)
+		var result []byte
+		require.NoError(t, rpc1.Call(context.Background(), testMsgCode, arg, &result))
+		assert.Equal(t, arg, result)
+
+		require.NoError(t, rpc2.Call(context.Background(), testMsgCode, arg, &result))
+		assert.Equal(t, arg, result)
+	}
+}
+
+func TestCallUncompressed(t *testing.T) {
+	rpc1, _ := newPair(t, false, false)
+	assert.False(t, rpc1.CompressionEnabled())
+
+	arg := bytes.Repeat([]byte{0xab}, 100*1024)
+	var result []byte
+	require.NoError(t, rpc1.Call(context.Background(), testMsgCode, arg, &result))
+	assert.Equal(t, arg, result)
+}
+
+func TestUnexpectedCompressedMsg(t *testing.T) {
+	rw1, rw2 := p2p.MsgPipe()
+	defer rw1.Close()
+
+	rpc1 := newRPC(1, rw1, true)
+	rpc2 := newRPC(2, rw2, false)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rpc2.Serve(func(*p2p.Msg, func(any)) error { return nil }, 1024*1024)
+	}()
+
+	require.NoError(t, rpc1.Notify(context.Background(), testMsgCode, bytes.Repeat([]byte{0xab}, 100*1024)))
+	assert.Equal(t, errUnexpectedCompress, <-errCh)
+}
+
+func TestCompressedMsgTooLarge(t *testing.T) {
+	rw1, rw2 := p2p.MsgPipe()
+	defer rw1.Close()
+
+	rpc1 := newRPC(1, rw1, true)
+	rpc2 := newRPC(2, rw2, true)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rpc2.Serve(func(*p2p.Msg, func(any)) error { return nil }, 10*1024)
+	}()
+
+	// highly compressible payload which is small on wire but exceeds the limit once decompressed
+	require.NoError(t, rpc1.Notify(context.Background(), testMsgCode, bytes.Repeat([]byte{0xab}, 100*1024)))
+	assert.Equal(t, errMsgTooLarge, <-errCh)
+}
+
+func TestFlagsCompatible(t *testing.T) {
+	// peers not aware of flags decode it as bool
+	legacy, err := rlp.EncodeToBytes(true)
+	require.NoError(t, err)
+	flags, err := rlp.EncodeToBytes(flagResult)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, flags)
+
+	legacy, err = rlp.EncodeToBytes(false)
+	require.NoError(t, err)
+	flags, err = rlp.EncodeToBytes(uint8(0))
+	require.NoError(t, err)
+	assert.Equal(t, legacy, flags)
+}
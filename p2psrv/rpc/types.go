@@ -7,10 +7,17 @@ package rpc
 
 import "github.com/ethereum/go-ethereum/p2p"
 
+// msg flags, encoded in place of the former bool field of result indicator,
+// so that the flagResult alone is compatible with peers not aware of flags.
+const (
+	flagResult     uint8 = 1 << iota // the msg is the result of a call
+	flagCompressed                   // the payload is snappy compressed rlp data
+)
+
 type msgData struct {
-	ID       uint32
-	IsResult bool
-	Payload  any
+	ID      uint32
+	Flags   uint8
+	Payload any
 }
 
 type resultListener struct {
@@ -11,6 +11,7 @@ import (
 	"math"
 	"net"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/mclock"
@@ -24,7 +25,10 @@ import (
 	"github.com/vechain/thor/v2/log"
 )
 
-var logger = log.WithContext("pkg", "p2psrv")
+var (
+	logger        = log.WithContext("pkg", "p2psrv")
+	errNodeBanned = errors.New("node banned")
+)
 
 // Server p2p server wraps ethereum's p2p.Server, and handles discovery v5 stuff.
 type Server struct {
@@ -37,6 +41,13 @@ type Server struct {
 	knownNodes      *cache.PrioCache
 	discoveredNodes *cache.RandCache
 	dialingNodes    *nodeMap
+	bannedNodes     *bannedNodes
+	peerStore       *peerStore
+	staticNodes     *nodeMap
+	trustedNodes    *nodeMap
+	runningPeers    *nodeSet
+	started         bool
+	lock            sync.Mutex // guards started and the underlying server's static/trusted nodes
 }
 
 // New create a p2p server.
@@ -47,6 +58,19 @@ func New(opts *Options) *Server {
 		knownNodes.Set(node.ID, node, 0)
 		discoveredNodes.Set(node.ID, node)
 	}
+	bannedNodes := newBannedNodes()
+	for _, banned := range opts.BannedNodes {
+		bannedNodes.Add(banned.ID, banned.Expiry)
+	}
+	staticNodes := newNodeMap()
+	for _, node := range opts.StaticNodes {
+		staticNodes.Add(node)
+	}
+	trustedNodes := newNodeMap()
+	for _, node := range opts.TrustedNodes {
+		trustedNodes.Add(node)
+		bannedNodes.Remove(node.ID)
+	}
 
 	return &Server{
 		opts: opts,
@@ -68,6 +92,11 @@ func New(opts *Options) *Server {
 		knownNodes:      knownNodes,
 		discoveredNodes: discoveredNodes,
 		dialingNodes:    newNodeMap(),
+		bannedNodes:     bannedNodes,
+		peerStore:       newPeerStore(opts.PeerRecords),
+		staticNodes:     staticNodes,
+		trustedNodes:    trustedNodes,
+		runningPeers:    newNodeSet(),
 	}
 }
 
@@ -89,13 +118,27 @@ func (s *Server) Start(protocols []*p2p.Protocol, topic discv5.Topic) error {
 			}
 			log := logger.New("peer", peer, "dir", dir)
 
+			if s.bannedNodes.Contains(peer.ID()) {
+				log.Debug("reject banned peer")
+				s.dialingNodes.Remove(peer.ID())
+				return errNodeBanned
+			}
+
+			// a peer may run several protocols, only the first one is accounted
+			if !s.runningPeers.Add(peer.ID()) {
+				return run(peer, rw)
+			}
+
 			log.Trace("peer connected")
 			metricConnectedPeers().Add(1)
+			s.markConnected(peer)
 
 			startTime := mclock.Now()
 			defer func() {
+				s.runningPeers.Remove(peer.ID())
 				log.Debug("peer disconnected", "reason", err)
-				if node := s.dialingNodes.Remove(peer.ID()); node != nil {
+				s.peerStore.MarkSeen(peer.ID())
+				if node := s.dialingNodes.Remove(peer.ID()); node != nil && !s.bannedNodes.Contains(peer.ID()) {
 					// we assume that good peer has longer connection duration.
 					s.knownNodes.Set(peer.ID(), node, float64(mclock.Now()-startTime))
 				}
@@ -106,7 +149,14 @@ func (s *Server) Start(protocols []*p2p.Protocol, topic discv5.Topic) error {
 		s.srv.Protocols = append(s.srv.Protocols, cpy)
 	}
 
-	if err := s.srv.Start(); err != nil {
+	s.lock.Lock()
+	// trusted nodes are also kept connected
+	s.srv.StaticNodes = append(s.staticNodes.List(), s.trustedNodes.List()...)
+	s.srv.TrustedNodes = s.trustedNodes.List()
+	err := s.srv.Start()
+	s.started = err == nil
+	s.lock.Unlock()
+	if err != nil {
 		return err
 	}
 	if !s.opts.NoDiscovery {
@@ -152,16 +202,123 @@ func (s *Server) KnownNodes() Nodes {
 	return nodes
 }
 
+// BanNode bans the node for the given duration.
+// Connection to the banned node should be closed by the caller.
+// Trusted nodes are never banned.
+func (s *Server) BanNode(id discover.NodeID, duration time.Duration) {
+	if s.trustedNodes.Contains(id) {
+		return
+	}
+	s.bannedNodes.Add(id, uint64(time.Now().Add(duration).Unix()))
+	s.knownNodes.Remove(id)
+	s.discoveredNodes.Remove(id)
+	s.peerStore.Remove(id)
+	metricBannedNodes().Add(1)
+	logger.Debug("node banned", "id", id, "duration", duration)
+}
+
+// UnbanNode lifts the ban of the node.
+func (s *Server) UnbanNode(id discover.NodeID) bool {
+	return s.bannedNodes.Remove(id)
+}
+
+// IsBanned returns whether the node is banned.
+func (s *Server) IsBanned(id discover.NodeID) bool {
+	return s.bannedNodes.Contains(id)
+}
+
+// BannedNodes returns nodes currently banned, which can be saved to restore bans next time.
+func (s *Server) BannedNodes() []BannedNode {
+	return s.bannedNodes.List()
+}
+
+// PeerRecords returns connection histories of nodes, sorted by dialing preference.
+// They can be saved to restore the peer store next time.
+func (s *Server) PeerRecords() []*PeerRecord {
+	return s.peerStore.Records()
+}
+
 // AddStatic connects to the given node and maintains the connection until the
 // server is shut down. If the connection fails for any reason, the server will
 // attempt to reconnect the peer.
 func (s *Server) AddStatic(node *discover.Node) {
-	s.srv.AddPeer(node)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.staticNodes.Add(node)
+	if s.started {
+		s.srv.AddPeer(node)
+	}
 }
 
 // RemoveStatic disconnects from the given node
 func (s *Server) RemoveStatic(node *discover.Node) {
-	s.srv.RemovePeer(node)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.staticNodes.Remove(node.ID)
+	if s.started && !s.trustedNodes.Contains(node.ID) {
+		s.srv.RemovePeer(node)
+	}
+}
+
+// StaticNodes returns static nodes.
+func (s *Server) StaticNodes() Nodes {
+	return s.staticNodes.List()
+}
+
+// AddTrusted adds the given node as trusted. It's kept connected like static nodes,
+// and allowed to connect even when peer slots are full. Its ban is lifted if banned.
+func (s *Server) AddTrusted(node *discover.Node) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.trustedNodes.Add(node)
+	s.bannedNodes.Remove(node.ID)
+	if s.started {
+		s.srv.AddTrustedPeer(node)
+		s.srv.AddPeer(node)
+	}
+}
+
+// RemoveTrusted removes the given node from trusted nodes.
+// It will be disconnected unless it's also a static node.
+func (s *Server) RemoveTrusted(node *discover.Node) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.trustedNodes.Remove(node.ID)
+	if s.started {
+		s.srv.RemoveTrustedPeer(node)
+		if !s.staticNodes.Contains(node.ID) {
+			s.srv.RemovePeer(node)
+		}
+	}
+}
+
+// TrustedNodes returns trusted nodes.
+func (s *Server) TrustedNodes() Nodes {
+	return s.trustedNodes.List()
+}
+
+// Disconnect disconnects the peer with the given ID.
+// It returns false if the peer is not connected.
+// Static or trusted peers will be reconnected later.
+func (s *Server) Disconnect(id discover.NodeID) bool {
+	s.lock.Lock()
+	started := s.started
+	s.lock.Unlock()
+	if !started {
+		return false
+	}
+
+	for _, peer := range s.srv.Peers() {
+		if peer.ID() == id {
+			peer.Disconnect(p2p.DiscRequested)
+			return true
+		}
+	}
+	return false
 }
 
 // NodeInfo gathers and returns a collection of metadata known about the host.
@@ -179,6 +336,9 @@ func (s *Server) TryDial(node *discover.Node) error {
 	if s.dialingNodes.Contains(node.ID) {
 		return nil
 	}
+	if s.bannedNodes.Contains(node.ID) {
+		return errNodeBanned
+	}
 
 	// Record the manual dialing node for future dial ratio calculation.
 	// But the dial ratio limit is not applied to manual dialing.
@@ -289,6 +449,10 @@ func (s *Server) dialLoop() {
 	const fastDialDur = 500 * time.Millisecond
 	const nonFastDialDur = 2 * time.Second
 	const stableDialDur = 10 * time.Second
+	const expirePeersDur = time.Hour
+
+	expireTicker := time.NewTicker(expirePeersDur)
+	defer expireTicker.Stop()
 
 	dialCount := 0
 	for {
@@ -313,19 +477,30 @@ func (s *Server) dialLoop() {
 				continue
 			}
 
-			entry := s.discoveredNodes.Pick()
-			if entry == nil {
-				continue
-			}
+			// prefer nodes connected before, then randomly pick a discovered one
+			node := s.peerStore.Pick(func(id discover.NodeID) bool {
+				return !s.dialingNodes.Contains(id) && !s.bannedNodes.Contains(id)
+			})
+			if node == nil {
+				entry := s.discoveredNodes.Pick()
+				if entry == nil {
+					continue
+				}
 
-			node := entry.Value.(*discover.Node)
-			if s.dialingNodes.Contains(node.ID) {
-				continue
+				node = entry.Value.(*discover.Node)
+				if s.dialingNodes.Contains(node.ID) {
+					continue
+				}
+				if s.bannedNodes.Contains(node.ID) {
+					s.discoveredNodes.Remove(node.ID)
+					continue
+				}
 			}
 
 			log := logger.New("node", node)
 			log.Debug("try to dial node")
 			s.dialingNodes.Add(node)
+			s.peerStore.MarkDialed(node.ID)
 			// don't use goes.Go, since the dial process can't be interrupted
 			go func() {
 				if err := s.tryDial(node); err != nil {
@@ -337,6 +512,10 @@ func (s *Server) dialLoop() {
 			}()
 
 			dialCount++
+		case <-expireTicker.C:
+			if n := s.peerStore.Expire(); n > 0 {
+				logger.Debug("expired peer records", "count", n)
+			}
 		case <-s.done:
 			return
 		}
@@ -347,11 +526,55 @@ func (s *Server) tryDial(node *discover.Node) error {
 	metricDialingNewNode().Add(1)
 	defer metricDialingNewNode().Add(-1)
 
-	conn, err := s.srv.Dialer.Dial(node)
+	startTime := mclock.Now()
+	err := func() error {
+		conn, err := s.srv.Dialer.Dial(node)
+		if err != nil {
+			return err
+		}
+		return s.srv.SetupConn(conn, 1, node)
+	}()
 	if err != nil {
+		s.peerStore.MarkFailed(node.ID)
 		return err
 	}
-	return s.srv.SetupConn(conn, 1, node)
+	// the connection itself is recorded once the peer runs
+	if !s.bannedNodes.Contains(node.ID) {
+		s.peerStore.MarkLatency(node, time.Duration(mclock.Now()-startTime))
+	}
+	return nil
+}
+
+// markConnected records the peer into the peer store, whether it's dialed by the dial loop, by the underlying server
+// as a static node, or inbound.
+func (s *Server) markConnected(peer *p2p.Peer) {
+	id := peer.ID()
+	node := s.dialingNodes.Get(id)
+	if node == nil {
+		node = s.staticNodes.Get(id)
+	}
+	if node == nil {
+		node = s.trustedNodes.Get(id)
+	}
+	if node == nil {
+		node = s.peerStore.Node(id)
+	}
+	if node == nil {
+		if val, _, ok := s.knownNodes.Get(id); ok {
+			node = val.(*discover.Node)
+		}
+	}
+	if node == nil && !peer.Inbound() {
+		// the remote address of an outbound connection is the listening endpoint
+		if addr, ok := peer.RemoteAddr().(*net.TCPAddr); ok {
+			node = discover.NewNode(id, addr.IP, uint16(addr.Port), uint16(addr.Port))
+		}
+	}
+	if node == nil {
+		// the listening endpoint of the inbound peer is unknown, so it can't be redialed
+		return
+	}
+	s.peerStore.MarkConnected(node)
 }
 
 func (s *Server) fetchBootstrap() {
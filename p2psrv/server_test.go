@@ -7,10 +7,13 @@ package p2psrv
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewServer(t *testing.T) {
@@ -79,3 +82,88 @@ func TestNewServerConnectOnly(t *testing.T) {
 	assert.True(t, server.discoveredNodes.Contains(knownNode.ID))
 	assert.True(t, server.knownNodes.Contains(knownNode.ID))
 }
+
+func TestServerStaticAndTrustedNodes(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unable to generate private key: %v", err)
+	}
+
+	static := dummyNode(1)
+	trusted := dummyNode(2)
+	server := New(&Options{
+		Name:         "testNode",
+		PrivateKey:   privateKey,
+		MaxPeers:     10,
+		StaticNodes:  Nodes{static},
+		TrustedNodes: Nodes{trusted},
+		BannedNodes:  []BannedNode{{trusted.ID, uint64(time.Now().Add(time.Hour).Unix())}},
+	})
+	assert.Equal(t, Nodes{static}, server.StaticNodes())
+	assert.Equal(t, Nodes{trusted}, server.TrustedNodes())
+	assert.False(t, server.IsBanned(trusted.ID), "trusted node should be unbanned")
+
+	// trusted nodes are never banned
+	server.BanNode(trusted.ID, time.Hour)
+	assert.False(t, server.IsBanned(trusted.ID))
+
+	// changes before start only update the sets
+	node := dummyNode(3)
+	server.BanNode(node.ID, time.Hour)
+	server.AddTrusted(node)
+	assert.False(t, server.IsBanned(node.ID))
+	assert.Len(t, server.TrustedNodes(), 2)
+	server.RemoveTrusted(node)
+	assert.Equal(t, Nodes{trusted}, server.TrustedNodes())
+
+	server.AddStatic(node)
+	assert.Len(t, server.StaticNodes(), 2)
+	server.RemoveStatic(node)
+	assert.Equal(t, Nodes{static}, server.StaticNodes())
+
+	assert.False(t, server.Disconnect(static.ID))
+}
+
+func TestServerRecordsConnectedPeers(t *testing.T) {
+	newServer := func() *Server {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		server := New(&Options{
+			Name:        "testNode",
+			PrivateKey:  privateKey,
+			MaxPeers:    10,
+			ListenAddr:  "127.0.0.1:0",
+			NoDiscovery: true,
+		})
+		require.NoError(t, server.Start([]*p2p.Protocol{{
+			Name:    "test",
+			Version: 1,
+			Length:  1,
+			Run: func(_ *p2p.Peer, rw p2p.MsgReadWriter) error {
+				for {
+					if _, err := rw.ReadMsg(); err != nil {
+						return err
+					}
+				}
+			},
+		}}, "test"))
+		return server
+	}
+
+	server1 := newServer()
+	defer server1.Stop()
+	server2 := newServer()
+	defer server2.Stop()
+
+	// dialed by the underlying server rather than the dial loop
+	server1.AddStatic(server2.Self())
+	assert.Eventually(t, func() bool { return server1.peerStore.Len() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	record := server1.PeerRecords()[0]
+	assert.Equal(t, server2.Self().ID, record.ID)
+	assert.Equal(t, server2.Self().TCP, record.TCP)
+	assert.Equal(t, uint32(1), record.Connections)
+
+	// the inbound peer is not recorded, as its listening endpoint is unknown
+	assert.Equal(t, 0, server2.peerStore.Len())
+}
@@ -207,6 +207,45 @@ func (f *Flow) Adopt(t *tx.Transaction) error {
 	return nil
 }
 
+// AdoptBundle try to adopt the given txs together and in order, or none of them.
+// If any tx fails to be adopted or is reverted, the state and txs adopted so far are rolled back.
+// A reverted member makes the bundle not adoptable forever, as retrying it in later blocks is likely to revert again.
+func (f *Flow) AdoptBundle(txs tx.Transactions) error {
+	var totalGas uint64
+	for _, t := range txs {
+		totalGas += t.Gas()
+	}
+	switch {
+	case totalGas > f.runtime.Context().GasLimit:
+		return badTxError{"bundle gas exceeds block gas limit"}
+	case f.gasUsed+totalGas > f.runtime.Context().GasLimit:
+		return errTxNotAdoptableNow
+	}
+
+	var (
+		checkpoint = f.runtime.State().NewCheckpoint()
+		gasUsed    = f.gasUsed
+		n          = len(f.txs)
+	)
+	for i, t := range txs {
+		err := f.Adopt(t)
+		if err == nil && f.processedTxs[t.ID()] {
+			err = fmt.Errorf("%w: reverted", errTxNotAdoptableForever)
+		}
+		if err != nil {
+			f.runtime.State().RevertTo(checkpoint)
+			for _, adopted := range f.txs[n:] {
+				delete(f.processedTxs, adopted.ID())
+			}
+			f.txs = f.txs[:n]
+			f.receipts = f.receipts[:n]
+			f.gasUsed = gasUsed
+			return fmt.Errorf("bundle member %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // Pack build and sign the new block.
 func (f *Flow) Pack(privateKey *ecdsa.PrivateKey, newBlockConflicts uint32, shouldVote bool) (*block.Block, *state.Stage, tx.Receipts, error) {
 	if f.packer.nodeMaster != thor.Address(crypto.PubkeyToAddress(privateKey.PublicKey)) {
@@ -25,6 +25,7 @@ import (
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -113,6 +114,61 @@ func TestAdopt(t *testing.T) {
 	flow.TotalScore()
 }
 
+func TestAdoptBundle(t *testing.T) {
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	g := genesis.NewDevnet()
+
+	b, _, _, _ := g.Build(stater)
+	repo, _ := chain.NewRepository(db, b)
+
+	chainTag := repo.ChainTag()
+	addr := thor.BytesToAddress([]byte("to"))
+	clause := tx.NewClause(&addr).WithValue(big.NewInt(10000))
+	// transfers more than the balance, to be reverted
+	revertedClause := tx.NewClause(&addr).WithValue(new(big.Int).Lsh(big.NewInt(1), 200))
+
+	pkr := packer.New(repo, stater, genesis.DevAccounts()[0].Address, &genesis.DevAccounts()[0].Address, &thor.NoFork, 0)
+	sum, err := repo.GetBlockSummary(b.Header().ID())
+	assert.NoError(t, err)
+	flow, _, err := pkr.Schedule(sum, uint64(time.Now().Unix()))
+	assert.NoError(t, err)
+
+	tx1 := createTx(tx.TypeLegacy, chainTag, 1, 10, 21000, 1, nil, clause, tx.NewBlockRef(0))
+	tx2 := createTx(tx.TypeLegacy, chainTag, 1, 10, 21000, 2, nil, revertedClause, tx.NewBlockRef(0))
+	tx3 := createTx(tx.TypeLegacy, chainTag, 1, 10, 21000, 3, (*thor.Bytes32)(tx1.ID().Bytes()), clause, tx.NewBlockRef(0))
+	tooMuchGas := createTx(tx.TypeLegacy, chainTag, 1, 10, math.MaxUint64/2, 4, nil, clause, tx.NewBlockRef(0))
+
+	// rolled back as a member reverted
+	err = flow.AdoptBundle(tx.Transactions{tx1, tx2})
+	assert.False(t, packer.IsTxNotAdoptableNow(err))
+	assert.Equal(t, "bundle member 1: tx not adoptable forever: reverted", err.Error())
+
+	err = flow.AdoptBundle(tx.Transactions{tx1, tooMuchGas})
+	assert.True(t, packer.IsBadTx(err))
+
+	// the dependency inside the bundle is satisfied by the former member
+	assert.NoError(t, flow.AdoptBundle(tx.Transactions{tx1, tx3}))
+
+	err = flow.AdoptBundle(tx.Transactions{tx2, tx3})
+	assert.Equal(t, "bundle member 0: tx not adoptable forever: reverted", err.Error())
+	err = flow.AdoptBundle(tx.Transactions{tx3})
+	assert.Equal(t, "bundle member 0: known tx", err.Error())
+
+	blk, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Transactions{tx1, tx3}, blk.Transactions())
+	assert.Len(t, receipts, 2)
+	assert.Equal(t, receipts[0].GasUsed+receipts[1].GasUsed, blk.Header().GasUsed())
+
+	root, err := stage.Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, blk.Header().StateRoot(), root)
+	balance, err := stater.NewState(trie.Root{Hash: root, Ver: trie.Version{Major: blk.Header().Number()}}).GetBalance(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(20000), balance)
+}
+
 func TestAdoptTypedTxs(t *testing.T) {
 	fc := &thor.SoloFork
 	fc.HAYABUSA = math.MaxUint32
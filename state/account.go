@@ -94,6 +94,12 @@ func loadAccount(trie *muxdb.Trie, addr thor.Address) (*Account, *AccountMetadat
 	if err != nil {
 		return nil, nil, err
 	}
+	return decodeAccount(data, meta)
+}
+
+// decodeAccount decodes an account object and its metadata.
+// It returns empty account if data is empty.
+func decodeAccount(data, meta []byte) (*Account, *AccountMetadata, error) {
 	if len(data) == 0 {
 		return emptyAccount(), &AccountMetadata{}, nil
 	}
@@ -114,23 +120,29 @@ func loadAccount(trie *muxdb.Trie, addr thor.Address) (*Account, *AccountMetadat
 // saveAccount save account into trie at given address.
 // If the given account is empty, the value for given address is deleted.
 func saveAccount(trie *muxdb.Trie, addr thor.Address, a *Account, am *AccountMetadata) error {
-	if a.IsEmpty() {
-		// delete if account is empty
-		return trie.Update(secureKey(addr[:]), nil, nil)
-	}
-
-	data, err := rlp.EncodeToBytes(a)
+	data, mdata, err := encodeAccount(a, am)
 	if err != nil {
 		return err
 	}
+	return trie.Update(secureKey(addr[:]), data, mdata)
+}
+
+// encodeAccount encodes an account object and its metadata.
+// Nil data is returned if the account is empty.
+func encodeAccount(a *Account, am *AccountMetadata) (data []byte, mdata []byte, err error) {
+	if a.IsEmpty() {
+		return nil, nil, nil
+	}
 
-	var mdata []byte
+	if data, err = rlp.EncodeToBytes(a); err != nil {
+		return nil, nil, err
+	}
 	if len(a.StorageRoot) > 0 { // discard metadata if storage root is empty
 		if mdata, err = rlp.EncodeToBytes(am); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
-	return trie.Update(secureKey(addr[:]), data, mdata)
+	return data, mdata, nil
 }
 
 // loadStorage load storage data for given key.
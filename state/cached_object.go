@@ -10,6 +10,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state/snapshot"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/trie"
 )
@@ -22,6 +23,7 @@ type cachedObject struct {
 	addr thor.Address
 	data Account
 	meta AccountMetadata
+	snap snapshot.Snapshot
 
 	cache struct {
 		code        []byte
@@ -30,8 +32,8 @@ type cachedObject struct {
 	}
 }
 
-func newCachedObject(db *muxdb.MuxDB, addr thor.Address, data *Account, meta *AccountMetadata) *cachedObject {
-	return &cachedObject{db: db, addr: addr, data: *data, meta: *meta}
+func newCachedObject(db *muxdb.MuxDB, addr thor.Address, data *Account, meta *AccountMetadata, snap snapshot.Snapshot) *cachedObject {
+	return &cachedObject{db: db, addr: addr, data: *data, meta: *meta, snap: snap}
 }
 
 func (co *cachedObject) getOrCreateStorageTrie() *muxdb.Trie {
@@ -71,13 +73,11 @@ func (co *cachedObject) GetStorage(key thor.Bytes32) (rlp.RawValue, error) {
 	}
 	// not found in cache
 
-	trie := co.getOrCreateStorageTrie()
-	if trie == nil {
+	if len(co.data.StorageRoot) == 0 {
 		return nil, nil
 	}
 
-	// load from trie
-	v, err := loadStorage(trie, key)
+	v, err := co.loadStorage(key)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +86,25 @@ func (co *cachedObject) GetStorage(key thor.Bytes32) (rlp.RawValue, error) {
 	return v, nil
 }
 
+// loadStorage loads storage value from the snapshot if available, otherwise from the trie.
+func (co *cachedObject) loadStorage(key thor.Bytes32) (rlp.RawValue, error) {
+	if co.snap != nil && len(co.meta.StorageID) > 0 {
+		v, err := co.snap.Storage(co.meta.StorageID, thor.Blake2b(key[:]))
+		if err == nil {
+			metricSnapshotReads().AddWithLabel(1, map[string]string{"type": "storage", "result": "hit"})
+			return v, nil
+		}
+		if !isSnapshotUnavailable(err) {
+			return nil, err
+		}
+		if err == snapshot.ErrStale {
+			co.snap = nil
+		}
+		metricSnapshotReads().AddWithLabel(1, map[string]string{"type": "storage", "result": "fallback"})
+	}
+	return loadStorage(co.getOrCreateStorageTrie(), key)
+}
+
 // GetCode returns the code of the account.
 func (co *cachedObject) GetCode() ([]byte, error) {
 	cache := &co.cache
@@ -95,12 +114,14 @@ func (co *cachedObject) GetCode() ([]byte, error) {
 	}
 
 	if len(co.data.CodeHash) > 0 {
-		// do have code
-		if code, has := codeCache.Get(string(co.data.CodeHash)); has {
-			return code.([]byte), nil
+		// do have code, and reads must reach the recording DB
+		if !co.db.IsRecording() {
+			if code, has := codeCache.Get(string(co.data.CodeHash)); has {
+				return code.([]byte), nil
+			}
 		}
 
-		code, err := co.db.NewStore(codeStoreName).Get(co.data.CodeHash)
+		code, err := co.db.NewStore(CodeStoreName).Get(co.data.CodeHash)
 		if err != nil {
 			return nil, err
 		}
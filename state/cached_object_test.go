@@ -46,7 +46,7 @@ func TestCachedObject(t *testing.T) {
 	rand.Read(code)
 
 	codeHash := thor.Keccak256(code).Bytes()
-	db.NewStore(codeStoreName).Put(codeHash, code)
+	db.NewStore(CodeStoreName).Put(codeHash, code)
 
 	account := Account{
 		Balance:     &big.Int{},
@@ -54,7 +54,7 @@ func TestCachedObject(t *testing.T) {
 		StorageRoot: storageRoot[:],
 	}
 
-	obj := newCachedObject(db, addr, &account, &AccountMetadata{StorageID: []byte("sid")})
+	obj := newCachedObject(db, addr, &account, &AccountMetadata{StorageID: []byte("sid")}, nil)
 
 	assert.Equal(t,
 		M(code, nil),
@@ -7,4 +7,7 @@ package state
 
 import "github.com/vechain/thor/v2/metrics"
 
-var metricAccountChanges = metrics.LazyLoadCounter("account_state_changes_count")
+var (
+	metricAccountChanges = metrics.LazyLoadCounter("account_state_changes_count")
+	metricSnapshotReads  = metrics.LazyLoadCounterVec("state_snapshot_read_count", []string{"type", "result"})
+)
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// ProveAccount constructs the merkle proof of the account at the given address,
+// against the state root. Changes not staged are not reflected.
+func (s *State) ProveAccount(addr thor.Address) ([][]byte, error) {
+	proof, err := s.trie.Prove(secureKey(addr[:]))
+	if err != nil {
+		return nil, &Error{err}
+	}
+	return proof, nil
+}
+
+// ProveStorage constructs the merkle proof of the storage value for the given address and key,
+// against the storage root of the account. Changes not staged are not reflected.
+func (s *State) ProveStorage(addr thor.Address, key thor.Bytes32) ([][]byte, error) {
+	a, am, err := loadAccount(s.trie, addr)
+	if err != nil {
+		return nil, &Error{err}
+	}
+	if len(a.StorageRoot) == 0 {
+		return nil, nil
+	}
+	storageTrie := s.db.NewTrie(
+		StorageTrieName(am.StorageID),
+		trie.Root{
+			Hash: thor.BytesToBytes32(a.StorageRoot),
+			Ver: trie.Version{
+				Major: am.StorageMajorVer,
+				Minor: am.StorageMinorVer,
+			},
+		},
+	)
+	proof, err := storageTrie.Prove(secureKey(key[:]))
+	if err != nil {
+		return nil, &Error{err}
+	}
+	return proof, nil
+}
+
+// VerifyAccountProof verifies the merkle proof of the account at the given address against
+// the state root, and returns the proved account. An empty account is returned if the proof
+// proves its absence.
+func VerifyAccountProof(root thor.Bytes32, addr thor.Address, proof [][]byte) (*Account, error) {
+	data, err := trie.VerifyProof(root, secureKey(addr[:]), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return emptyAccount(), nil
+	}
+	var a Account
+	if err := rlp.DecodeBytes(data, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// VerifyStorageProof verifies the merkle proof of the storage value for the given key against
+// the storage root of the account, and returns the proved value.
+func VerifyStorageProof(storageRoot thor.Bytes32, key thor.Bytes32, proof [][]byte) (thor.Bytes32, error) {
+	raw, err := trie.VerifyProof(storageRoot, secureKey(key[:]), proof)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return decodeStorageValue(raw)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestProof(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, trie.Root{})
+
+	addr := thor.BytesToAddress([]byte("account1"))
+	key := thor.BytesToBytes32([]byte("key"))
+	value := thor.BytesToBytes32([]byte("value"))
+	for i := range 100 {
+		st.SetBalance(thor.BytesToAddress([]byte{byte(i)}), big.NewInt(int64(i+1)))
+		st.SetStorage(addr, thor.BytesToBytes32([]byte{byte(i)}), thor.BytesToBytes32([]byte{byte(i + 1)}))
+	}
+	st.SetBalance(addr, big.NewInt(100))
+	st.SetStorage(addr, key, value)
+
+	stage, err := st.Stage(trie.Version{Major: 1})
+	require.NoError(t, err)
+	root, err := stage.Commit()
+	require.NoError(t, err)
+
+	st = New(db, trie.Root{Hash: root, Ver: trie.Version{Major: 1}})
+
+	// account
+	proof, err := st.ProveAccount(addr)
+	require.NoError(t, err)
+	acc, err := VerifyAccountProof(root, addr, proof)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), acc.Balance)
+
+	_, err = VerifyAccountProof(thor.Bytes32{1}, addr, proof)
+	assert.Error(t, err)
+
+	// absent account
+	absent := thor.BytesToAddress([]byte("absent"))
+	proof, err = st.ProveAccount(absent)
+	require.NoError(t, err)
+	acc, err = VerifyAccountProof(root, absent, proof)
+	require.NoError(t, err)
+	assert.True(t, acc.IsEmpty())
+
+	// storage
+	proof, err = st.ProveAccount(addr)
+	require.NoError(t, err)
+	acc, err = VerifyAccountProof(root, addr, proof)
+	require.NoError(t, err)
+	storageRoot := thor.BytesToBytes32(acc.StorageRoot)
+
+	proof, err = st.ProveStorage(addr, key)
+	require.NoError(t, err)
+	v, err := VerifyStorageProof(storageRoot, key, proof)
+	require.NoError(t, err)
+	assert.Equal(t, value, v)
+
+	// absent storage
+	proof, err = st.ProveStorage(addr, thor.Bytes32{0xff})
+	require.NoError(t, err)
+	v, err = VerifyStorageProof(storageRoot, thor.Bytes32{0xff}, proof)
+	require.NoError(t, err)
+	assert.True(t, v.IsZero())
+
+	// account without storage
+	proof, err = st.ProveStorage(absent, key)
+	require.NoError(t, err)
+	assert.Empty(t, proof)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"context"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state/snapshot"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+const snapshotStoreName = "state.snap"
+
+// OpenSnapshot opens the flat state snapshot kept in db, with at most the given count of
+// diff layers for recent blocks.
+func OpenSnapshot(db *muxdb.MuxDB, layers int) (*snapshot.Tree, error) {
+	return snapshot.New(db.NewStore(snapshotStoreName), layers)
+}
+
+// isSnapshotUnavailable returns whether the snapshot read failed because the snapshot
+// is temporarily or permanently unavailable, and the trie should be read instead.
+func isSnapshotUnavailable(err error) bool {
+	return err == snapshot.ErrStale || err == snapshot.ErrNotReady
+}
+
+// updateSnapshot stacks the changes onto the snapshot of this state.
+// The snapshot is rebuilt at the new root if it falls behind.
+func (s *State) updateSnapshot(root trie.Root, changes *snapshot.Changes) error {
+	err := s.snaps.Update(s.root.Hash, root.Hash, root.Ver.Major, changes)
+	if err == snapshot.ErrUnknownParent {
+		return s.snaps.Rebuild(root.Hash, root.Ver.Major, fillSnapshot(s.db, root))
+	}
+	return err
+}
+
+// fillSnapshot returns the function to fill the snapshot with all accounts and storage of the state.
+func fillSnapshot(db *muxdb.MuxDB, root trie.Root) snapshot.FillFunc {
+	return func(w *snapshot.Writer) error {
+		sw := walker{
+			account: func(key thor.Bytes32, value, meta []byte, _ *Account, _ *AccountMetadata) error {
+				return w.PutAccount(key, value, meta)
+			},
+			storage: w.PutStorage,
+		}
+		// the writer checks for cancellation
+		return sw.walk(context.Background(), db, root)
+	}
+}
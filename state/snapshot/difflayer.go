@@ -0,0 +1,105 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+// diffLayer holds the changes made by a block, on top of the parent layer.
+type diffLayer struct {
+	root     thor.Bytes32
+	number   uint32
+	accounts map[thor.Bytes32]*AccountChange
+	storage  map[string]map[thor.Bytes32][]byte
+	removed  [][]byte
+	stale    atomic.Bool
+
+	lock   sync.RWMutex
+	parent layer
+}
+
+func newDiffLayer(parent layer, root thor.Bytes32, number uint32, changes *Changes) *diffLayer {
+	dl := &diffLayer{
+		root:     root,
+		number:   number,
+		accounts: changes.Accounts,
+		storage:  changes.Storage,
+		removed:  changes.Removed,
+		parent:   parent,
+	}
+	if dl.accounts == nil {
+		dl.accounts = make(map[thor.Bytes32]*AccountChange)
+	}
+	if dl.storage == nil {
+		dl.storage = make(map[string]map[thor.Bytes32][]byte)
+	}
+	return dl
+}
+
+func (dl *diffLayer) Root() thor.Bytes32 { return dl.root }
+func (dl *diffLayer) Number() uint32     { return dl.number }
+func (dl *diffLayer) markStale()         { dl.stale.Store(true) }
+
+func (dl *diffLayer) Parent() layer {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	return dl.parent
+}
+
+func (dl *diffLayer) setParent(parent layer) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+	dl.parent = parent
+}
+
+// readParent reads from the parent layer. The parent may be replaced by flattening
+// while reading, and in that case the read is retried on the new parent.
+func (dl *diffLayer) readParent(read func(p layer) error) error {
+	for {
+		p := dl.Parent()
+		err := read(p)
+		if err == ErrStale && !dl.stale.Load() && dl.Parent() != p {
+			continue
+		}
+		return err
+	}
+}
+
+func (dl *diffLayer) Account(key thor.Bytes32) (value []byte, meta []byte, err error) {
+	if dl.stale.Load() {
+		return nil, nil, ErrStale
+	}
+	if acc, ok := dl.accounts[key]; ok {
+		metricDiffHits().Add(1)
+		return acc.Value, acc.Meta, nil
+	}
+	err = dl.readParent(func(p layer) (err error) {
+		value, meta, err = p.Account(key)
+		return
+	})
+	return
+}
+
+func (dl *diffLayer) Storage(sid []byte, key thor.Bytes32) (value []byte, err error) {
+	if dl.stale.Load() {
+		return nil, ErrStale
+	}
+	if values, ok := dl.storage[string(sid)]; ok {
+		if v, ok := values[key]; ok {
+			metricDiffHits().Add(1)
+			return v, nil
+		}
+	}
+	err = dl.readParent(func(p layer) (err error) {
+		value, err = p.Storage(sid, key)
+		return
+	})
+	return
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// diskLayer is the flattened state persisted in the store.
+type diskLayer struct {
+	store  kv.Store
+	lock   *sync.RWMutex // shared by all disk layers of a tree
+	root   thor.Bytes32
+	number uint32
+	ready  atomic.Bool // false while generating
+	stale  atomic.Bool
+}
+
+func newDiskLayer(store kv.Store, lock *sync.RWMutex, root thor.Bytes32, number uint32, ready bool) *diskLayer {
+	dl := &diskLayer{
+		store:  store,
+		lock:   lock,
+		root:   root,
+		number: number,
+	}
+	dl.ready.Store(ready)
+	return dl
+}
+
+func (dl *diskLayer) Root() thor.Bytes32 { return dl.root }
+func (dl *diskLayer) Number() uint32     { return dl.number }
+func (dl *diskLayer) Parent() layer      { return nil }
+func (dl *diskLayer) markStale()         { dl.stale.Store(true) }
+
+func (dl *diskLayer) get(key []byte) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale.Load() {
+		return nil, ErrStale
+	}
+	if !dl.ready.Load() {
+		return nil, ErrNotReady
+	}
+	data, err := dl.store.Get(key)
+	if err != nil {
+		if dl.store.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (dl *diskLayer) Account(key thor.Bytes32) ([]byte, []byte, error) {
+	data, err := dl.get(accountKey(key))
+	if err != nil || len(data) == 0 {
+		return nil, nil, err
+	}
+	return decodeAccount(data)
+}
+
+func (dl *diskLayer) Storage(sid []byte, key thor.Bytes32) ([]byte, error) {
+	return dl.get(storageKey(sid, key))
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// key prefixes of the store
+const (
+	accountPrefix = byte('a') // accountPrefix + hashed address => account value and metadata
+	storagePrefix = byte('s') // storagePrefix + len(sid) + sid + hashed key => storage value
+	diffPrefix    = byte('d') // diffPrefix + root => journaled diff layer
+)
+
+// rootKey is the key of the root of the disk layer, absent if the disk layer is incomplete.
+var rootKey = []byte("root")
+
+func accountKey(key thor.Bytes32) []byte {
+	return append([]byte{accountPrefix}, key[:]...)
+}
+
+func storagePrefixOf(sid []byte) []byte {
+	// the length of sid is always less than 256
+	prefix := make([]byte, 0, 2+len(sid)+32)
+	prefix = append(prefix, storagePrefix, byte(len(sid)))
+	return append(prefix, sid...)
+}
+
+func storageKey(sid []byte, key thor.Bytes32) []byte {
+	return append(storagePrefixOf(sid), key[:]...)
+}
+
+func storageRange(sid []byte) kv.Range {
+	return kv.Range(*util.BytesPrefix(storagePrefixOf(sid)))
+}
+
+func diffKey(root thor.Bytes32) []byte {
+	return append([]byte{diffPrefix}, root[:]...)
+}
+
+func encodeAccount(value, meta []byte) []byte {
+	data, _ := rlp.EncodeToBytes([][]byte{value, meta})
+	return data
+}
+
+func decodeAccount(data []byte) (value []byte, meta []byte, err error) {
+	var fields [][]byte
+	if err := rlp.DecodeBytes(data, &fields); err != nil {
+		return nil, nil, err
+	}
+	if len(fields) != 2 {
+		return nil, nil, errors.New("invalid account entry")
+	}
+	return fields[0], fields[1], nil
+}
+
+type diskRoot struct {
+	Root   thor.Bytes32
+	Number uint32
+}
+
+func encodeRoot(root thor.Bytes32, number uint32) []byte {
+	data, _ := rlp.EncodeToBytes(&diskRoot{root, number})
+	return data
+}
+
+type (
+	journalAccount struct {
+		Key   thor.Bytes32
+		Value []byte
+		Meta  []byte
+	}
+	journalStorage struct {
+		SID   []byte
+		Key   thor.Bytes32
+		Value []byte
+	}
+	// journalDiff is the persisted form of diff layer, to restore diff layers after restart.
+	journalDiff struct {
+		Parent   thor.Bytes32
+		Root     thor.Bytes32
+		Number   uint32
+		Accounts []journalAccount
+		Storage  []journalStorage
+		Removed  [][]byte
+	}
+)
+
+func encodeDiff(dl *diffLayer) []byte {
+	jd := journalDiff{
+		Parent:  dl.Parent().Root(),
+		Root:    dl.root,
+		Number:  dl.number,
+		Removed: dl.removed,
+	}
+	for key, acc := range dl.accounts {
+		jd.Accounts = append(jd.Accounts, journalAccount{key, acc.Value, acc.Meta})
+	}
+	for sid, values := range dl.storage {
+		for key, v := range values {
+			jd.Storage = append(jd.Storage, journalStorage{[]byte(sid), key, v})
+		}
+	}
+	// deterministic encoding
+	sort.Slice(jd.Accounts, func(i, j int) bool {
+		return string(jd.Accounts[i].Key[:]) < string(jd.Accounts[j].Key[:])
+	})
+	sort.Slice(jd.Storage, func(i, j int) bool {
+		a, b := jd.Storage[i], jd.Storage[j]
+		if string(a.SID) != string(b.SID) {
+			return string(a.SID) < string(b.SID)
+		}
+		return string(a.Key[:]) < string(b.Key[:])
+	})
+	data, _ := rlp.EncodeToBytes(&jd)
+	return data
+}
+
+func (jd *journalDiff) changes() *Changes {
+	c := &Changes{
+		Accounts: make(map[thor.Bytes32]*AccountChange, len(jd.Accounts)),
+		Storage:  make(map[string]map[thor.Bytes32][]byte),
+		Removed:  jd.Removed,
+	}
+	for _, acc := range jd.Accounts {
+		c.Accounts[acc.Key] = &AccountChange{Value: acc.Value, Meta: acc.Meta}
+	}
+	for _, s := range jd.Storage {
+		values := c.Storage[string(s.SID)]
+		if values == nil {
+			values = make(map[thor.Bytes32][]byte)
+			c.Storage[string(s.SID)] = values
+		}
+		values[s.Key] = s.Value
+	}
+	return c
+}
+
+// load restores the disk layer and journaled diff layers.
+func (t *Tree) load() error {
+	data, err := t.store.Get(rootKey)
+	if err != nil {
+		if t.store.IsNotFound(err) {
+			// no complete snapshot, journaled diffs are useless
+			return t.store.DeleteRange(context.Background(), kv.Range(*util.BytesPrefix([]byte{diffPrefix})))
+		}
+		return err
+	}
+	var dr diskRoot
+	if err := rlp.DecodeBytes(data, &dr); err != nil {
+		return err
+	}
+	t.disk = newDiskLayer(t.store, &t.diskLock, dr.Root, dr.Number, true)
+	t.layers[dr.Root] = t.disk
+
+	// group journaled diffs by parent
+	children := make(map[thor.Bytes32][]*journalDiff)
+	all := make(map[thor.Bytes32]struct{})
+	it := t.store.Iterate(kv.Range(*util.BytesPrefix([]byte{diffPrefix})))
+	for it.Next() {
+		var jd journalDiff
+		if err := rlp.DecodeBytes(it.Value(), &jd); err != nil {
+			it.Release()
+			return err
+		}
+		children[jd.Parent] = append(children[jd.Parent], &jd)
+		all[jd.Root] = struct{}{}
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	var link func(parent layer)
+	link = func(parent layer) {
+		for _, jd := range children[parent.Root()] {
+			if _, ok := t.layers[jd.Root]; ok {
+				continue
+			}
+			dl := newDiffLayer(parent, jd.Root, jd.Number, jd.changes())
+			t.layers[jd.Root] = dl
+			delete(all, jd.Root)
+			link(dl)
+		}
+	}
+	link(t.disk)
+	metricLayers().Add(int64(len(t.layers) - 1))
+
+	// remove orphans
+	for root := range all {
+		if err := t.store.Delete(diffKey(root)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Writer writes entries into the disk layer under generation.
+type Writer struct {
+	bulk     kv.Bulk
+	check    func() error
+	accounts int
+}
+
+// PutAccount writes an account.
+func (w *Writer) PutAccount(key thor.Bytes32, value, meta []byte) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+	w.accounts++
+	return w.bulk.Put(accountKey(key), encodeAccount(value, meta))
+}
+
+// PutStorage writes a storage value.
+func (w *Writer) PutStorage(sid []byte, key thor.Bytes32, value []byte) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+	return w.bulk.Put(storageKey(sid, key), value)
+}
+
+// FillFunc fills the disk layer with all accounts and storage of the state.
+type FillFunc func(w *Writer) error
+
+type generator struct {
+	disk   *diskLayer
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// stopGeneration cancels the running generation and waits for it. It must be called with genLock held.
+func (t *Tree) stopGeneration() {
+	if t.gen != nil {
+		t.gen.cancel()
+		<-t.gen.done
+		t.gen = nil
+	}
+}
+
+// Rebuild drops all layers, and regenerates the disk layer at the given root in background.
+// Diff layers can be stacked on the generating disk layer, but reads are served only after
+// the generation finished.
+func (t *Tree) Rebuild(root thor.Bytes32, number uint32, fill FillFunc) error {
+	t.genLock.Lock()
+	defer t.genLock.Unlock()
+
+	t.stopGeneration()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// mark the disk layer incomplete before wiping
+	if err := t.store.Delete(rootKey); err != nil {
+		return err
+	}
+	for _, l := range t.layers {
+		l.markStale()
+	}
+	metricLayers().Add(-int64(len(t.layers)))
+
+	disk := newDiskLayer(t.store, &t.diskLock, root, number, false)
+	t.disk = disk
+	t.layers = map[thor.Bytes32]layer{root: disk}
+	metricLayers().Add(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.gen = &generator{
+		disk:   disk,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func(gen *generator) {
+		defer close(gen.done)
+		if err := t.generate(ctx, gen, fill); err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("failed to generate state snapshot", "root", root, "err", err)
+				t.abandon(gen)
+			}
+		}
+	}(t.gen)
+	return nil
+}
+
+func (t *Tree) generate(ctx context.Context, gen *generator, fill FillFunc) error {
+	startTime := time.Now()
+	logger.Info("generating state snapshot", "root", gen.disk.root, "number", gen.disk.number)
+
+	// wipe the remains
+	if err := t.store.DeleteRange(ctx, kv.Range{}); err != nil {
+		return err
+	}
+
+	bulk := t.store.Bulk()
+	bulk.EnableAutoFlush()
+	w := &Writer{bulk: bulk, check: newContextChecker(ctx, 5000)}
+	if err := fill(w); err != nil {
+		return err
+	}
+	if err := bulk.Write(); err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if ctx.Err() != nil || t.disk != gen.disk {
+		return ctx.Err()
+	}
+	// journal diff layers stacked during generation, since the store was wiped
+	for _, l := range t.layers {
+		if dl, ok := l.(*diffLayer); ok {
+			if err := t.store.Put(diffKey(dl.root), encodeDiff(dl)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := t.store.Put(rootKey, encodeRoot(gen.disk.root, gen.disk.number)); err != nil {
+		return err
+	}
+	gen.disk.ready.Store(true)
+	logger.Info("state snapshot generated", "accounts", w.accounts, "elapsed", time.Since(startTime).Round(time.Millisecond))
+
+	// flatten layers stacked during generation
+	var top layer
+	for _, l := range t.layers {
+		if top == nil || l.Number() > top.Number() {
+			top = l
+		}
+	}
+	return t.capLocked(top)
+}
+
+// abandon drops all layers after generation failed, so that it'll be rebuilt on next update.
+func (t *Tree) abandon(gen *generator) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.disk != gen.disk {
+		return
+	}
+	for _, l := range t.layers {
+		l.markStale()
+	}
+	metricLayers().Add(-int64(len(t.layers)))
+	t.layers = make(map[thor.Bytes32]layer)
+	t.disk = nil
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import "github.com/vechain/thor/v2/metrics"
+
+var (
+	metricLayers   = metrics.LazyLoadGauge("state_snapshot_layers_gauge")
+	metricFlattens = metrics.LazyLoadCounter("state_snapshot_flatten_count")
+	metricDiffHits = metrics.LazyLoadCounter("state_snapshot_diff_hit_count")
+)
@@ -0,0 +1,305 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package snapshot implements a flat key-value view of the latest world state, kept alongside the tries.
+//
+// The persisted disk layer holds the flattened state at some root. Changes of recent blocks are kept in
+// memory as diff layers stacked on it, so that states of recent revisions, including those on forks,
+// can be read without walking trie nodes. Once the stack grows over the limit, the bottom diff layer
+// is flattened into the disk layer, and layers no longer based on it turn stale.
+//
+// Accounts are keyed by the hashed address, and storage values by the storage id and hashed key,
+// as in the tries.
+package snapshot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/thor"
+)
+
+var logger = log.WithContext("pkg", "snapshot")
+
+var (
+	// ErrStale is returned when reading a layer which was flattened or invalidated.
+	ErrStale = errors.New("snapshot stale")
+	// ErrNotReady is returned when reading the disk layer under generation.
+	ErrNotReady = errors.New("snapshot not ready")
+	// ErrUnknownParent is returned by Update when the snapshot should be rebuilt to catch up.
+	ErrUnknownParent = errors.New("unknown parent snapshot")
+)
+
+// Snapshot is the flat state at a root.
+type Snapshot interface {
+	// Root returns the state root of the snapshot.
+	Root() thor.Bytes32
+	// Account returns the account value and metadata of the hashed address. Nil values for absent account.
+	Account(key thor.Bytes32) (value []byte, meta []byte, err error)
+	// Storage returns the storage value of the hashed key. Nil value for absent key.
+	Storage(sid []byte, key thor.Bytes32) ([]byte, error)
+}
+
+// layer is either the disk layer or a diff layer.
+type layer interface {
+	Snapshot
+	Number() uint32
+	Parent() layer
+	markStale()
+}
+
+// AccountChange is the change of an account. Nil value means the account is deleted.
+type AccountChange struct {
+	Value []byte
+	Meta  []byte
+}
+
+// Changes is the state changes made by a block.
+type Changes struct {
+	Accounts map[thor.Bytes32]*AccountChange
+	Storage  map[string]map[thor.Bytes32][]byte // storage id => hashed key => value
+	Removed  [][]byte                           // ids of storage no longer referred
+}
+
+// Tree maintains the disk layer and diff layers.
+type Tree struct {
+	store kv.Store
+	limit int
+
+	lock     sync.RWMutex
+	layers   map[thor.Bytes32]layer
+	disk     *diskLayer
+	diskLock sync.RWMutex // guards reads of disk layers against flattening
+
+	genLock sync.Mutex // serializes rebuilding
+	gen     *generator
+}
+
+// New creates a snapshot tree over the store, and loads the persisted layers.
+// At most limit diff layers are kept on the disk layer.
+func New(store kv.Store, limit int) (*Tree, error) {
+	t := &Tree{
+		store:  store,
+		limit:  max(limit, 1),
+		layers: make(map[thor.Bytes32]layer),
+	}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Snapshot returns the snapshot at the given root, or nil if not available.
+func (t *Tree) Snapshot(root thor.Bytes32) Snapshot {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if l, ok := t.layers[root]; ok {
+		return l
+	}
+	return nil
+}
+
+// Update stacks a diff layer of changes made by the block with the given number.
+//
+// It returns ErrUnknownParent if the parent is absent while the block is newer than all layers,
+// which means the snapshot falls behind and should be rebuilt. Changes on unknown old branches
+// are ignored.
+func (t *Tree) Update(parentRoot, root thor.Bytes32, number uint32, changes *Changes) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.layers[root]; ok {
+		return nil
+	}
+	parent, ok := t.layers[parentRoot]
+	if !ok {
+		if t.disk == nil || number > t.highest() {
+			return ErrUnknownParent
+		}
+		return nil
+	}
+
+	dl := newDiffLayer(parent, root, number, changes)
+	if err := t.store.Put(diffKey(root), encodeDiff(dl)); err != nil {
+		return err
+	}
+	t.layers[root] = dl
+	metricLayers().Add(1)
+	return t.capLocked(dl)
+}
+
+// highest returns the highest number of layers. It must be called with lock held.
+func (t *Tree) highest() uint32 {
+	var n uint32
+	for _, l := range t.layers {
+		n = max(n, l.Number())
+	}
+	return n
+}
+
+// capLocked flattens bottom diff layers under the given one, until the diff layers
+// stacked on disk are within limit. It must be called with lock held.
+func (t *Tree) capLocked(top layer) error {
+	if !t.disk.ready.Load() {
+		// it's not possible to flatten into the disk layer under generation
+		return nil
+	}
+	var path []*diffLayer
+	for l := top; l != t.disk; l = l.Parent() {
+		dl, ok := l.(*diffLayer)
+		if !ok {
+			// not based on the current disk layer
+			return nil
+		}
+		path = append(path, dl)
+	}
+	for len(path) > t.limit {
+		bottom := path[len(path)-1]
+		if err := t.flattenLocked(bottom); err != nil {
+			return err
+		}
+		path = path[:len(path)-1]
+	}
+	return nil
+}
+
+// flattenLocked merges the bottom diff layer into the disk layer. Diff layers on other branches
+// are dropped. It must be called with lock held.
+func (t *Tree) flattenLocked(bottom *diffLayer) error {
+	var (
+		bulk     = t.store.Bulk()
+		children = t.childrenLocked()
+		dropped  []layer
+	)
+	// removed storage first, in case of ids reused
+	for _, sid := range bottom.removed {
+		it := t.store.Iterate(storageRange(sid))
+		for it.Next() {
+			if err := bulk.Delete(it.Key()); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return err
+		}
+	}
+	if err := writeChanges(bulk, bottom.accounts, bottom.storage); err != nil {
+		return err
+	}
+	if err := bulk.Put(rootKey, encodeRoot(bottom.root, bottom.number)); err != nil {
+		return err
+	}
+	if err := bulk.Delete(diffKey(bottom.root)); err != nil {
+		return err
+	}
+	// drop siblings of the bottom layer with their descendants
+	var drop func(l layer)
+	drop = func(l layer) {
+		dropped = append(dropped, l)
+		for _, c := range children[l.Root()] {
+			drop(c)
+		}
+	}
+	for _, c := range children[t.disk.root] {
+		if c != bottom {
+			drop(c)
+		}
+	}
+	for _, l := range dropped {
+		if err := bulk.Delete(diffKey(l.Root())); err != nil {
+			return err
+		}
+	}
+
+	newDisk := newDiskLayer(t.store, &t.diskLock, bottom.root, bottom.number, true)
+
+	t.diskLock.Lock()
+	t.disk.markStale()
+	err := bulk.Write()
+	if err == nil {
+		for _, c := range children[bottom.root] {
+			c.setParent(newDisk)
+		}
+	}
+	t.diskLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	bottom.markStale()
+	delete(t.layers, t.disk.root)
+	t.layers[bottom.root] = newDisk
+	t.disk = newDisk
+	for _, l := range dropped {
+		l.markStale()
+		delete(t.layers, l.Root())
+	}
+	metricLayers().Add(-int64(len(dropped) + 1))
+	metricFlattens().Add(1)
+	return nil
+}
+
+// childrenLocked returns diff layers grouped by their parent roots. It must be called with lock held.
+func (t *Tree) childrenLocked() map[thor.Bytes32][]*diffLayer {
+	children := make(map[thor.Bytes32][]*diffLayer)
+	for _, l := range t.layers {
+		if dl, ok := l.(*diffLayer); ok {
+			p := dl.Parent().Root()
+			children[p] = append(children[p], dl)
+		}
+	}
+	return children
+}
+
+// Close stops generation if any.
+func (t *Tree) Close() {
+	t.genLock.Lock()
+	defer t.genLock.Unlock()
+	t.stopGeneration()
+}
+
+// writeChanges writes account and storage changes into the putter.
+func writeChanges(w kv.Putter, accounts map[thor.Bytes32]*AccountChange, storage map[string]map[thor.Bytes32][]byte) error {
+	for key, acc := range accounts {
+		if len(acc.Value) == 0 {
+			if err := w.Delete(accountKey(key)); err != nil {
+				return err
+			}
+		} else if err := w.Put(accountKey(key), encodeAccount(acc.Value, acc.Meta)); err != nil {
+			return err
+		}
+	}
+	for sid, values := range storage {
+		for key, v := range values {
+			if len(v) == 0 {
+				if err := w.Delete(storageKey([]byte(sid), key)); err != nil {
+					return err
+				}
+			} else if err := w.Put(storageKey([]byte(sid), key), v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ensure the context is checked in long loops, but not too often.
+func newContextChecker(ctx context.Context, debounce int) func() error {
+	count := 0
+	return func() error {
+		count++
+		if count > debounce {
+			count = 0
+			return ctx.Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func waitGeneration(tree *Tree) {
+	tree.genLock.Lock()
+	gen := tree.gen
+	tree.genLock.Unlock()
+	if gen != nil {
+		<-gen.done
+	}
+}
+
+func rootOf(i int) thor.Bytes32 {
+	return thor.Blake2b([]byte{byte(i)})
+}
+
+func keyOf(s string) thor.Bytes32 {
+	return thor.Blake2b([]byte(s))
+}
+
+// changesOf makes changes setting account "acc" and storage "key" of sid "sid" to the value.
+func changesOf(value string) *Changes {
+	return &Changes{
+		Accounts: map[thor.Bytes32]*AccountChange{
+			keyOf("acc"): {Value: []byte(value), Meta: []byte("meta")},
+		},
+		Storage: map[string]map[thor.Bytes32][]byte{
+			"sid": {keyOf("key"): []byte(value)},
+		},
+	}
+}
+
+func newGeneratedTree(t *testing.T, store *muxdb.MuxDB, limit int) *Tree {
+	tree, err := New(store.NewStore("snap"), limit)
+	require.NoError(t, err)
+
+	require.NoError(t, tree.Rebuild(rootOf(0), 0, func(w *Writer) error {
+		if err := w.PutAccount(keyOf("acc"), []byte("v0"), []byte("meta")); err != nil {
+			return err
+		}
+		if err := w.PutAccount(keyOf("other"), []byte("other"), nil); err != nil {
+			return err
+		}
+		return w.PutStorage([]byte("sid"), keyOf("key"), []byte("v0"))
+	}))
+	waitGeneration(tree)
+	return tree
+}
+
+func readAccount(t *testing.T, snap Snapshot, key string) string {
+	v, _, err := snap.Account(keyOf(key))
+	require.NoError(t, err)
+	return string(v)
+}
+
+func readStorage(t *testing.T, snap Snapshot, sid, key string) string {
+	v, err := snap.Storage([]byte(sid), keyOf(key))
+	require.NoError(t, err)
+	return string(v)
+}
+
+func TestGenerate(t *testing.T) {
+	tree := newGeneratedTree(t, muxdb.NewMem(), 4)
+
+	snap := tree.Snapshot(rootOf(0))
+	require.NotNil(t, snap)
+	assert.Equal(t, "v0", readAccount(t, snap, "acc"))
+	assert.Equal(t, "other", readAccount(t, snap, "other"))
+	assert.Equal(t, "", readAccount(t, snap, "absent"))
+	assert.Equal(t, "v0", readStorage(t, snap, "sid", "key"))
+	assert.Equal(t, "", readStorage(t, snap, "sid", "absent"))
+
+	_, meta, err := snap.Account(keyOf("acc"))
+	require.NoError(t, err)
+	assert.Equal(t, "meta", string(meta))
+
+	assert.Nil(t, tree.Snapshot(rootOf(1)))
+}
+
+func TestGenerateFailed(t *testing.T) {
+	tree, err := New(muxdb.NewMem().NewStore("snap"), 4)
+	require.NoError(t, err)
+
+	// nothing to stack on
+	assert.Equal(t, ErrUnknownParent, tree.Update(rootOf(0), rootOf(1), 1, changesOf("v1")))
+
+	require.NoError(t, tree.Rebuild(rootOf(0), 0, func(*Writer) error {
+		return errors.New("failed")
+	}))
+	waitGeneration(tree)
+
+	assert.Nil(t, tree.Snapshot(rootOf(0)))
+	assert.Equal(t, ErrUnknownParent, tree.Update(rootOf(0), rootOf(1), 1, changesOf("v1")))
+}
+
+func TestNotReady(t *testing.T) {
+	tree, err := New(muxdb.NewMem().NewStore("snap"), 4)
+	require.NoError(t, err)
+
+	blocked := make(chan struct{})
+	require.NoError(t, tree.Rebuild(rootOf(0), 0, func(w *Writer) error {
+		<-blocked
+		return w.PutAccount(keyOf("other"), []byte("other"), nil)
+	}))
+
+	// diff layers can be stacked during generation
+	for i := 1; i <= 6; i++ {
+		require.NoError(t, tree.Update(rootOf(i-1), rootOf(i), uint32(i), changesOf("v"+string(rune('0'+i)))))
+	}
+	snap := tree.Snapshot(rootOf(6))
+	assert.Equal(t, "v6", readAccount(t, snap, "acc"))
+	_, _, err = snap.Account(keyOf("other"))
+	assert.Equal(t, ErrNotReady, err)
+
+	close(blocked)
+	waitGeneration(tree)
+
+	// flattened to limit after generated
+	assert.Equal(t, "other", readAccount(t, snap, "other"))
+	assert.Nil(t, tree.Snapshot(rootOf(0)))
+	assert.Nil(t, tree.Snapshot(rootOf(1)))
+	assert.NotNil(t, tree.Snapshot(rootOf(2)))
+	assert.Len(t, tree.layers, 5)
+}
+
+func TestDiffLayers(t *testing.T) {
+	db := muxdb.NewMem()
+	tree := newGeneratedTree(t, db, 2)
+
+	require.NoError(t, tree.Update(rootOf(0), rootOf(1), 1, changesOf("v1")))
+	require.NoError(t, tree.Update(rootOf(1), rootOf(2), 2, changesOf("v2")))
+	// fork at 1
+	require.NoError(t, tree.Update(rootOf(1), rootOf(12), 2, changesOf("v12")))
+
+	snap0 := tree.Snapshot(rootOf(0))
+	snap1 := tree.Snapshot(rootOf(1))
+	snap2 := tree.Snapshot(rootOf(2))
+	snap12 := tree.Snapshot(rootOf(12))
+
+	assert.Equal(t, "v1", readAccount(t, snap1, "acc"))
+	assert.Equal(t, "v2", readAccount(t, snap2, "acc"))
+	assert.Equal(t, "v12", readAccount(t, snap12, "acc"))
+	assert.Equal(t, "v2", readStorage(t, snap2, "sid", "key"))
+	assert.Equal(t, "other", readAccount(t, snap2, "other"))
+
+	// re-update is ignored
+	require.NoError(t, tree.Update(rootOf(1), rootOf(2), 2, changesOf("v2x")))
+	assert.Equal(t, "v2", readAccount(t, snap2, "acc"))
+
+	// unknown old parent ignored, but unknown new parent requires rebuilding
+	require.NoError(t, tree.Update(rootOf(100), rootOf(101), 1, changesOf("x")))
+	assert.Equal(t, ErrUnknownParent, tree.Update(rootOf(100), rootOf(101), 3, changesOf("x")))
+
+	// layer 1 flattened
+	require.NoError(t, tree.Update(rootOf(2), rootOf(3), 3, changesOf("v3")))
+	_, _, err := snap0.Account(keyOf("acc"))
+	assert.Equal(t, ErrStale, err)
+	_, _, err = snap1.Account(keyOf("acc"))
+	assert.Equal(t, ErrStale, err)
+	assert.Equal(t, "v2", readAccount(t, snap2, "acc"))
+	assert.Equal(t, "v3", readAccount(t, tree.Snapshot(rootOf(3)), "acc"))
+	assert.Equal(t, "v1", readAccount(t, tree.Snapshot(rootOf(1)), "acc"))
+	// fork still based on the new disk layer
+	assert.Equal(t, "v12", readAccount(t, snap12, "acc"))
+
+	// layer 2 flattened, fork dropped
+	require.NoError(t, tree.Update(rootOf(3), rootOf(4), 4, changesOf("v4")))
+	_, _, err = snap12.Account(keyOf("acc"))
+	assert.Equal(t, ErrStale, err)
+	assert.Nil(t, tree.Snapshot(rootOf(12)))
+	assert.Equal(t, "v2", readAccount(t, tree.Snapshot(rootOf(2)), "acc"))
+	assert.Equal(t, "v4", readStorage(t, tree.Snapshot(rootOf(4)), "sid", "key"))
+
+	// reload
+	tree, err = New(db.NewStore("snap"), 2)
+	require.NoError(t, err)
+	assert.Len(t, tree.layers, 3)
+	assert.Equal(t, "v2", readAccount(t, tree.Snapshot(rootOf(2)), "acc"))
+	assert.Equal(t, "v3", readAccount(t, tree.Snapshot(rootOf(3)), "acc"))
+	assert.Equal(t, "v4", readAccount(t, tree.Snapshot(rootOf(4)), "acc"))
+	assert.Nil(t, tree.Snapshot(rootOf(12)))
+}
+
+func TestRemovedStorage(t *testing.T) {
+	tree := newGeneratedTree(t, muxdb.NewMem(), 1)
+
+	changes := changesOf("v1")
+	changes.Accounts[keyOf("acc")].Value = nil
+	changes.Storage = nil
+	changes.Removed = [][]byte{[]byte("sid")}
+	require.NoError(t, tree.Update(rootOf(0), rootOf(1), 1, changes))
+
+	snap := tree.Snapshot(rootOf(1))
+	assert.Equal(t, "", readAccount(t, snap, "acc"))
+
+	require.NoError(t, tree.Update(rootOf(1), rootOf(2), 2, &Changes{}))
+	// flattened
+	assert.Equal(t, "", readStorage(t, tree.Snapshot(rootOf(1)), "sid", "key"))
+	assert.Equal(t, "", readAccount(t, tree.Snapshot(rootOf(1)), "acc"))
+	assert.Equal(t, "other", readAccount(t, tree.Snapshot(rootOf(2)), "other"))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestSnapshot(t *testing.T) {
+	db := muxdb.NewMem()
+	snaps, err := OpenSnapshot(db, 2)
+	require.NoError(t, err)
+	defer snaps.Close()
+	stater := NewSnapshotStater(db, snaps)
+
+	var (
+		addr1 = thor.BytesToAddress([]byte("addr1"))
+		addr2 = thor.BytesToAddress([]byte("addr2"))
+		addr3 = thor.BytesToAddress([]byte("addr3"))
+		addrs = []thor.Address{addr1, addr2, addr3}
+		key1  = thor.BytesToBytes32([]byte("key1"))
+		key2  = thor.BytesToBytes32([]byte("key2"))
+		keys  = []thor.Bytes32{key1, key2}
+		roots []trie.Root
+	)
+
+	// conflicts is the minor version, to distinguish forks of the same height
+	commit := func(parent trie.Root, conflicts uint32, update func(st *State)) trie.Root {
+		st := stater.NewState(parent)
+		update(st)
+		ver := trie.Version{Major: parent.Ver.Major + 1, Minor: conflicts}
+		stage, err := st.Stage(ver)
+		require.NoError(t, err)
+		root, err := stage.Commit()
+		require.NoError(t, err)
+		r := trie.Root{Hash: root, Ver: ver}
+		roots = append(roots, r)
+		return r
+	}
+
+	// compares reads from snapshot with those from trie
+	verify := func(root trie.Root) {
+		snapState := stater.NewState(root)
+		trieState := New(db, root)
+		for _, addr := range addrs {
+			assert.Equal(t, M(trieState.GetBalance(addr)), M(snapState.GetBalance(addr)))
+			assert.Equal(t, M(trieState.GetCode(addr)), M(snapState.GetCode(addr)))
+			for _, key := range keys {
+				assert.Equal(t, M(trieState.GetStorage(addr, key)), M(snapState.GetStorage(addr, key)))
+			}
+		}
+	}
+
+	root := commit(trie.Root{}, 0, func(st *State) {
+		st.SetBalance(addr1, big.NewInt(1))
+		st.SetStorage(addr1, key1, thor.BytesToBytes32([]byte("v1")))
+		st.SetStorage(addr1, key2, thor.BytesToBytes32([]byte("v2")))
+		st.SetBalance(addr2, big.NewInt(2))
+		st.SetCode(addr2, []byte("code"))
+		st.SetStorage(addr2, key1, thor.BytesToBytes32([]byte("v1")))
+	})
+	// snapshot is generated at the first root
+	require.Eventually(t, func() bool {
+		snap := snaps.Snapshot(root.Hash)
+		if snap == nil {
+			return false
+		}
+		_, _, err := snap.Account(thor.Blake2b(addr1[:]))
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	verify(root)
+
+	root = commit(root, 0, func(st *State) {
+		st.SetStorage(addr1, key1, thor.BytesToBytes32([]byte("v1'")))
+		st.Delete(addr2)
+		st.SetBalance(addr3, big.NewInt(3))
+		st.SetStorage(addr3, key2, thor.BytesToBytes32([]byte("v2")))
+	})
+	assert.NotNil(t, stater.NewState(root).snap)
+	verify(root)
+
+	// storage reset
+	fork := root
+	root = commit(root, 0, func(st *State) {
+		st.Delete(addr1)
+		st.SetBalance(addr1, big.NewInt(10))
+		st.SetStorage(addr1, key2, thor.BytesToBytes32([]byte("v2'")))
+		st.SetStorage(addr3, key2, thor.Bytes32{})
+	})
+	verify(root)
+
+	// fork
+	forkRoot := commit(fork, 1, func(st *State) {
+		st.SetBalance(addr2, big.NewInt(20))
+	})
+	verify(forkRoot)
+
+	for range 3 {
+		root = commit(root, 0, func(st *State) {
+			st.SetStorage(addr1, key1, thor.BytesToBytes32([]byte{byte(root.Ver.Major)}))
+		})
+	}
+
+	// flattened layers fall back to trie
+	assert.Nil(t, stater.NewState(roots[0]).snap)
+	assert.Nil(t, stater.NewState(forkRoot).snap)
+	assert.NotNil(t, stater.NewState(root).snap)
+	for _, r := range roots {
+		verify(r)
+	}
+}
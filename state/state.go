@@ -15,6 +15,7 @@ import (
 
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/stackedmap"
+	"github.com/vechain/thor/v2/state/snapshot"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/trie"
 )
@@ -24,7 +25,8 @@ const (
 	AccountTrieName       = "a"
 	StorageTrieNamePrefix = "s"
 
-	codeStoreName = "state.code"
+	// CodeStoreName is the name of the store for contract codes, keyed by code hash.
+	CodeStoreName = "state.code"
 )
 
 // StorageTrieName converts the storage id into the name of storage trie.
@@ -48,17 +50,31 @@ func (e *Error) Error() string {
 // State manages the world state.
 type State struct {
 	db    *muxdb.MuxDB
+	root  trie.Root
 	trie  *muxdb.Trie                    // the accounts trie reader
 	cache map[thor.Address]*cachedObject // cache of accounts trie
 	sm    *stackedmap.StackedMap         // keeps revisions of accounts state
+	snaps *snapshot.Tree                 // nil if snapshot disabled
+	snap  snapshot.Snapshot              // flat state at root, nil if not available
+	err   error                          // set if the state is unavailable
 }
 
 // New create state object.
 func New(db *muxdb.MuxDB, root trie.Root) *State {
+	return newState(db, root, nil)
+}
+
+func newState(db *muxdb.MuxDB, root trie.Root, snaps *snapshot.Tree) *State {
 	state := State{
 		db:    db,
+		root:  root,
 		trie:  db.NewTrie(AccountTrieName, root),
 		cache: make(map[thor.Address]*cachedObject),
+		snaps: snaps,
+	}
+	// reads must reach the recording DB, while changes still update the snapshot on commit
+	if snaps != nil && !db.IsRecording() {
+		state.snap = snaps.Snapshot(root.Hash)
 	}
 
 	state.sm = stackedmap.New(func(key any) (any, bool, error) {
@@ -69,7 +85,7 @@ func New(db *muxdb.MuxDB, root trie.Root) *State {
 
 // Checkout checkouts to another state.
 func (s *State) Checkout(root trie.Root) *State {
-	return New(s.db, root)
+	return newState(s.db, root, s.snaps)
 }
 
 // cacheGetter implements stackedmap.MapGetter.
@@ -117,15 +133,37 @@ func (s *State) getCachedObject(addr thor.Address) (*cachedObject, error) {
 	if co, ok := s.cache[addr]; ok {
 		return co, nil
 	}
-	a, am, err := loadAccount(s.trie, addr)
+	if s.err != nil {
+		return nil, s.err
+	}
+	a, am, err := s.loadAccount(addr)
 	if err != nil {
 		return nil, err
 	}
-	co := newCachedObject(s.db, addr, a, am)
+	co := newCachedObject(s.db, addr, a, am, s.snap)
 	s.cache[addr] = co
 	return co, nil
 }
 
+// loadAccount loads account from the snapshot if available, otherwise from the trie.
+func (s *State) loadAccount(addr thor.Address) (*Account, *AccountMetadata, error) {
+	if s.snap != nil {
+		data, meta, err := s.snap.Account(thor.Blake2b(addr[:]))
+		if err == nil {
+			metricSnapshotReads().AddWithLabel(1, map[string]string{"type": "account", "result": "hit"})
+			return decodeAccount(data, meta)
+		}
+		if !isSnapshotUnavailable(err) {
+			return nil, nil, err
+		}
+		if err == snapshot.ErrStale {
+			s.snap = nil
+		}
+		metricSnapshotReads().AddWithLabel(1, map[string]string{"type": "account", "result": "fallback"})
+	}
+	return loadAccount(s.trie, addr)
+}
+
 // getAccount gets account by address. the returned account should not be modified.
 func (s *State) getAccount(addr thor.Address) (*Account, error) {
 	v, _, err := s.sm.Get(addr)
@@ -229,12 +267,21 @@ func (s *State) GetStorage(addr thor.Address, key thor.Bytes32) (thor.Bytes32, e
 	if err != nil {
 		return thor.Bytes32{}, &Error{err}
 	}
+	v, err := decodeStorageValue(raw)
+	if err != nil {
+		return thor.Bytes32{}, &Error{err}
+	}
+	return v, nil
+}
+
+// decodeStorageValue decodes the raw storage value into bytes32.
+func decodeStorageValue(raw rlp.RawValue) (thor.Bytes32, error) {
 	if len(raw) == 0 {
 		return thor.Bytes32{}, nil
 	}
 	kind, content, _, err := rlp.Split(raw)
 	if err != nil {
-		return thor.Bytes32{}, &Error{err}
+		return thor.Bytes32{}, err
 	}
 	if kind == rlp.List {
 		// special case for rlp list, it should be customized storage value
@@ -411,6 +458,7 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 		meta            AccountMetadata
 		storage         map[thor.Bytes32]rlp.RawValue
 		baseStorageTrie *muxdb.Trie
+		baseStorageID   []byte
 	}
 
 	var (
@@ -430,7 +478,7 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 			return nil, &Error{err}
 		}
 
-		c := &changed{data: co.data, meta: co.meta, baseStorageTrie: co.cache.storageTrie}
+		c := &changed{data: co.data, meta: co.meta, baseStorageTrie: co.cache.storageTrie, baseStorageID: co.meta.StorageID}
 		changes[addr] = c
 		return c, nil
 	}
@@ -484,6 +532,14 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 	trieCpy := s.trie.Copy()
 	tries := make([]*muxdb.Trie, 0, len(changes)+2)
 
+	var snapChanges *snapshot.Changes
+	if s.snaps != nil {
+		snapChanges = &snapshot.Changes{
+			Accounts: make(map[thor.Bytes32]*snapshot.AccountChange, len(changes)),
+			Storage:  make(map[string]map[thor.Bytes32][]byte),
+		}
+	}
+
 	for addr, c := range changes {
 		// skip storage changes if account is empty
 		if !c.data.IsEmpty() {
@@ -507,6 +563,13 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 						return nil, &Error{err}
 					}
 				}
+				if snapChanges != nil {
+					values := make(map[thor.Bytes32][]byte, len(c.storage))
+					for k, v := range c.storage {
+						values[thor.Blake2b(k[:])] = v
+					}
+					snapChanges.Storage[string(c.meta.StorageID)] = values
+				}
 				sRoot := sTrie.Hash()
 				c.data.StorageRoot = sRoot[:]
 				c.meta.StorageMajorVer = newVer.Major
@@ -514,9 +577,20 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 				tries = append(tries, sTrie)
 			}
 		}
-		if err := saveAccount(trieCpy, addr, &c.data, &c.meta); err != nil {
+		data, mdata, err := encodeAccount(&c.data, &c.meta)
+		if err != nil {
+			return nil, &Error{err}
+		}
+		if err := trieCpy.Update(secureKey(addr[:]), data, mdata); err != nil {
 			return nil, &Error{err}
 		}
+		if snapChanges != nil {
+			snapChanges.Accounts[thor.Blake2b(addr[:])] = &snapshot.AccountChange{Value: data, Meta: mdata}
+			// the storage is no longer referred if the account deleted or its storage reset
+			if len(c.baseStorageID) > 0 && (len(data) == 0 || !bytes.Equal(c.meta.StorageID, c.baseStorageID)) {
+				snapChanges.Removed = append(snapChanges.Removed, c.baseStorageID)
+			}
+		}
 	}
 	root := trieCpy.Hash()
 	tries = append(tries, trieCpy)
@@ -525,7 +599,7 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 		root: root,
 		commit: func() error {
 			if len(codes) > 0 {
-				bulk := s.db.NewStore(codeStoreName).Bulk()
+				bulk := s.db.NewStore(CodeStoreName).Bulk()
 				for hash, code := range codes {
 					if err := bulk.Put(hash[:], code); err != nil {
 						return err
@@ -542,6 +616,10 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 			}
 			// Just once for the account trie.
 			metricAccountChanges().Add(int64(len(changes)))
+
+			if snapChanges != nil {
+				return s.updateSnapshot(trie.Root{Hash: root, Ver: newVer}, snapChanges)
+			}
 			return nil
 		},
 	}, nil
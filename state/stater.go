@@ -6,21 +6,70 @@
 package state
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state/snapshot"
 	"github.com/vechain/thor/v2/trie"
 )
 
+// Regenerator rebuilds states which have been pruned.
+type Regenerator interface {
+	// Regenerate returns the DB which holds the state with the given root.
+	// It returns nil DB if the state is not pruned.
+	Regenerate(root trie.Root) (*muxdb.MuxDB, error)
+}
+
 // Stater is the state creator.
 type Stater struct {
-	db *muxdb.MuxDB
+	db    *muxdb.MuxDB
+	snaps *snapshot.Tree
+	regen Regenerator
 }
 
 // NewStater create a new stater.
 func NewStater(db *muxdb.MuxDB) *Stater {
-	return &Stater{db}
+	return &Stater{db: db}
+}
+
+// NewSnapshotStater create a new stater, whose states read from the flat snapshot at recent roots,
+// and keep the snapshot updated on commit.
+func NewSnapshotStater(db *muxdb.MuxDB, snaps *snapshot.Tree) *Stater {
+	return &Stater{db: db, snaps: snaps}
+}
+
+// WithRegenerator returns a copy of the stater, whose states of pruned roots are rebuilt by the regenerator.
+func (s *Stater) WithRegenerator(regen Regenerator) *Stater {
+	cpy := *s
+	cpy.regen = regen
+	return &cpy
+}
+
+// NewRecordingView returns a copy of the stater, whose states are read through a recording view of the DB, which
+// reports reads to the recorder. The snapshot is bypassed for reads, but kept updated on commit.
+func (s *Stater) NewRecordingView(rec muxdb.Recorder) *Stater {
+	cpy := *s
+	cpy.db = s.db.NewRecordingView(rec)
+	return &cpy
+}
+
+// IsRecording returns whether states are read through a recording view of the DB.
+func (s *Stater) IsRecording() bool {
+	return s.db.IsRecording()
 }
 
 // NewState create a new state object.
 func (s *Stater) NewState(root trie.Root) *State {
-	return New(s.db, root)
+	if s.regen != nil {
+		db, err := s.regen.Regenerate(root)
+		if err != nil {
+			st := newState(s.db, root, nil)
+			st.err = errors.Wrap(err, "regenerate state")
+			return st
+		}
+		if db != nil {
+			return newState(db, root, nil)
+		}
+	}
+	return newState(s.db, root, s.snaps)
 }
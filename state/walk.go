@@ -0,0 +1,112 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// walker visits raw entries of the state.
+type walker struct {
+	// account is called for each account, with the hashed address as key.
+	account func(key thor.Bytes32, value, meta []byte, a *Account, am *AccountMetadata) error
+	// storage is called for each storage value of the account visited before, with the hashed storage key.
+	storage func(sid []byte, key thor.Bytes32, value []byte) error
+}
+
+// walk visits all accounts and storage of the state with the given root.
+func (w *walker) walk(ctx context.Context, db *muxdb.MuxDB, root trie.Root) error {
+	accTrie := db.NewTrie(AccountTrieName, root)
+	accTrie.SetNoFillCache(true)
+
+	it := trie.NewIterator(accTrie.NodeIterator(nil, 0))
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		a, am, err := decodeAccount(it.Value, it.Meta)
+		if err != nil {
+			return errors.Wrap(err, "decode account")
+		}
+		if err := w.account(thor.BytesToBytes32(it.Key), it.Value, it.Meta, a, am); err != nil {
+			return err
+		}
+		if len(a.StorageRoot) == 0 || len(am.StorageID) == 0 {
+			// no storage
+			continue
+		}
+
+		sTrie := db.NewTrie(
+			StorageTrieName(am.StorageID),
+			trie.Root{
+				Hash: thor.BytesToBytes32(a.StorageRoot),
+				Ver: trie.Version{
+					Major: am.StorageMajorVer,
+					Minor: am.StorageMinorVer,
+				},
+			},
+		)
+		sTrie.SetNoFillCache(true)
+
+		sit := trie.NewIterator(sTrie.NodeIterator(nil, 0))
+		for sit.Next() {
+			if err := w.storage(am.StorageID, thor.BytesToBytes32(sit.Key), sit.Value); err != nil {
+				return err
+			}
+		}
+		if sit.Err != nil {
+			return errors.Wrapf(sit.Err, "iterate storage of account %v", thor.BytesToBytes32(it.Key))
+		}
+	}
+	if it.Err != nil {
+		return errors.Wrap(it.Err, "iterate accounts")
+	}
+	return nil
+}
+
+// VerifyResult is the result of state verification.
+type VerifyResult struct {
+	Accounts  uint64
+	Contracts uint64
+	Slots     uint64
+}
+
+// Verify walks through the state with the given root, to ensure all trie nodes of accounts and
+// storage, and codes of contracts are reachable.
+func Verify(ctx context.Context, db *muxdb.MuxDB, root trie.Root) (*VerifyResult, error) {
+	var (
+		res       VerifyResult
+		codeStore = db.NewStore(CodeStoreName)
+	)
+	w := walker{
+		account: func(key thor.Bytes32, _, _ []byte, a *Account, _ *AccountMetadata) error {
+			res.Accounts++
+			if len(a.CodeHash) > 0 {
+				res.Contracts++
+				if has, err := codeStore.Has(a.CodeHash); err != nil {
+					return err
+				} else if !has {
+					return errors.Errorf("missing code %x of account %v", a.CodeHash, key)
+				}
+			}
+			return nil
+		},
+		storage: func([]byte, thor.Bytes32, []byte) error {
+			res.Slots++
+			return nil
+		},
+	}
+	if err := w.walk(ctx, db, root); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestVerify(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, trie.Root{})
+
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	st.SetBalance(addr1, big.NewInt(1))
+	st.SetCode(addr2, []byte("code"))
+	st.SetStorage(addr2, thor.BytesToBytes32([]byte("k1")), thor.BytesToBytes32([]byte("v1")))
+	st.SetStorage(addr2, thor.BytesToBytes32([]byte("k2")), thor.BytesToBytes32([]byte("v2")))
+
+	stage, err := st.Stage(trie.Version{Major: 1})
+	require.NoError(t, err)
+	hash, err := stage.Commit()
+	require.NoError(t, err)
+	root := trie.Root{Hash: hash, Ver: trie.Version{Major: 1}}
+
+	res, err := Verify(context.Background(), db, root)
+	require.NoError(t, err)
+	assert.Equal(t, &VerifyResult{Accounts: 2, Contracts: 1, Slots: 2}, res)
+
+	// code lost
+	codeHash, err := New(db, root).GetCodeHash(addr2)
+	require.NoError(t, err)
+	require.NoError(t, db.NewStore(CodeStoreName).Delete(codeHash[:]))
+	_, err = Verify(context.Background(), db, root)
+	assert.Error(t, err)
+
+	// unreachable root
+	_, err = Verify(context.Background(), db, trie.Root{Hash: thor.Blake2b([]byte("absent")), Ver: trie.Version{Major: 1}})
+	assert.Error(t, err)
+}
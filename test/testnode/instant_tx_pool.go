@@ -6,6 +6,7 @@
 package testnode
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/event"
@@ -56,6 +57,28 @@ func (m *instantMintPool) AddLocal(trx *tx.Transaction) error {
 	return m.chain.MintBlock(m.validator, trx)
 }
 
+// ReplaceLocal always fails, since txs are minted once added, and never pending.
+func (m *instantMintPool) ReplaceLocal(*tx.Transaction, thor.Bytes32) error {
+	return errors.New("tx rejected: tx to replace not found")
+}
+
+// AddBundle mints the bundle into a block at once.
+func (m *instantMintPool) AddBundle(txs tx.Transactions) (thor.Bytes32, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.txs = append(m.txs, txs...)
+	return txpool.BundleID(txs), m.chain.MintBlock(m.validator, txs...)
+}
+
+func (m *instantMintPool) GetBundleStatus(thor.Bytes32) []txpool.TxTransition {
+	return nil
+}
+
+func (m *instantMintPool) GetDependencies(thor.Bytes32) *txpool.TxDeps {
+	return nil
+}
+
 func (m *instantMintPool) Dump() tx.Transactions {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -73,3 +96,7 @@ func (m *instantMintPool) Len() int {
 func (m *instantMintPool) SubscribeTxEvent(ch chan *txpool.TxEvent) event.Subscription {
 	return m.scope.Track(m.txFeed.Subscribe(ch))
 }
+
+func (m *instantMintPool) GetStatus(thor.Bytes32) []txpool.TxTransition {
+	return nil
+}
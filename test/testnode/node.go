@@ -16,6 +16,7 @@ import (
 	"github.com/vechain/thor/v2/api/debug"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/fees"
+	"github.com/vechain/thor/v2/api/logs"
 	node2 "github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/api/subscriptions"
 	"github.com/vechain/thor/v2/api/transactions"
@@ -78,6 +79,7 @@ func (n *node) Start() error {
 	accounts.New(repo, stater, 40_000_000, forkConfig, engine, true).Mount(router, "/accounts")
 	events.New(repo, logDB, 1000).Mount(router, "/logs/event")
 	transfers.New(repo, logDB, 1000).Mount(router, "/logs/transfer")
+	logs.New(logDB).Mount(router, "/logs")
 	blocks.New(repo, engine).Mount(router, "/blocks")
 	transactions.New(repo, n.txPool).Mount(router, "/transactions")
 	debug.New(repo, stater, forkConfig, engine,
@@ -254,6 +254,25 @@ func (c *Client) GetBlock(blockID string) (*api.JSONCollapsedBlock, error) {
 	return &block, nil
 }
 
+// GetRawBlock retrieves the RLP encoded header of a block by its revision.
+func (c *Client) GetRawBlock(revision string) (*api.JSONRawBlockSummary, error) {
+	body, err := c.httpGET(c.url + "/blocks/" + revision + "?raw=true")
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve raw block - %w", err)
+	}
+
+	if len(body) == 0 || bytes.Equal(bytes.TrimSpace(body), []byte("null")) {
+		return nil, ErrNotFound
+	}
+
+	var block api.JSONRawBlockSummary
+	if err = json.Unmarshal(body, &block); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal raw block - %w", err)
+	}
+
+	return &block, nil
+}
+
 // GetExpandedBlock retrieves an expanded block by its revision.
 func (c *Client) GetExpandedBlock(revision string) (*api.JSONExpandedBlock, error) {
 	body, err := c.httpGET(c.url + "/blocks/" + revision + "?expanded=true")
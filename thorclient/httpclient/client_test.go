@@ -281,6 +281,25 @@ func TestClient_GetExpandedBlock(t *testing.T) {
 	assert.Equal(t, expectedBlock, block)
 }
 
+func TestClient_GetRawBlock(t *testing.T) {
+	expectedBlock := &api.JSONRawBlockSummary{Raw: "0x01"}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blocks/123", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("raw"))
+
+		blockBytes, _ := json.Marshal(expectedBlock)
+		w.Write(blockBytes)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	block, err := client.GetRawBlock("123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBlock, block)
+}
+
 func TestClient_GetBlock(t *testing.T) {
 	blockID := "123"
 	expectedBlock := &api.JSONCollapsedBlock{
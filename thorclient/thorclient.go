@@ -709,6 +709,35 @@ func (c *Client) Block(revision string) (blocks *api.JSONCollapsedBlock, err err
 	return c.httpConn.GetBlock(revision)
 }
 
+// RawBlock retrieves the block header by its revision in raw RLP-encoded format.
+//
+// This method corresponds to the GET /blocks/{revision}?raw=true API endpoint. The header
+// can be decoded into a block.Header, and is the exact header signed by the block signer.
+// Transactions of the block are not included, use RawTransaction() to retrieve them.
+//
+// Parameters:
+//   - revision: Block identifier - "best", "justified", "finalized", block number, or block ID
+//
+// Returns:
+//   - *api.JSONRawBlockSummary: Block header in RLP-encoded hexadecimal format
+//   - error: Error if the request fails, revision is invalid, or httpclient.ErrNotFound if block not found
+//
+// Example:
+//
+//	raw, err := client.RawBlock("1000000")
+//	if err != nil {
+//		return err
+//	}
+//	data, err := hexutil.Decode(raw.Raw)
+//	if err != nil {
+//		return err
+//	}
+//	var header block.Header
+//	err = rlp.DecodeBytes(data, &header)
+func (c *Client) RawBlock(revision string) (*api.JSONRawBlockSummary, error) {
+	return c.httpConn.GetRawBlock(revision)
+}
+
 // ExpandedBlock retrieves block information by its revision with full transaction details.
 //
 // This method corresponds to the GET /blocks/{revision}?expanded=true API endpoint
@@ -18,6 +18,22 @@ type DerivableList interface {
 }
 
 func DeriveRoot(list DerivableList) thor.Bytes32 {
+	trie := deriveTrie(list)
+	return trie.Hash()
+}
+
+// DeriveProof constructs the merkle proof of the i-th item in list, against the root derived by DeriveRoot.
+func DeriveProof(list DerivableList, i int) ([][]byte, error) {
+	trie := deriveTrie(list)
+	return trie.Prove(DeriveKey(i))
+}
+
+// DeriveKey returns the trie key of the i-th item in list.
+func DeriveKey(i int) []byte {
+	return drlp.AppendUint(nil, uint64(i))
+}
+
+func deriveTrie(list DerivableList) *Trie {
 	var (
 		trie Trie
 		key  []byte
@@ -27,6 +43,5 @@ func DeriveRoot(list DerivableList) thor.Bytes32 {
 		key = drlp.AppendUint(key[:0], uint64(i))
 		trie.Update(key, list.EncodeIndex(i), nil)
 	}
-
-	return trie.Hash()
+	return &trie
 }
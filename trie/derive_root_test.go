@@ -7,6 +7,9 @@ package trie
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockedDerivableList struct {
@@ -18,6 +21,34 @@ func (l *mockedDerivableList) Len() int { return l.n }
 
 func (l *mockedDerivableList) EncodeIndex(i int) []byte { return l.content }
 
+type indexedList [][]byte
+
+func (l indexedList) Len() int                 { return len(l) }
+func (l indexedList) EncodeIndex(i int) []byte { return l[i] }
+
+func TestDeriveProof(t *testing.T) {
+	var list indexedList
+	for i := range 300 {
+		list = append(list, randBytes(i%40+1))
+	}
+	root := DeriveRoot(list)
+
+	for i := range list {
+		proof, err := DeriveProof(list, i)
+		require.NoError(t, err)
+		val, err := VerifyProof(root, DeriveKey(i), proof)
+		require.NoError(t, err)
+		assert.Equal(t, list[i], val)
+	}
+
+	// out of range
+	proof, err := DeriveProof(list, len(list))
+	require.NoError(t, err)
+	val, err := VerifyProof(root, DeriveKey(len(list)), proof)
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+}
+
 func BenchmarkDeriveRoot(b *testing.B) {
 	list := mockedDerivableList{
 		n:       100,
@@ -0,0 +1,236 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+// see "github.com/ethereum/go-ethereum/trie/proof.go"
+
+// Prove constructs a merkle proof for key. The result contains all consensus-encoded nodes
+// on the path to the value at key. The value itself is also included in the last
+// node and can be retrieved by verifying the proof.
+//
+// If the trie does not contain a value for key, the returned proof contains all
+// nodes of the longest existing prefix of the key (at least the root node), ending
+// with the node that proves the absence of the key.
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
+	var (
+		hexKey = keybytesToHex(key)
+		pos    int
+		nodes  []node
+		tn     = t.root
+	)
+	for pos < len(hexKey) && tn != nil {
+		switch n := tn.(type) {
+		case *shortNode:
+			if len(hexKey)-pos < len(n.key) || !bytes.Equal(n.key, hexKey[pos:pos+len(n.key)]) {
+				// the trie doesn't contain the key.
+				tn = nil
+			} else {
+				tn = n.child
+				pos += len(n.key)
+			}
+			nodes = append(nodes, n)
+		case *fullNode:
+			tn = n.children[hexKey[pos]]
+			pos++
+			nodes = append(nodes, n)
+		case *refNode:
+			resolved, err := t.resolveRef(n, hexKey[:pos])
+			if err != nil {
+				return nil, err
+			}
+			tn = resolved
+		case *valueNode:
+			tn = nil
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
+		}
+	}
+
+	h := hasherPool.Get().(*hasher)
+	defer hasherPool.Put(h)
+
+	proof := make([][]byte, 0, len(nodes))
+	for i, n := range nodes {
+		// nodes loaded from db have their own hash cached, but not those of embedded children,
+		// which are required for the consensus encoding.
+		switch n := n.(type) {
+		case *fullNode:
+			for _, cn := range n.children {
+				if cn != nil {
+					h.hash(cn, false)
+				}
+			}
+		case *shortNode:
+			h.hash(n.child, false)
+		}
+		// nodes smaller than 32 bytes are embedded in their parent, except the root
+		if hash := h.hash(n, i == 0); hash != nil {
+			proof = append(proof, n.encodeConsensus(nil))
+		}
+	}
+	return proof, nil
+}
+
+// VerifyProof checks merkle proofs. The given proof must contain the value for
+// key in a trie with the given root hash. VerifyProof returns an error if the
+// proof contains invalid trie nodes or the wrong value.
+//
+// A nil value with nil error is returned if the proof proves the absence of the key.
+func VerifyProof(root thor.Bytes32, key []byte, proof [][]byte) (value []byte, err error) {
+	if root == emptyRoot || root.IsZero() {
+		return nil, nil
+	}
+	db := make(map[thor.Bytes32][]byte, len(proof))
+	for _, enc := range proof {
+		db[thor.Blake2b(enc)] = enc
+	}
+
+	hexKey := keybytesToHex(key)
+	wantHash := root
+	for i := 0; ; i++ {
+		buf, ok := db[wantHash]
+		if !ok {
+			return nil, fmt.Errorf("proof node %d (hash %v) missing", i, wantHash)
+		}
+		n, err := decodeConsensusNode(buf)
+		if err != nil {
+			return nil, fmt.Errorf("bad proof node %d: %v", i, err)
+		}
+		rest, cn := getProofChild(n, hexKey)
+		switch cn := cn.(type) {
+		case nil:
+			// the trie doesn't contain the key.
+			return nil, nil
+		case *refNode:
+			hexKey = rest
+			wantHash = thor.BytesToBytes32(cn.hash)
+		case *valueNode:
+			return cn.val, nil
+		}
+	}
+}
+
+// getProofChild walks the node along the key, and returns the
+// remaining key and the reached ref node or value node.
+func getProofChild(tn node, key []byte) ([]byte, node) {
+	for {
+		switch n := tn.(type) {
+		case *shortNode:
+			if len(key) < len(n.key) || !bytes.Equal(n.key, key[:len(n.key)]) {
+				return nil, nil
+			}
+			tn = n.child
+			key = key[len(n.key):]
+		case *fullNode:
+			if len(key) == 0 {
+				return nil, nil
+			}
+			tn = n.children[key[0]]
+			key = key[1:]
+		case *refNode:
+			return key, n
+		case *valueNode:
+			return nil, n
+		case nil:
+			return nil, nil
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
+		}
+	}
+}
+
+// decodeConsensusNode parses a node in consensus encoding.
+// Nodes referenced by hash are decoded as ref nodes with only the hash field.
+func decodeConsensusNode(buf []byte) (node, error) {
+	elems, _, err := rlp.SplitList(buf)
+	if err != nil {
+		return nil, err
+	}
+	switch c, _ := rlp.CountValues(elems); c {
+	case 2:
+		return decodeConsensusShort(elems)
+	case 17:
+		return decodeConsensusFull(elems)
+	default:
+		return nil, fmt.Errorf("invalid number of list elements: %v", c)
+	}
+}
+
+func decodeConsensusShort(elems []byte) (node, error) {
+	compactKey, rest, err := rlp.SplitString(elems)
+	if err != nil {
+		return nil, err
+	}
+	n := &shortNode{key: compactToHex(compactKey)}
+	if hasTerm(n.key) {
+		val, _, err := rlp.SplitString(rest)
+		if err != nil {
+			return nil, err
+		}
+		n.child = &valueNode{val: val}
+		return n, nil
+	}
+	if n.child, _, err = decodeConsensusRef(rest); err != nil {
+		return nil, err
+	}
+	if n.child == nil {
+		return nil, errors.New("empty child of short node")
+	}
+	return n, nil
+}
+
+func decodeConsensusFull(elems []byte) (node, error) {
+	var (
+		n   fullNode
+		err error
+	)
+	for i := range 16 {
+		if n.children[i], elems, err = decodeConsensusRef(elems); err != nil {
+			return nil, err
+		}
+	}
+	val, _, err := rlp.SplitString(elems)
+	if err != nil {
+		return nil, err
+	}
+	if len(val) > 0 {
+		n.children[16] = &valueNode{val: val}
+	}
+	return &n, nil
+}
+
+func decodeConsensusRef(buf []byte) (node, []byte, error) {
+	kind, val, rest, err := rlp.Split(buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case kind == rlp.List:
+		// embedded node
+		if size := len(buf) - len(rest); size >= 32 {
+			return nil, nil, fmt.Errorf("oversized embedded node (size is %d bytes, want size < 32)", size)
+		}
+		n, err := decodeConsensusNode(buf[:len(buf)-len(rest)])
+		return n, rest, err
+	case kind == rlp.String && len(val) == 0:
+		// empty node
+		return nil, rest, nil
+	case kind == rlp.String && len(val) == 32:
+		return &refNode{hash: val}, rest, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid RLP string size %d (want 0 or 32)", len(val))
+	}
+}
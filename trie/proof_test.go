@@ -0,0 +1,121 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package trie
+
+import (
+	"crypto/rand"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+func randBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+func randomTrie(n int) (*Trie, map[string][]byte) {
+	var (
+		trie Trie
+		vals = make(map[string][]byte)
+	)
+	// some short values to produce embedded nodes
+	for i := range byte(100) {
+		k, v := []byte{i}, []byte{i}
+		trie.Update(k, v, nil)
+		vals[string(k)] = v
+	}
+	for range n {
+		k, v := randBytes(32), randBytes(mrand.Intn(64)+1)
+		trie.Update(k, v, randBytes(4))
+		vals[string(k)] = v
+	}
+	return &trie, vals
+}
+
+func TestProof(t *testing.T) {
+	trie, vals := randomTrie(500)
+	root := trie.Hash()
+
+	verify := func(trie *Trie) {
+		for k, v := range vals {
+			proof, err := trie.Prove([]byte(k))
+			require.NoError(t, err)
+			val, err := VerifyProof(root, []byte(k), proof)
+			require.NoError(t, err, "key %x", k)
+			assert.Equal(t, v, val, "key %x", k)
+		}
+	}
+
+	// in memory
+	verify(trie)
+
+	// loaded from db
+	db := newMemDatabase()
+	ver := Version{Major: 1}
+	require.NoError(t, trie.Commit(db, ver, false))
+	verify(New(Root{Hash: root, Ver: ver}, db))
+}
+
+func TestProofOfAbsence(t *testing.T) {
+	trie, _ := randomTrie(500)
+	root := trie.Hash()
+
+	for range 100 {
+		key := randBytes(32)
+		proof, err := trie.Prove(key)
+		require.NoError(t, err)
+		assert.NotEmpty(t, proof)
+		val, err := VerifyProof(root, key, proof)
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+	}
+
+	// empty trie
+	var empty Trie
+	proof, err := empty.Prove([]byte("k"))
+	assert.NoError(t, err)
+	assert.Empty(t, proof)
+}
+
+func TestBadProof(t *testing.T) {
+	trie, vals := randomTrie(500)
+	root := trie.Hash()
+
+	for k := range vals {
+		proof, err := trie.Prove([]byte(k))
+		require.NoError(t, err)
+		if len(proof) == 0 {
+			continue
+		}
+
+		// missing node
+		i := mrand.Intn(len(proof))
+		_, err = VerifyProof(root, []byte(k), append(proof[:i:i], proof[i+1:]...))
+		assert.Error(t, err)
+
+		// tampered node
+		tampered := append([]byte(nil), proof[i]...)
+		tampered[mrand.Intn(len(tampered))] ^= 1
+		proof[i] = tampered
+		_, err = VerifyProof(root, []byte(k), proof)
+		assert.Error(t, err)
+	}
+
+	// wrong root
+	for k := range vals {
+		proof, err := trie.Prove([]byte(k))
+		require.NoError(t, err)
+		_, err = VerifyProof(thor.Bytes32{1}, []byte(k), proof)
+		assert.Error(t, err)
+		break
+	}
+}
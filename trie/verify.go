@@ -0,0 +1,93 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+// VerifyNodes checks that the given nodes form a partial trie of the root hash, i.e. each node
+// hashes to the ref held by its parent, and no node is left unreachable. Nodes are keyed by their
+// paths, the same as read through DatabaseReader.
+//
+// Values of leaves reached are passed to onLeaf if not nil.
+func VerifyNodes(rootHash thor.Bytes32, nodes map[string][]byte, onLeaf func(val, meta []byte) error) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	rootBlob, ok := nodes[""]
+	if !ok {
+		return errors.New("missing root node")
+	}
+
+	var (
+		verified int
+		verify   func(path, hash, blob []byte) error
+		walk     func(n node, path []byte) error
+	)
+	verify = func(path, hash, blob []byte) error {
+		n, err := decodeUntrusted(blob)
+		if err != nil {
+			return fmt.Errorf("node %x: %w", path, err)
+		}
+		h := hasherPool.Get().(*hasher)
+		got := h.hash(n, true)
+		hasherPool.Put(h)
+		if !bytes.Equal(got, hash) {
+			return fmt.Errorf("node %x: hash mismatch", path)
+		}
+		verified++
+		return walk(n, path)
+	}
+	walk = func(n node, path []byte) error {
+		switch n := n.(type) {
+		case *fullNode:
+			for i, cn := range n.children {
+				if cn != nil {
+					if err := walk(cn, concat(path, byte(i))); err != nil {
+						return err
+					}
+				}
+			}
+		case *shortNode:
+			return walk(n.child, concat(path, n.key...))
+		case *refNode:
+			if blob, ok := nodes[string(path)]; ok {
+				return verify(path, n.hash, blob)
+			}
+		case *valueNode:
+			if onLeaf != nil {
+				return onLeaf(n.val, n.meta)
+			}
+		}
+		return nil
+	}
+
+	if err := verify(nil, rootHash[:], rootBlob); err != nil {
+		return err
+	}
+	if verified != len(nodes) {
+		return fmt.Errorf("%v nodes unreachable", len(nodes)-verified)
+	}
+	return nil
+}
+
+// decodeUntrusted decodes a node from untrusted source. The decoder assumes well-formed input and may
+// panic on malformed one.
+func decodeUntrusted(blob []byte) (n node, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed node: %v", r)
+		}
+	}()
+	// decode without ref, to have the hash computed
+	n, _, err = decodeNode(&refNode{}, blob, 0)
+	return
+}
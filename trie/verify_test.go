@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+// recordingDB records nodes read, keyed by path.
+type recordingDB struct {
+	DatabaseReader
+	nodes map[string][]byte
+}
+
+func (r *recordingDB) Get(path []byte, ver Version) ([]byte, error) {
+	blob, err := r.DatabaseReader.Get(path, ver)
+	if err == nil {
+		r.nodes[string(path)] = blob
+	}
+	return blob, err
+}
+
+func TestVerifyNodes(t *testing.T) {
+	db, tr, _ := makeTestTrie()
+	root := Root{Hash: tr.Hash(), Ver: Version{Major: 1}}
+
+	rec := &recordingDB{db, make(map[string][]byte)}
+	tr = New(root, rec)
+	for _, key := range [][]byte{{1, 3}, {5, 100}, {12, 254}} {
+		_, _, err := tr.Get(common.LeftPadBytes(key, 32))
+		assert.Nil(t, err)
+	}
+	assert.True(t, len(rec.nodes) > 1)
+
+	var leaves [][]byte
+	assert.Nil(t, VerifyNodes(root.Hash, rec.nodes, func(val, _ []byte) error {
+		leaves = append(leaves, val)
+		return nil
+	}))
+	assert.Contains(t, leaves, []byte{5, 100})
+	assert.Nil(t, VerifyNodes(root.Hash, nil, nil))
+
+	assert.Error(t, VerifyNodes(thor.Bytes32{1}, rec.nodes, nil), "root mismatch")
+
+	tampered := make(map[string][]byte)
+	for path, blob := range rec.nodes {
+		tampered[path] = blob
+	}
+	for path, blob := range rec.nodes {
+		if path != "" {
+			delete(tampered, path)
+			tampered[path+"\x00\x01"] = blob
+			break
+		}
+	}
+	assert.Error(t, VerifyNodes(root.Hash, tampered, nil), "unreachable node")
+
+	delete(rec.nodes, "")
+	assert.Error(t, VerifyNodes(root.Hash, rec.nodes, nil), "missing root")
+}
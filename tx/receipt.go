@@ -187,6 +187,28 @@ func (rs Receipts) RootHash() thor.Bytes32 {
 	return trie.DeriveRoot(derivableReceipts(rs))
 }
 
+// Prove constructs the merkle proof of the i-th receipt, against the receipts root.
+func (rs Receipts) Prove(i int) ([][]byte, error) {
+	return trie.DeriveProof(derivableReceipts(rs), i)
+}
+
+// VerifyReceiptProof verifies the merkle proof of the i-th receipt against the receipts root,
+// and returns the proved receipt. A nil receipt is returned if the proof proves its absence.
+func VerifyReceiptProof(root thor.Bytes32, i int, proof [][]byte) (*Receipt, error) {
+	data, err := trie.VerifyProof(root, trie.DeriveKey(i), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var r Receipt
+	if err := r.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
 // implements DerivableList
 type derivableReceipts Receipts
 
@@ -87,6 +87,39 @@ func TestEmptyRootHash(t *testing.T) {
 	}
 }
 
+func TestReceiptProof(t *testing.T) {
+	var receipts Receipts
+	for i := range 20 {
+		r := getMockReceipt(TypeLegacy)
+		if i%2 == 1 {
+			r = getMockReceipt(TypeDynamicFee)
+		}
+		r.GasUsed = uint64(i)
+		receipts = append(receipts, &r)
+	}
+	root := receipts.RootHash()
+
+	for i := range receipts {
+		proof, err := receipts.Prove(i)
+		assert.Nil(t, err)
+		r, err := VerifyReceiptProof(root, i, proof)
+		assert.Nil(t, err)
+		assert.Equal(t, receipts[i], r)
+	}
+
+	proof, err := receipts.Prove(0)
+	assert.Nil(t, err)
+	_, err = VerifyReceiptProof(thor.Bytes32{1}, 0, proof)
+	assert.NotNil(t, err)
+
+	// absence
+	proof, err = receipts.Prove(len(receipts))
+	assert.Nil(t, err)
+	r, err := VerifyReceiptProof(root, len(receipts), proof)
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+}
+
 func TestMarshalAndUnmarshalBinary(t *testing.T) {
 	for _, txType := range []Type{TypeLegacy, TypeDynamicFee} {
 		originalReceipt := getMockReceipt(txType)
@@ -24,6 +24,28 @@ func (txs Transactions) RootHash() thor.Bytes32 {
 	return trie.DeriveRoot(derivableTxs(txs))
 }
 
+// Prove constructs the merkle proof of the i-th transaction, against the txs root.
+func (txs Transactions) Prove(i int) ([][]byte, error) {
+	return trie.DeriveProof(derivableTxs(txs), i)
+}
+
+// VerifyTransactionProof verifies the merkle proof of the i-th transaction against the txs root,
+// and returns the proved transaction. A nil transaction is returned if the proof proves its absence.
+func VerifyTransactionProof(root thor.Bytes32, i int, proof [][]byte) (*Transaction, error) {
+	data, err := trie.VerifyProof(root, trie.DeriveKey(i), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var t Transaction
+	if err := t.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 // implements types.DerivableList
 type derivableTxs Transactions
 
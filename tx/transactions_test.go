@@ -180,3 +180,27 @@ func TestRootHash(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionProof(t *testing.T) {
+	txs := Transactions{GetMockTx(TypeLegacy), GetMockTx(TypeDynamicFee), GetMockTx(TypeLegacy)}
+	root := txs.RootHash()
+
+	for i := range txs {
+		proof, err := txs.Prove(i)
+		assert.NoError(t, err)
+		trx, err := VerifyTransactionProof(root, i, proof)
+		assert.NoError(t, err)
+		assert.Equal(t, txs[i].ID(), trx.ID())
+
+		// proof of a different index
+		_, err = VerifyTransactionProof(root, (i+1)%len(txs), proof)
+		assert.Error(t, err)
+	}
+
+	// proof of absence
+	proof, err := txs.Prove(len(txs))
+	assert.NoError(t, err)
+	trx, err := VerifyTransactionProof(root, len(txs), proof)
+	assert.NoError(t, err)
+	assert.Nil(t, trx)
+}
@@ -29,3 +29,15 @@ func IsTxRejected(err error) bool {
 	_, ok := err.(txRejectedError)
 	return ok
 }
+
+// dropReason returns the reason of tx dropped due to the error.
+func dropReason(err error) string {
+	switch e := err.(type) {
+	case badTxError:
+		return e.msg
+	case txRejectedError:
+		return e.msg
+	default:
+		return err.Error()
+	}
+}
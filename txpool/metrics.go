@@ -13,4 +13,6 @@ var (
 	metricTxPoolGauge            = metrics.LazyLoadGaugeVec("txpool_current_tx_count", []string{"source", "type"})
 	metricBadTxGauge             = metrics.LazyLoadGaugeVec("bad_tx_count", []string{"source"})
 	metricTxPoolExecutablesGauge = metrics.LazyLoadGauge("txpool_executable_tx_count")
+	metricTxPoolDisplacedGauge   = metrics.LazyLoadGaugeVec("txpool_displaced_tx_count", []string{"strategy"})
+	metricPackerDisplacedCounter = metrics.LazyLoadCounterVec("packer_displaced_tx_count", []string{"strategy"})
 )
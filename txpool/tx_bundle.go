@@ -0,0 +1,279 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	// max count of txs in a bundle
+	MaxBundleSize = 16
+	// max count of pending bundles
+	bundleLimit = 256
+	// count of bundles kept in the status journal
+	bundleJournalLimit = 4096
+)
+
+// DropReasonPartiallyPacked is the reason of bundles dropped since some members are packed without the others.
+const DropReasonPartiallyPacked = "partially packed"
+
+// Bundle is a group of txs, which are to be packed into the same block in order, or not at all.
+// Bundles are kept apart from individual txs, and never broadcast, so they are packed only by the local node.
+type Bundle struct {
+	ID        thor.Bytes32
+	Txs       tx.Transactions
+	timeAdded int64
+}
+
+// BundleID returns the id of the bundle made of the txs.
+func BundleID(txs tx.Transactions) thor.Bytes32 {
+	return thor.Blake2bFn(func(w io.Writer) {
+		for _, trx := range txs {
+			id := trx.ID()
+			w.Write(id[:])
+		}
+	})
+}
+
+// bundleSet holds pending bundles, and indexes their members.
+type bundleSet struct {
+	lock    sync.RWMutex
+	bundles map[thor.Bytes32]*Bundle
+	members map[thor.Bytes32]thor.Bytes32 // tx id => bundle id
+}
+
+func newBundleSet() *bundleSet {
+	return &bundleSet{
+		bundles: make(map[thor.Bytes32]*Bundle),
+		members: make(map[thor.Bytes32]thor.Bytes32),
+	}
+}
+
+// add adds the bundle, and returns false if it's already there.
+func (s *bundleSet) add(b *Bundle) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, found := s.bundles[b.ID]; found {
+		return false, nil
+	}
+	if len(s.bundles) >= bundleLimit {
+		return false, txRejectedError{"bundle pool is full"}
+	}
+	for i, trx := range b.Txs {
+		if _, found := s.members[trx.ID()]; found {
+			return false, txRejectedError{fmt.Sprintf("bundle member %d already in another bundle", i)}
+		}
+	}
+	s.bundles[b.ID] = b
+	for _, trx := range b.Txs {
+		s.members[trx.ID()] = b.ID
+	}
+	return true, nil
+}
+
+func (s *bundleSet) remove(id thor.Bytes32) *Bundle {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, found := s.bundles[id]
+	if !found {
+		return nil
+	}
+	delete(s.bundles, id)
+	for _, trx := range b.Txs {
+		delete(s.members, trx.ID())
+	}
+	return b
+}
+
+func (s *bundleSet) containsTx(txID thor.Bytes32) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, found := s.members[txID]
+	return found
+}
+
+// list returns pending bundles, from the earliest added.
+func (s *bundleSet) list() []*Bundle {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	bundles := make([]*Bundle, 0, len(s.bundles))
+	for _, b := range s.bundles {
+		bundles = append(bundles, b)
+	}
+	slices.SortFunc(bundles, func(a, b *Bundle) int {
+		return cmp.Or(cmp.Compare(a.timeAdded, b.timeAdded), slices.Compare(a.ID[:], b.ID[:]))
+	})
+	return bundles
+}
+
+func (s *bundleSet) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.bundles)
+}
+
+// AddBundle adds locally submitted txs as a bundle, and returns the bundle id.
+// Members are validated one by one, but not for executability, which relies on the former members.
+func (p *TxPool) AddBundle(txs tx.Transactions) (thor.Bytes32, error) {
+	switch {
+	case len(txs) == 0:
+		return thor.Bytes32{}, badTxError{"empty bundle"}
+	case len(txs) > MaxBundleSize:
+		return thor.Bytes32{}, txRejectedError{"bundle too large"}
+	}
+
+	var (
+		headSummary = p.repo.BestBlockSummary()
+		chain       = p.repo.NewChain(headSummary.Header.ID())
+		seen        = make(map[thor.Bytes32]bool, len(txs))
+	)
+	for i, trx := range txs {
+		if err := p.validateBundleMember(chain, headSummary.Header.Number()+1, trx, seen); err != nil {
+			switch e := err.(type) {
+			case badTxError:
+				return thor.Bytes32{}, badTxError{fmt.Sprintf("bundle member %d: %s", i, e.msg)}
+			case txRejectedError:
+				return thor.Bytes32{}, txRejectedError{fmt.Sprintf("bundle member %d: %s", i, e.msg)}
+			default:
+				return thor.Bytes32{}, err
+			}
+		}
+	}
+
+	b := &Bundle{
+		ID:        BundleID(txs),
+		Txs:       slices.Clone(txs),
+		timeAdded: time.Now().UnixNano(),
+	}
+	if added, err := p.bundles.add(b); err != nil {
+		return thor.Bytes32{}, err
+	} else if added {
+		p.bundleJournal.record(b.ID, TxTransition{Status: TxStatusAdded})
+		logger.Debug("bundle added", "id", b.ID, "len", len(txs))
+	}
+	return b.ID, nil
+}
+
+func (p *TxPool) validateBundleMember(chain *chain.Chain, nextBlockNum uint32, trx *tx.Transaction, seen map[thor.Bytes32]bool) error {
+	if err := p.validateTxBasics(trx); err != nil {
+		return err
+	}
+	txObj, err := ResolveTx(trx, true)
+	if err != nil {
+		return badTxError{err.Error()}
+	}
+	if p.blockedTxObj(txObj) {
+		return txRejectedError{"blocked"}
+	}
+	if seen[txObj.ID()] {
+		return badTxError{"duplicated"}
+	}
+	seen[txObj.ID()] = true
+
+	if trx.IsExpired(nextBlockNum) {
+		return badTxError{"expired"}
+	}
+	if p.all.ContainsHash(trx.Hash()) {
+		return txRejectedError{"already in the pool"}
+	}
+	if found, err := chain.HasTransaction(txObj.ID(), trx.BlockRef().Number()); err != nil {
+		return err
+	} else if found {
+		return badTxError{"known tx"}
+	}
+	return nil
+}
+
+// blockedTxObj returns whether the origin or delegator of the tx is blocked.
+func (p *TxPool) blockedTxObj(txObj *TxObject) bool {
+	if thor.IsOriginBlocked(txObj.Origin()) || p.blocklist.Contains(txObj.Origin()) {
+		return true
+	}
+	delegator := txObj.Delegator()
+	return delegator != nil && (thor.IsOriginBlocked(*delegator) || p.blocklist.Contains(*delegator))
+}
+
+// Bundles returns pending bundles, from the earliest added.
+func (p *TxPool) Bundles() []*Bundle {
+	return p.bundles.list()
+}
+
+// DropBundle drops the pending bundle with the reason, e.g. the error of adopting it.
+func (p *TxPool) DropBundle(id thor.Bytes32, reason string) bool {
+	if p.bundles.remove(id) == nil {
+		return false
+	}
+	p.bundleJournal.record(id, TxTransition{Status: TxStatusDropped, Reason: reason})
+	logger.Debug("bundle dropped", "id", id, "reason", reason)
+	return true
+}
+
+// GetBundleStatus returns recorded state transitions of the bundle, from the oldest to the latest.
+func (p *TxPool) GetBundleStatus(id thor.Bytes32) []TxTransition {
+	return p.bundleJournal.get(id)
+}
+
+// washBundles settles bundles packed, and drops ones no longer able to be packed as a whole.
+// this method should only be called in housekeeping go routine
+func (p *TxPool) washBundles(headSummary *chain.BlockSummary) {
+	var (
+		chain        = p.repo.NewChain(headSummary.Header.ID())
+		nextBlockNum = headSummary.Header.Number() + 1
+		now          = time.Now().UnixNano()
+	)
+	for _, b := range p.bundles.list() {
+		var (
+			packed int
+			reason string
+		)
+		for _, trx := range b.Txs {
+			if found, err := chain.HasTransaction(trx.ID(), trx.BlockRef().Number()); err != nil {
+				logger.Warn("failed to wash bundle", "id", b.ID, "err", err)
+				return
+			} else if found {
+				packed++
+			} else if trx.IsExpired(nextBlockNum) {
+				reason = "expired"
+			}
+		}
+
+		switch {
+		case packed == len(b.Txs):
+			if t, ok := packedIn(chain, b.Txs[len(b.Txs)-1].ID()); ok && p.bundles.remove(b.ID) != nil {
+				p.bundleJournal.record(b.ID, t)
+				logger.Debug("bundle packed", "id", b.ID, "block", t.BlockID)
+			}
+		case packed > 0:
+			p.DropBundle(b.ID, DropReasonPartiallyPacked)
+		case reason != "":
+			p.DropBundle(b.ID, reason)
+		case now > b.timeAdded+int64(p.options.MaxLifetime):
+			p.DropBundle(b.ID, DropReasonLifetime)
+		default:
+			for _, trx := range b.Txs {
+				if txObj, err := ResolveTx(trx, true); err == nil && p.blockedTxObj(txObj) {
+					p.DropBundle(b.ID, DropReasonBlocked)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestAddBundle(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+
+	chainTag := pool.repo.ChainTag()
+	trx1 := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	trx2 := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[1])
+	trx3 := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[2])
+	expired := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 0, nil, tx.Features(0), devAccounts[3])
+	badChainTag := newTx(tx.TypeLegacy, chainTag+1, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[3])
+
+	_, err := pool.AddBundle(nil)
+	assert.EqualError(t, err, "bad tx: empty bundle")
+	_, err = pool.AddBundle(make(tx.Transactions, MaxBundleSize+1))
+	assert.EqualError(t, err, "tx rejected: bundle too large")
+	_, err = pool.AddBundle(tx.Transactions{trx1, badChainTag})
+	assert.EqualError(t, err, "bad tx: bundle member 1: chain tag mismatch")
+	_, err = pool.AddBundle(tx.Transactions{trx1, trx1})
+	assert.EqualError(t, err, "bad tx: bundle member 1: duplicated")
+	_, err = pool.AddBundle(tx.Transactions{expired})
+	assert.EqualError(t, err, "bad tx: bundle member 0: expired")
+
+	pool.Fill(tx.Transactions{trx3})
+	_, err = pool.AddBundle(tx.Transactions{trx1, trx3})
+	assert.EqualError(t, err, "tx rejected: bundle member 1: already in the pool")
+
+	id, err := pool.AddBundle(tx.Transactions{trx1, trx2})
+	require.NoError(t, err)
+	assert.Equal(t, BundleID(tx.Transactions{trx1, trx2}), id)
+	assert.NotEqual(t, BundleID(tx.Transactions{trx2, trx1}), id)
+
+	// adding again is not an error
+	_, err = pool.AddBundle(tx.Transactions{trx1, trx2})
+	assert.NoError(t, err)
+	_, err = pool.AddBundle(tx.Transactions{trx2})
+	assert.EqualError(t, err, "tx rejected: bundle member 0 already in another bundle")
+
+	// members are not added individually, nor packed as executables
+	assert.NoError(t, pool.Add(trx1))
+	assert.Nil(t, pool.Get(trx1.ID()))
+
+	bundles := pool.Bundles()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, id, bundles[0].ID)
+	assert.Equal(t, tx.Transactions{trx1, trx2}, bundles[0].Txs)
+
+	assert.True(t, pool.DropBundle(id, "bad tx: insufficient energy"))
+	assert.False(t, pool.DropBundle(id, "dropped again"))
+	assert.Empty(t, pool.Bundles())
+
+	transitions := pool.GetBundleStatus(id)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, TxStatusAdded, transitions[0].Status)
+	assert.Equal(t, TxStatusDropped, transitions[1].Status)
+	assert.Equal(t, "bad tx: insufficient energy", transitions[1].Reason)
+	assert.Nil(t, pool.GetBundleStatus(thor.Bytes32{}))
+
+	// members are free once the bundle is dropped
+	_, err = pool.AddBundle(tx.Transactions{trx2})
+	assert.NoError(t, err)
+}
+
+func TestWashBundles(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+
+	chainTag := pool.repo.ChainTag()
+	newMember := func(i int, expiration uint32) *tx.Transaction {
+		return newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, expiration, nil, tx.Features(0), devAccounts[i])
+	}
+	trx1, trx2, trx3, trx4 := newMember(0, 100), newMember(1, 100), newMember(2, 100), newMember(3, 100)
+	expiring := newMember(4, 1)
+
+	packedID, err := pool.AddBundle(tx.Transactions{trx1, trx2})
+	require.NoError(t, err)
+	partialID, err := pool.AddBundle(tx.Transactions{trx3, trx4})
+	require.NoError(t, err)
+	expiredID, err := pool.AddBundle(tx.Transactions{expiring})
+	require.NoError(t, err)
+
+	best := pool.repo.BestBlockSummary().Header
+	b1 := new(block.Builder).
+		ParentID(best.ID()).
+		Timestamp(best.Timestamp() + thor.BlockInterval()).
+		TotalScore(best.TotalScore() + 1).
+		GasLimit(best.GasLimit()).
+		Transaction(trx1).
+		Transaction(trx2).
+		Transaction(trx3).
+		Build()
+	require.NoError(t, pool.repo.AddBlock(b1, tx.Receipts{&tx.Receipt{}, &tx.Receipt{}, &tx.Receipt{}}, 0, true))
+
+	pool.washBundles(pool.repo.BestBlockSummary())
+	assert.Empty(t, pool.Bundles())
+
+	latest := func(id thor.Bytes32) TxTransition {
+		transitions := pool.GetBundleStatus(id)
+		return transitions[len(transitions)-1]
+	}
+	assert.Equal(t, TxStatusPacked, latest(packedID).Status)
+	assert.Equal(t, b1.Header().ID(), *latest(packedID).BlockID)
+	assert.Equal(t, TxTransition{Status: TxStatusDropped, Reason: DropReasonPartiallyPacked}, withoutTime(latest(partialID)))
+	assert.Equal(t, TxTransition{Status: TxStatusDropped, Reason: "expired"}, withoutTime(latest(expiredID)))
+}
+
+func withoutTime(t TxTransition) TxTransition {
+	t.Timestamp = 0
+	return t
+}
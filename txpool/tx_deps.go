@@ -0,0 +1,112 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"math/big"
+
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// DropReasonDepDropped is the reason of txs dropped since the tx they depend on is dropped.
+const DropReasonDepDropped = "dependency dropped"
+
+// TxDeps is the place of a pooled tx in the dependency graph built by tx DependsOn.
+type TxDeps struct {
+	DependsOn     *thor.Bytes32  // the tx depended on, if any
+	DepPending    bool           // whether the tx depended on is pending in the pool, rather than packed or unknown
+	DepExecutable bool           // whether the tx depended on is executable in the pool
+	Dependents    []thor.Bytes32 // txs in the pool depending on it
+}
+
+// GetDependencies returns the dependencies of the tx in the pool, or nil if the tx is not in the pool.
+func (p *TxPool) GetDependencies(id thor.Bytes32) *TxDeps {
+	txObj := p.all.GetByID(id)
+	if txObj == nil {
+		return nil
+	}
+
+	deps := &TxDeps{DependsOn: txObj.DependsOn()}
+	if deps.DependsOn != nil {
+		deps.DepPending = p.all.GetByID(*deps.DependsOn) != nil
+		deps.DepExecutable = p.depExecutable(txObj)
+	}
+	for _, dependent := range p.all.GetDependents(id) {
+		deps.Dependents = append(deps.Dependents, dependent.ID())
+	}
+	return deps
+}
+
+// depExecutable returns whether the tx it depends on is executable in the pool, as of the last wash.
+func (p *TxPool) depExecutable(txObj *TxObject) bool {
+	if dep := txObj.DependsOn(); dep != nil {
+		priorities, _ := p.priorities.Load().(map[thor.Bytes32]*big.Int)
+		_, ok := priorities[*dep]
+		return ok && p.all.GetByID(*dep) != nil
+	}
+	return false
+}
+
+// dropDependents removes txs depending on the dropped tx of the given id, all the way down the dependency graph,
+// since they can never be packed.
+func (p *TxPool) dropDependents(id thor.Bytes32) {
+	var events []*TxEvent
+	for queue := []thor.Bytes32{id}; len(queue) > 0; queue = queue[1:] {
+		for _, dependent := range p.all.GetDependents(queue[0]) {
+			if !p.all.RemoveByHash(dependent.Hash()) {
+				continue
+			}
+			txTypeString := "Legacy"
+			if dependent.Type() == tx.TypeDynamicFee {
+				txTypeString = "DynamicFee"
+			}
+			metricTxPoolGauge().AddWithLabel(-1, map[string]string{"source": "n/a", "type": txTypeString})
+			logger.Debug("tx dropped along with dep", "id", dependent.ID(), "dep", queue[0])
+
+			events = p.appendTransit(events, dependent.Transaction, TxTransition{Status: TxStatusDropped, Reason: DropReasonDepDropped})
+			queue = append(queue, dependent.ID())
+		}
+	}
+	if len(events) > 0 {
+		p.goes.Go(func() {
+			for _, ev := range events {
+				p.txFeed.Send(ev)
+			}
+		})
+	}
+}
+
+// orderByDeps moves txs behind the ones they depend on in the list, and keeps the order otherwise.
+func orderByDeps(txObjs []*TxObject) {
+	listed := make(map[thor.Bytes32]bool, len(txObjs))
+	for _, txObj := range txObjs {
+		listed[txObj.ID()] = true
+	}
+
+	var (
+		ordered = make([]*TxObject, 0, len(txObjs))
+		placed  = make(map[thor.Bytes32]bool, len(txObjs))
+		waiting = make(map[thor.Bytes32][]*TxObject) // id of tx depended on => dependents waiting for it
+		place   func(txObj *TxObject)
+	)
+	place = func(txObj *TxObject) {
+		ordered = append(ordered, txObj)
+		placed[txObj.ID()] = true
+		for _, dependent := range waiting[txObj.ID()] {
+			place(dependent)
+		}
+		delete(waiting, txObj.ID())
+	}
+	for _, txObj := range txObjs {
+		if dep := txObj.DependsOn(); dep != nil && listed[*dep] && !placed[*dep] {
+			waiting[*dep] = append(waiting[*dep], txObj)
+			continue
+		}
+		place(txObj)
+	}
+	copy(txObjs, ordered)
+}
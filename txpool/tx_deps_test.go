@@ -0,0 +1,42 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestOrderByDeps(t *testing.T) {
+	newObj := func(dependsOn *thor.Bytes32) *TxObject {
+		trx := newTx(tx.TypeLegacy, 0, nil, 21000, tx.BlockRef{}, 100, dependsOn, tx.Features(0), devAccounts[0])
+		obj, err := ResolveTx(trx, false)
+		require.NoError(t, err)
+		return obj
+	}
+	idOf := func(obj *TxObject) *thor.Bytes32 {
+		id := obj.ID()
+		return &id
+	}
+
+	a := newObj(nil)
+	b := newObj(idOf(a))
+	c := newObj(idOf(b))
+	d := newObj(&thor.Bytes32{1})
+
+	objs := []*TxObject{c, d, b, a}
+	orderByDeps(objs)
+	assert.Equal(t, []*TxObject{d, a, b, c}, objs)
+
+	// already in order
+	orderByDeps(objs)
+	assert.Equal(t, []*TxObject{d, a, b, c}, objs)
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// journaledTx is a pooled tx persisted by the journal.
+type journaledTx struct {
+	Tx         *tx.Transaction
+	Executable bool
+	Local      bool
+	TimeAdded  uint64 // unix nano
+}
+
+// Journal periodically persists all txs in the pool, along with their executable status, and restores them into
+// the pool on startup, so that pending txs survive restarts.
+type Journal struct {
+	pool     *TxPool
+	db       *leveldb.DB
+	interval time.Duration
+	saved    map[thor.Bytes32]bool // ids of txs persisted => executable status persisted
+
+	ctx    context.Context
+	cancel func()
+	goes   co.Goes
+}
+
+// NewJournal opens the journal at the given path, and starts to restore journaled txs into the pool once the chain
+// is synced, then to persist the pool at the given interval.
+func NewJournal(pool *TxPool, path string, interval time.Duration) (*Journal, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &Journal{
+		pool:     pool,
+		db:       db,
+		interval: interval,
+		saved:    make(map[thor.Bytes32]bool),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	j.goes.Go(j.loop)
+	return j, nil
+}
+
+// Stop stops the periodical persisting, persists the pool for the last time and closes the db.
+func (j *Journal) Stop() {
+	j.cancel()
+	j.goes.Wait()
+	if err := j.persist(); err != nil {
+		logger.Warn("persist txpool journal", "err", err)
+	}
+	if err := j.db.Close(); err != nil {
+		logger.Warn("close txpool journal db", "err", err)
+	}
+}
+
+// restore adds journaled txs back into the pool. Txs are validated against the synced head as newly added ones, and
+// previously executable txs go first, so they are not crowded out by non-executables.
+func (j *Journal) restore() {
+	var (
+		entries []*journaledTx
+		batch   leveldb.Batch
+	)
+
+	it := j.db.NewIterator(util.BytesPrefix(nil), nil)
+	for it.Next() {
+		var entry journaledTx
+		if err := rlp.DecodeBytes(it.Value(), &entry); err != nil {
+			logger.Warn("decode journaled tx", "err", err)
+			batch.Delete(it.Key())
+		} else {
+			entries = append(entries, &entry)
+		}
+	}
+	it.Release()
+
+	slices.SortStableFunc(entries, func(a, b *journaledTx) int {
+		if a.Executable != b.Executable {
+			if a.Executable {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.TimeAdded, b.TimeAdded)
+	})
+
+	var restored int
+	for _, entry := range entries {
+		id := entry.Tx.ID()
+		if err := j.pool.addWithOptions(entry.Tx, false, entry.Local, addOptions{timeAdded: int64(entry.TimeAdded)}); err != nil {
+			logger.Debug("journaled tx rejected by pool", "id", id, "err", err)
+		}
+		if j.pool.all.ContainsHash(entry.Tx.Hash()) {
+			j.saved[id] = entry.Executable
+			restored++
+		} else {
+			batch.Delete(id.Bytes())
+		}
+	}
+	if err := j.db.Write(&batch, nil); err != nil {
+		logger.Warn("remove journaled txs not restored", "err", err)
+	}
+	logger.Info("restored txs from journal", "restored", restored, "journaled", len(entries))
+}
+
+func (j *Journal) loop() {
+	// restored once synced, since the pool skips validations relying on the head until then
+	headTicker := j.pool.repo.NewTicker()
+	for !isChainSynced(uint64(time.Now().Unix()), j.pool.repo.BestBlockSummary().Header.Timestamp()) {
+		select {
+		case <-j.ctx.Done():
+			return
+		case <-headTicker.C():
+		}
+	}
+	j.restore()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.persist(); err != nil {
+				logger.Warn("persist txpool journal", "err", err)
+			}
+		}
+	}
+}
+
+// persist writes txs newly added or with executable status changed since the last persisting, and deletes txs no
+// longer in the pool. Executable status is taken as of the last wash.
+func (j *Journal) persist() error {
+	executables := make(map[thor.Bytes32]bool)
+	for _, trx := range j.pool.Executables() {
+		executables[trx.ID()] = true
+	}
+
+	var (
+		batch leveldb.Batch
+		saved = make(map[thor.Bytes32]bool)
+	)
+	for _, txObj := range j.pool.all.ToTxObjects() {
+		id := txObj.ID()
+		executable := executables[id]
+		saved[id] = executable
+		if wasExecutable, ok := j.saved[id]; ok && wasExecutable == executable {
+			continue
+		}
+		data, err := rlp.EncodeToBytes(&journaledTx{
+			Tx:         txObj.Transaction,
+			Executable: executable,
+			Local:      txObj.localSubmitted,
+			TimeAdded:  uint64(txObj.timeAdded),
+		})
+		if err != nil {
+			return err
+		}
+		batch.Put(id.Bytes(), data)
+	}
+	for id := range j.saved {
+		if _, ok := saved[id]; !ok {
+			batch.Delete(id.Bytes())
+		}
+	}
+
+	if err := j.db.Write(&batch, nil); err != nil {
+		return err
+	}
+	j.saved = saved
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// putJournaled writes entries into the journal db at the path.
+func putJournaled(t *testing.T, path string, entries ...*journaledTx) {
+	db, err := leveldb.OpenFile(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, entry := range entries {
+		data, err := rlp.EncodeToBytes(entry)
+		require.NoError(t, err)
+		require.NoError(t, db.Put(entry.Tx.ID().Bytes(), data, nil))
+	}
+}
+
+// journaledIDs returns ids of txs in the journal db at the path.
+func journaledIDs(t *testing.T, path string) []thor.Bytes32 {
+	db, err := leveldb.OpenFile(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var ids []thor.Bytes32
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		ids = append(ids, thor.BytesToBytes32(it.Key()))
+	}
+	return ids
+}
+
+func TestJournal(t *testing.T) {
+	// pool metrics are bound on the first use, which must come after TestTxPoolMetrics initializes them,
+	// and parallel tests are run after all sequential ones
+	t.Parallel()
+
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+
+	path := t.TempDir()
+	journal, err := NewJournal(pool, path, time.Hour)
+	require.NoError(t, err)
+
+	chainTag := pool.repo.ChainTag()
+	executable := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	orphan := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, &thor.Bytes32{1}, tx.Features(0), devAccounts[1])
+	require.NoError(t, pool.AddLocal(executable))
+	require.NoError(t, pool.Add(orphan))
+	assert.Eventually(t, func() bool { return len(pool.Executables()) == 1 }, 5*time.Second, 100*time.Millisecond)
+	journal.Stop()
+
+	// entries can't be restored
+	badChainTag := newTx(tx.TypeLegacy, chainTag+1, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[2])
+	putJournaled(t, path, &journaledTx{Tx: badChainTag, Executable: true})
+	db, err := leveldb.OpenFile(path, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Put([]byte("broken"), []byte{0x1}, nil))
+	require.NoError(t, db.Close())
+
+	restoredPool := New(pool.repo, pool.stater, pool.options, &thor.NoFork)
+	defer restoredPool.Close()
+	journal, err = NewJournal(restoredPool, path, time.Hour)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return len(restoredPool.Executables()) == 1 }, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, 2, restoredPool.Len())
+	for _, trx := range []*tx.Transaction{executable, orphan} {
+		restored := restoredPool.all.GetByID(trx.ID())
+		require.NotNil(t, restored)
+		original := pool.all.GetByID(trx.ID())
+		assert.Equal(t, original.localSubmitted, restored.localSubmitted)
+		assert.Equal(t, original.timeAdded, restored.timeAdded)
+	}
+
+	// txs left the pool are removed from the journal
+	restoredPool.Remove(orphan.Hash(), orphan.ID())
+	journal.Stop()
+	assert.Equal(t, map[thor.Bytes32]bool{executable.ID(): true}, journal.saved)
+	assert.Equal(t, []thor.Bytes32{executable.ID()}, journaledIDs(t, path))
+}
+
+func TestJournalRestoreUnsynced(t *testing.T) {
+	// see TestJournal
+	t.Parallel()
+
+	// the head is an hour old, so the pool is not synced
+	pool := newPoolWithParams(LIMIT, LIMIT_PER_ACCOUNT, "", "", uint64(time.Now().Unix())-3600, &thor.NoFork)
+	defer pool.Close()
+
+	chainTag := pool.repo.ChainTag()
+	valid := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	// expires once the synced block is added
+	expired := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 1, nil, tx.Features(0), devAccounts[1])
+
+	path := t.TempDir()
+	putJournaled(t, path,
+		&journaledTx{Tx: valid, Executable: true},
+		&journaledTx{Tx: expired, Executable: true},
+	)
+	journal, err := NewJournal(pool, path, time.Hour)
+	require.NoError(t, err)
+
+	// held until synced, rather than added without validations against the head
+	assert.Never(t, func() bool { return pool.Len() > 0 }, 500*time.Millisecond, 50*time.Millisecond)
+
+	addSyncedBlock(t, pool)
+	assert.Eventually(t, func() bool { return pool.Get(valid.ID()) != nil }, 5*time.Second, 50*time.Millisecond)
+	journal.Stop()
+
+	assert.Nil(t, pool.Get(expired.ID()))
+	assert.Equal(t, []thor.Bytes32{valid.ID()}, journaledIDs(t, path))
+}
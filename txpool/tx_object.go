@@ -8,6 +8,7 @@ package txpool
 import (
 	"math/big"
 	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -22,10 +23,12 @@ import (
 
 type TxObject struct {
 	*tx.Transaction
-	resolved *runtime.ResolvedTransaction
+	resolved   *runtime.ResolvedTransaction
+	replaceKey thor.Bytes32 // locally submitted txs replace pending ones with the same key
 
 	timeAdded      int64
 	localSubmitted bool          // tx is submitted locally on this node, or synced remotely from p2p.
+	announced      atomic.Bool   // tx is posted as executable, hence broadcast to peers
 	payer          *thor.Address // payer of the tx, either origin, delegator, or on-chain delegation payer
 	cost           *big.Int      // total tx cost the payer needs to pay before execution(gas price * gas)
 
@@ -42,9 +45,15 @@ func ResolveTx(tx *tx.Transaction, localSubmitted bool) (*TxObject, error) {
 		return nil, err
 	}
 
+	replaceKey, err := replaceKeyOf(tx, resolved.Origin)
+	if err != nil {
+		return nil, err
+	}
+
 	return &TxObject{
 		Transaction:    tx,
 		resolved:       resolved,
+		replaceKey:     replaceKey,
 		timeAdded:      time.Now().UnixNano(),
 		localSubmitted: localSubmitted,
 	}, nil
@@ -66,7 +75,22 @@ func (o *TxObject) Payer() *thor.Address {
 	return o.payer
 }
 
+var errKnownTx = errors.New("known tx")
+
 func (o *TxObject) Executable(chain *chain.Chain, state *state.State, headBlock *block.Header, forkConfig *thor.ForkConfig, baseFee *big.Int) (bool, error) {
+	return o.executableAfterDep(chain, state, headBlock, forkConfig, baseFee, false)
+}
+
+// executableAfterDep is like Executable, while the tx it depends on is taken as found if depExecutable is true,
+// i.e. the dep is executable in the pool, to be packed ahead in the same block.
+func (o *TxObject) executableAfterDep(
+	chain *chain.Chain,
+	state *state.State,
+	headBlock *block.Header,
+	forkConfig *thor.ForkConfig,
+	baseFee *big.Int,
+	depExecutable bool,
+) (bool, error) {
 	// evaluate the tx on the next block as head block is already history
 	nextBlockNum := headBlock.Number() + 1
 	nextBlockTime := headBlock.Timestamp() + thor.BlockInterval()
@@ -96,10 +120,10 @@ func (o *TxObject) Executable(chain *chain.Chain, state *state.State, headBlock
 	if has, err := chain.HasTransaction(o.ID(), o.BlockRef().Number()); err != nil {
 		return false, err
 	} else if has {
-		return false, errors.New("known tx")
+		return false, errKnownTx
 	}
 
-	if dep := o.DependsOn(); dep != nil {
+	if dep := o.DependsOn(); dep != nil && !depExecutable {
 		txMeta, err := chain.GetTransactionMeta(*dep)
 		if err != nil {
 			if chain.IsNotFound(err) {
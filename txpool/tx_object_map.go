@@ -19,6 +19,8 @@ type txObjectMap struct {
 	lock      sync.RWMutex
 	mapByHash map[thor.Bytes32]*TxObject
 	mapByID   map[thor.Bytes32]*TxObject
+	mapByKey  map[thor.Bytes32]*TxObject                  // replace key => tx object
+	mapByDep  map[thor.Bytes32]map[thor.Bytes32]*TxObject // id of tx depended on => id of dependent => tx object
 	quota     map[thor.Address]int
 	cost      map[thor.Address]*big.Int
 }
@@ -27,6 +29,8 @@ func newTxObjectMap() *txObjectMap {
 	return &txObjectMap{
 		mapByHash: make(map[thor.Bytes32]*TxObject),
 		mapByID:   make(map[thor.Bytes32]*TxObject),
+		mapByKey:  make(map[thor.Bytes32]*TxObject),
+		mapByDep:  make(map[thor.Bytes32]map[thor.Bytes32]*TxObject),
 		quota:     make(map[thor.Address]int),
 		cost:      make(map[thor.Address]*big.Int),
 	}
@@ -43,6 +47,31 @@ func (m *txObjectMap) Add(txObj *TxObject, limitPerAccount int, validatePayer fu
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	return m.add(txObj, limitPerAccount, validatePayer)
+}
+
+// Replace replaces the old tx object with the new one. The old one is kept if the new one can't be added.
+func (m *txObjectMap) Replace(
+	txObj *TxObject,
+	old *TxObject,
+	limitPerAccount int,
+	validatePayer func(payer thor.Address, needs *big.Int) error,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.mapByHash[old.Hash()] != old {
+		return errors.New("tx to replace not found")
+	}
+	m.remove(old.Hash())
+	if err := m.add(txObj, limitPerAccount, validatePayer); err != nil {
+		m.insert(old)
+		return err
+	}
+	return nil
+}
+
+func (m *txObjectMap) add(txObj *TxObject, limitPerAccount int, validatePayer func(payer thor.Address, needs *big.Int) error) error {
 	hash := txObj.Hash()
 	if _, found := m.mapByHash[hash]; found {
 		return nil
@@ -90,19 +119,92 @@ func (m *txObjectMap) Add(txObj *TxObject, limitPerAccount int, validatePayer fu
 
 	m.mapByHash[hash] = txObj
 	m.mapByID[txObj.ID()] = txObj
+	m.mapByKey[txObj.replaceKey] = txObj
+	m.link(txObj)
 	return nil
 }
 
+// insert puts back the tx object removed, with its quota and pending cost.
+func (m *txObjectMap) insert(txObj *TxObject) {
+	m.quota[txObj.Origin()]++
+	if delegator := txObj.Delegator(); delegator != nil {
+		m.quota[*delegator]++
+	}
+	if payer := txObj.Payer(); payer != nil && txObj.Cost() != nil {
+		if pending := m.cost[*payer]; pending != nil {
+			m.cost[*payer] = new(big.Int).Add(pending, txObj.Cost())
+		} else {
+			m.cost[*payer] = new(big.Int).Set(txObj.Cost())
+		}
+	}
+	m.mapByHash[txObj.Hash()] = txObj
+	m.mapByID[txObj.ID()] = txObj
+	m.mapByKey[txObj.replaceKey] = txObj
+	m.link(txObj)
+}
+
+// link puts the tx object into the dependency graph, if it depends on another tx.
+func (m *txObjectMap) link(txObj *TxObject) {
+	if dep := txObj.DependsOn(); dep != nil {
+		dependents := m.mapByDep[*dep]
+		if dependents == nil {
+			dependents = make(map[thor.Bytes32]*TxObject)
+			m.mapByDep[*dep] = dependents
+		}
+		dependents[txObj.ID()] = txObj
+	}
+}
+
+// unlink removes the tx object from the dependency graph.
+func (m *txObjectMap) unlink(txObj *TxObject) {
+	if dep := txObj.DependsOn(); dep != nil {
+		if dependents := m.mapByDep[*dep]; dependents[txObj.ID()] == txObj {
+			delete(dependents, txObj.ID())
+			if len(dependents) == 0 {
+				delete(m.mapByDep, *dep)
+			}
+		}
+	}
+}
+
 func (m *txObjectMap) GetByID(id thor.Bytes32) *TxObject {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 	return m.mapByID[id]
 }
 
+func (m *txObjectMap) GetByHash(txHash thor.Bytes32) *TxObject {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.mapByHash[txHash]
+}
+
+func (m *txObjectMap) GetByReplaceKey(key thor.Bytes32) *TxObject {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.mapByKey[key]
+}
+
+// GetDependents returns tx objects depending on the tx of the given id.
+func (m *txObjectMap) GetDependents(id thor.Bytes32) []*TxObject {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	dependents := make([]*TxObject, 0, len(m.mapByDep[id]))
+	for _, txObj := range m.mapByDep[id] {
+		dependents = append(dependents, txObj)
+	}
+	return dependents
+}
+
 func (m *txObjectMap) RemoveByHash(txHash thor.Bytes32) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	return m.remove(txHash)
+}
+
+func (m *txObjectMap) remove(txHash thor.Bytes32) bool {
 	if txObj, ok := m.mapByHash[txHash]; ok {
 		if m.quota[txObj.Origin()] > 1 {
 			m.quota[txObj.Origin()]--
@@ -131,6 +233,10 @@ func (m *txObjectMap) RemoveByHash(txHash thor.Bytes32) bool {
 
 		delete(m.mapByHash, txHash)
 		delete(m.mapByID, txObj.ID())
+		if m.mapByKey[txObj.replaceKey] == txObj {
+			delete(m.mapByKey, txObj.replaceKey)
+		}
+		m.unlink(txObj)
 		return true
 	}
 	return false
@@ -183,6 +289,8 @@ func (m *txObjectMap) Fill(txObjs []*TxObject) {
 		}
 		m.mapByHash[txObj.Hash()] = txObj
 		m.mapByID[txObj.ID()] = txObj
+		m.mapByKey[txObj.replaceKey] = txObj
+		m.link(txObj)
 		// skip cost check and accumulation
 	}
 }
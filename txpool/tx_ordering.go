@@ -0,0 +1,133 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Names of ordering strategies.
+const (
+	OrderingPriorityFee = "priority-fee"
+	OrderingFIFO        = "fifo"
+	OrderingFairShare   = "fair-share"
+	OrderingLocalFirst  = "local-first"
+)
+
+// Ordering is the strategy to order executable txs, in which the packer adopts them.
+type Ordering interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// Sort sorts txs in the order to be packed.
+	Sort(txObjs []*TxObject)
+}
+
+// NewOrdering returns the ordering strategy of the given name.
+func NewOrdering(name string) (Ordering, error) {
+	switch name {
+	case OrderingPriorityFee:
+		return priorityFeeOrdering{}, nil
+	case OrderingFIFO:
+		return fifoOrdering{}, nil
+	case OrderingFairShare:
+		return fairShareOrdering{}, nil
+	case OrderingLocalFirst:
+		return localFirstOrdering{}, nil
+	default:
+		return nil, fmt.Errorf("unknown tx ordering strategy %q", name)
+	}
+}
+
+// priorityFeeOrdering orders txs by priority fee from high to low, which is the default.
+type priorityFeeOrdering struct{}
+
+func (priorityFeeOrdering) Name() string { return OrderingPriorityFee }
+
+func (priorityFeeOrdering) Sort(txObjs []*TxObject) {
+	sortTxObjsByPriorityGasPriceDesc(txObjs)
+}
+
+// fifoOrdering orders txs by the time added to the pool, regardless of fees.
+type fifoOrdering struct{}
+
+func (fifoOrdering) Name() string { return OrderingFIFO }
+
+func (fifoOrdering) Sort(txObjs []*TxObject) {
+	slices.SortFunc(txObjs, func(a, b *TxObject) int {
+		return cmp.Or(cmp.Compare(a.timeAdded, b.timeAdded), b.priorityGasPrice.Cmp(a.priorityGasPrice))
+	})
+}
+
+// fairShareOrdering takes txs of origins in turn, so that no origin fills the block at the cost of others.
+// Each turn takes the best paying tx of each origin, ordered by priority fee.
+type fairShareOrdering struct{}
+
+func (fairShareOrdering) Name() string { return OrderingFairShare }
+
+func (fairShareOrdering) Sort(txObjs []*TxObject) {
+	sortTxObjsByPriorityGasPriceDesc(txObjs)
+
+	type turned struct {
+		obj  *TxObject
+		turn int
+	}
+	var (
+		turns  = make(map[thor.Address]int)
+		sorted = make([]turned, 0, len(txObjs))
+	)
+	for _, obj := range txObjs {
+		sorted = append(sorted, turned{obj, turns[obj.Origin()]})
+		turns[obj.Origin()]++
+	}
+	slices.SortStableFunc(sorted, func(a, b turned) int {
+		return cmp.Compare(a.turn, b.turn)
+	})
+	for i := range sorted {
+		txObjs[i] = sorted[i].obj
+	}
+}
+
+// localFirstOrdering puts txs submitted locally ahead of txs from p2p, each part ordered by priority fee.
+type localFirstOrdering struct{}
+
+func (localFirstOrdering) Name() string { return OrderingLocalFirst }
+
+func (localFirstOrdering) Sort(txObjs []*TxObject) {
+	sortTxObjsByPriorityGasPriceDesc(txObjs)
+	slices.SortStableFunc(txObjs, func(a, b *TxObject) int {
+		switch {
+		case a.localSubmitted == b.localSubmitted:
+			return 0
+		case a.localSubmitted:
+			return -1
+		default:
+			return 1
+		}
+	})
+}
+
+// countDisplaced returns the count of txs placed behind some tx paying lower priority fee, i.e. displaced by the
+// ordering compared with ordering by priority fee.
+func countDisplaced(txObjs []*TxObject) int {
+	var (
+		lowest    *big.Int
+		displaced int
+	)
+	for _, obj := range txObjs {
+		if lowest != nil && obj.priorityGasPrice.Cmp(lowest) > 0 {
+			displaced++
+		}
+		if lowest == nil || obj.priorityGasPrice.Cmp(lowest) < 0 {
+			lowest = obj.priorityGasPrice
+		}
+	}
+	return displaced
+}
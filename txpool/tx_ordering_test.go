@@ -0,0 +1,69 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestOrdering(t *testing.T) {
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	newObj := func(name string, origin thor.Address, price int64, timeAdded int64, local bool) *TxObject {
+		return &TxObject{
+			resolved:         &runtime.ResolvedTransaction{Origin: origin},
+			replaceKey:       thor.BytesToBytes32([]byte(name)),
+			priorityGasPrice: big.NewInt(price),
+			timeAdded:        timeAdded,
+			localSubmitted:   local,
+		}
+	}
+	objs := []*TxObject{
+		newObj("a", addr1, 30, 1, false),
+		newObj("b", addr1, 20, 2, false),
+		newObj("c", addr1, 10, 3, false),
+		newObj("d", addr2, 15, 4, true),
+		newObj("e", addr2, 5, 5, false),
+	}
+
+	tests := []struct {
+		name      string
+		expected  string
+		displaced int
+	}{
+		{OrderingPriorityFee, "abdce", 0},
+		{OrderingFIFO, "abcde", 1},
+		{OrderingFairShare, "adbec", 2},
+		{OrderingLocalFirst, "dabce", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordering, err := NewOrdering(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, ordering.Name())
+
+			sorted := append([]*TxObject(nil), objs...)
+			ordering.Sort(sorted)
+
+			var names []byte
+			for _, obj := range sorted {
+				names = append(names, obj.replaceKey[31])
+			}
+			assert.Equal(t, tt.expected, string(names))
+			assert.Equal(t, tt.displaced, countDisplaced(sorted))
+		})
+	}
+
+	_, err := NewOrdering("random")
+	assert.EqualError(t, err, `unknown tx ordering strategy "random"`)
+}
@@ -10,6 +10,8 @@ import (
 	"math/big"
 	"math/rand/v2"
 	"os"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -41,12 +43,19 @@ type Options struct {
 	MaxLifetime            time.Duration
 	BlocklistCacheFilePath string
 	BlocklistFetchURL      string
+	PriorityFeeBump        int      // min percentage to bump MaxPriorityFeePerGas or GasPriceCoef for replacement
+	MaxFeeBump             int      // min percentage to bump MaxFeePerGas for replacement
+	Ordering               Ordering // strategy to order executables, by priority fee if nil
 }
 
 // TxEvent will be posted when tx is added or status changed.
 type TxEvent struct {
 	Tx         *tx.Transaction
 	Executable *bool
+	Status     TxStatus
+	Reason     string        // reason of being dropped
+	ReplacedBy *thor.Bytes32 // tx replaced by, if dropped due to replacement
+	BlockID    *thor.Bytes32 // block packed into
 }
 
 // TxPool maintains unprocessed transactions.
@@ -57,10 +66,16 @@ type TxPool struct {
 	blocklist    blocklist
 	forkConfig   *thor.ForkConfig
 	baseFeeCache *baseFeeCache
+	ordering     Ordering
 
 	executables    atomic.Value
+	execLock       sync.Mutex   // serializes updates of executables
+	priorities     atomic.Value // priority gas prices of executables, keyed by tx id
 	all            *txObjectMap
 	addedAfterWash uint32
+	journal        *statusJournal
+	bundles        *bundleSet
+	bundleJournal  *statusJournal
 
 	ctx    context.Context
 	cancel func()
@@ -73,15 +88,23 @@ type TxPool struct {
 // Shutdown is required to be called at end.
 func New(repo *chain.Repository, stater *state.Stater, options Options, forkConfig *thor.ForkConfig) *TxPool {
 	ctx, cancel := context.WithCancel(context.Background())
+	ordering := options.Ordering
+	if ordering == nil {
+		ordering = priorityFeeOrdering{}
+	}
 	pool := &TxPool{
-		options:      options,
-		repo:         repo,
-		stater:       stater,
-		all:          newTxObjectMap(),
-		ctx:          ctx,
-		cancel:       cancel,
-		forkConfig:   forkConfig,
-		baseFeeCache: newBaseFeeCache(forkConfig),
+		options:       options,
+		repo:          repo,
+		stater:        stater,
+		all:           newTxObjectMap(),
+		journal:       newStatusJournal(statusJournalLimit),
+		bundles:       newBundleSet(),
+		bundleJournal: newStatusJournal(bundleJournalLimit),
+		ctx:           ctx,
+		cancel:        cancel,
+		forkConfig:    forkConfig,
+		baseFeeCache:  newBaseFeeCache(forkConfig),
+		ordering:      ordering,
 	}
 
 	pool.goes.Go(pool.housekeeping)
@@ -112,6 +135,9 @@ func (p *TxPool) housekeeping() {
 				// skip washing txs if not synced
 				continue
 			}
+			if p.bundles.Len() > 0 {
+				p.washBundles(headSummary)
+			}
 			poolLen := p.all.Len()
 			// do wash on
 			// 1. head block changed
@@ -134,7 +160,7 @@ func (p *TxPool) housekeeping() {
 				if err != nil {
 					ctx = append(ctx, "err", err)
 				} else {
-					p.executables.Store(executables)
+					executables = p.storeExecutables(executables)
 					metricTxPoolExecutablesGauge().Set(int64(len(executables)))
 				}
 
@@ -215,7 +241,17 @@ func (p *TxPool) SubscribeTxEvent(ch chan *TxEvent) event.Subscription {
 	return p.scope.Track(p.txFeed.Subscribe(ch))
 }
 
-func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmitted bool) (err error) {
+// addOptions are optional inputs of adding tx.
+type addOptions struct {
+	replaces  *thor.Bytes32 // the pending tx to replace explicitly
+	timeAdded int64         // kept as the time added if non-zero, which is the case of txs restored
+}
+
+func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmitted bool) error {
+	return p.addWithOptions(newTx, rejectNonExecutable, localSubmitted, addOptions{})
+}
+
+func (p *TxPool) addWithOptions(newTx *tx.Transaction, rejectNonExecutable bool, localSubmitted bool, opts addOptions) (err error) {
 	source := "local"
 	if !localSubmitted {
 		source = "remote"
@@ -223,6 +259,7 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 	defer func() {
 		if err != nil {
 			metricBadTxGauge().AddWithLabel(1, map[string]string{"source": source})
+			p.transit(newTx, TxTransition{Status: TxStatusDropped, Reason: dropReason(err)})
 		}
 	}()
 	txTypeString := "Legacy"
@@ -230,20 +267,22 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		txTypeString = "DynamicFee"
 	}
 
-	if p.all.ContainsHash(newTx.Hash()) {
-		// tx already in the pool
+	if p.all.ContainsHash(newTx.Hash()) || p.bundles.containsTx(newTx.ID()) {
+		// tx already in the pool, or pending in a bundle
 		return nil
 	}
 
 	origin, _ := newTx.Origin()
 	if thor.IsOriginBlocked(origin) || p.blocklist.Contains(origin) {
 		// tx origin blocked
+		p.transit(newTx, TxTransition{Status: TxStatusDropped, Reason: DropReasonBlocked})
 		return nil
 	}
 
 	delegator, _ := newTx.Delegator()
 	if delegator != nil && (thor.IsOriginBlocked(*delegator) || p.blocklist.Contains(*delegator)) {
 		// tx delegator blocked
+		p.transit(newTx, TxTransition{Status: TxStatusDropped, Reason: DropReasonBlocked})
 		return nil
 	}
 
@@ -255,6 +294,28 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 	if err != nil {
 		return badTxError{err.Error()}
 	}
+	if opts.timeAdded != 0 {
+		txObj.timeAdded = opts.timeAdded
+		// restored txs may be broadcast before the restart
+		txObj.announced.Store(true)
+	}
+
+	// the tx replaced, resolved once the new tx is qualified to be added
+	var old *TxObject
+	addObj := func(validatePayer func(thor.Address, *big.Int) error) (err error) {
+		if old, err = p.replacedBy(txObj, opts.replaces); err != nil {
+			return err
+		}
+		if old != nil {
+			err = p.all.Replace(txObj, old, p.options.LimitPerAccount, validatePayer)
+		} else {
+			err = p.all.Add(txObj, p.options.LimitPerAccount, validatePayer)
+		}
+		if err != nil {
+			return txRejectedError{err.Error()}
+		}
+		return nil
+	}
 
 	headSummary := p.repo.BestBlockSummary()
 	if isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp()) {
@@ -266,12 +327,13 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		}
 
 		state := p.stater.NewState(headSummary.Root())
-		executable, err := txObj.Executable(
+		executable, err := txObj.executableAfterDep(
 			p.repo.NewChain(headSummary.Header.ID()),
 			state,
 			headSummary.Header,
 			p.forkConfig,
 			p.baseFeeCache.Get(headSummary.Header),
+			p.depExecutable(txObj),
 		)
 		if err != nil {
 			return txRejectedError{err.Error()}
@@ -288,7 +350,7 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		}
 
 		txObj.executable = executable
-		if err := p.all.Add(txObj, p.options.LimitPerAccount, func(payer thor.Address, needs *big.Int) error {
+		if err := addObj(func(payer thor.Address, needs *big.Int) error {
 			// check payer's balance
 			balance, err := builtin.Energy.Native(state, headSummary.Header.Timestamp()+thor.BlockInterval()).Get(payer)
 			if err != nil {
@@ -301,11 +363,16 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 
 			return nil
 		}); err != nil {
-			return txRejectedError{err.Error()}
+			return err
 		}
 
+		if executable {
+			txObj.announced.Store(true)
+		}
+		p.journal.record(newTx.ID(), TxTransition{Status: TxStatusAdded})
+		p.journal.record(newTx.ID(), TxTransition{Status: statusOf(executable)})
 		p.goes.Go(func() {
-			p.txFeed.Send(&TxEvent{newTx, &executable})
+			p.txFeed.Send(&TxEvent{Tx: newTx, Executable: &executable, Status: TxStatusAdded})
 		})
 		logger.Trace("tx added", "id", newTx.ID(), "executable", executable)
 	} else {
@@ -316,19 +383,49 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		}
 
 		// skip pending cost check when chain is not synced
-		if err := p.all.Add(txObj, p.options.LimitPerAccount, func(_ thor.Address, _ *big.Int) error { return nil }); err != nil {
-			return txRejectedError{err.Error()}
+		if err := addObj(func(_ thor.Address, _ *big.Int) error { return nil }); err != nil {
+			return err
 		}
 		logger.Trace("tx added", "id", newTx.ID())
+		p.journal.record(newTx.ID(), TxTransition{Status: TxStatusAdded})
 		p.goes.Go(func() {
-			p.txFeed.Send(&TxEvent{newTx, nil})
+			p.txFeed.Send(&TxEvent{Tx: newTx, Status: TxStatusAdded})
 		})
 	}
+	if old != nil {
+		p.evictReplaced(old, newTx.ID())
+	}
 	atomic.AddUint32(&p.addedAfterWash, 1)
 	metricTxPoolGauge().AddWithLabel(1, map[string]string{"source": source, "type": txTypeString})
 	return nil
 }
 
+// evictReplaced finishes the eviction of the tx replaced, which is already removed from the map.
+func (p *TxPool) evictReplaced(old *TxObject, newID thor.Bytes32) {
+	txTypeString := "Legacy"
+	if old.Type() == tx.TypeDynamicFee {
+		txTypeString = "DynamicFee"
+	}
+	metricTxPoolGauge().AddWithLabel(-1, map[string]string{"source": "n/a", "type": txTypeString})
+
+	// drop it from executables at once, rather than waiting for the next wash, not to be packed
+	p.execLock.Lock()
+	if executables := p.Executables(); len(executables) > 0 {
+		remained := make(tx.Transactions, 0, len(executables))
+		for _, trx := range executables {
+			if trx != old.Transaction {
+				remained = append(remained, trx)
+			}
+		}
+		p.executables.Store(remained)
+	}
+	p.execLock.Unlock()
+	logger.Debug("tx replaced", "id", old.ID(), "by", newID)
+	p.transit(old.Transaction, TxTransition{Status: TxStatusDropped, Reason: DropReasonReplaced, ReplacedBy: &newID})
+	// dependents refer to the id of the tx replaced
+	p.dropDependents(old.ID())
+}
+
 // Add adds a new tx into pool.
 // It's not assumed as an error if the tx to be added is already in the pool,
 func (p *TxPool) Add(newTx *tx.Transaction) error {
@@ -340,6 +437,13 @@ func (p *TxPool) AddLocal(newTx *tx.Transaction) error {
 	return p.add(newTx, false, true)
 }
 
+// ReplaceLocal adds new locally submitted tx into pool, which replaces the pending tx of the given id from the same
+// origin. Locally submitted txs with the same type, origin, chain tag, nonce and clauses replace pending ones even
+// without the id given.
+func (p *TxPool) ReplaceLocal(newTx *tx.Transaction, replaces thor.Bytes32) error {
+	return p.addWithOptions(newTx, false, true, addOptions{replaces: &replaces})
+}
+
 // Get get pooled tx by id.
 func (p *TxPool) Get(id thor.Bytes32) *tx.Transaction {
 	if txObj := p.all.GetByID(id); txObj != nil {
@@ -348,6 +452,14 @@ func (p *TxPool) Get(id thor.Bytes32) *tx.Transaction {
 	return nil
 }
 
+// GetByHash get pooled tx by hash.
+func (p *TxPool) GetByHash(txHash thor.Bytes32) *tx.Transaction {
+	if txObj := p.all.GetByHash(txHash); txObj != nil {
+		return txObj.Transaction
+	}
+	return nil
+}
+
 // StrictlyAdd add new tx into pool. A rejection error will be returned, if tx is not executable at this time.
 func (p *TxPool) StrictlyAdd(newTx *tx.Transaction) error {
 	return p.add(newTx, true, false)
@@ -368,11 +480,31 @@ func (p *TxPool) Remove(txHash thor.Bytes32, txID thor.Bytes32) bool {
 		}
 		metricTxPoolGauge().AddWithLabel(-1, map[string]string{"source": "n/a", "type": txTypeString})
 		logger.Debug("tx removed", "id", txID)
+
+		if packed, ok := packedIn(p.repo.NewBestChain(), txID); ok {
+			p.transit(removedTransaction.Transaction, packed)
+		} else {
+			p.transit(removedTransaction.Transaction, TxTransition{Status: TxStatusDropped, Reason: DropReasonRemoved})
+			p.dropDependents(txID)
+		}
 		return true
 	}
 	return false
 }
 
+// storeExecutables stores executables resulted from the wash, except the ones evicted from the pool meanwhile, e.g.
+// replaced, which are dropped from the executables already. It returns the executables stored.
+func (p *TxPool) storeExecutables(executables tx.Transactions) tx.Transactions {
+	p.execLock.Lock()
+	defer p.execLock.Unlock()
+
+	executables = slices.DeleteFunc(executables, func(trx *tx.Transaction) bool {
+		return !p.all.ContainsHash(trx.Hash())
+	})
+	p.executables.Store(executables)
+	return executables
+}
+
 // Executables returns executable txs.
 func (p *TxPool) Executables() tx.Transactions {
 	if sorted := p.executables.Load(); sorted != nil {
@@ -385,6 +517,9 @@ func (p *TxPool) Executables() tx.Transactions {
 func (p *TxPool) Fill(txs tx.Transactions) {
 	txObjs := make([]*TxObject, 0, len(txs))
 	for _, tx := range txs {
+		if p.all.ContainsHash(tx.Hash()) {
+			continue
+		}
 		origin, _ := tx.Origin()
 		if thor.IsOriginBlocked(origin) || p.blocklist.Contains(origin) {
 			continue
@@ -399,6 +534,9 @@ func (p *TxPool) Fill(txs tx.Transactions) {
 		}
 	}
 	p.all.Fill(txObjs)
+	for _, txObj := range txObjs {
+		p.journal.record(txObj.ID(), TxTransition{Status: TxStatusAdded})
+	}
 }
 
 // Dump dumps all txs in the pool.
@@ -418,8 +556,24 @@ func (p *TxPool) wash(
 	err error,
 ) {
 	all := p.all.ToTxObjects()
-	var toRemove []*TxObject
-	var toUpdateCost []*TxObject
+	// txs are evaluated ahead of their dependents
+	orderByDeps(all)
+	var (
+		toRemove     []*TxObject
+		removals     []TxTransition // transitions of txs to remove
+		removed      = make(map[thor.Bytes32]bool)
+		dropped      = make(map[thor.Bytes32]bool)
+		toUpdateCost []*TxObject
+		events       []*TxEvent
+	)
+	remove := func(txObj *TxObject, t TxTransition) {
+		toRemove = append(toRemove, txObj)
+		removals = append(removals, t)
+		removed[txObj.ID()] = true
+		if t.Status == TxStatusDropped {
+			dropped[txObj.ID()] = true
+		}
+	}
 	defer func() {
 		if err != nil {
 			// in case of error, simply cut pool size to limit
@@ -433,21 +587,30 @@ func (p *TxPool) wash(
 					removedDynamicFee++
 				}
 				p.all.RemoveByHash(txObj.Hash())
+				events = p.appendTransit(events, txObj.Transaction, TxTransition{Status: TxStatusDropped, Reason: DropReasonPoolLimit})
 			}
 		} else {
-			for _, txObj := range toRemove {
+			for i, txObj := range toRemove {
 				p.all.RemoveByHash(txObj.Hash())
 				if txObj.Type() == tx.TypeLegacy {
 					removedLegacy++
 				} else if txObj.Type() == tx.TypeDynamicFee {
 					removedDynamicFee++
 				}
+				events = p.appendTransit(events, txObj.Transaction, removals[i])
 			}
 		}
 		// update pending cost
 		for _, txObj := range toUpdateCost {
 			p.all.UpdatePendingCost(txObj)
 		}
+		if len(events) > 0 {
+			p.goes.Go(func() {
+				for _, ev := range events {
+					p.txFeed.Send(ev)
+				}
+			})
+		}
 	}()
 
 	// recreate state every time to avoid high RAM usage when the pool at hight water-mark.
@@ -460,6 +623,7 @@ func (p *TxPool) wash(
 		executableObjs      = make([]*TxObject, 0, len(all))
 		nonExecutableObjs   = make([]*TxObject, 0, len(all))
 		localExecutableObjs = make([]*TxObject, 0, len(all))
+		executableIDs       = make(map[thor.Bytes32]bool)
 		now                 = time.Now().UnixNano()
 		baseFee             = p.baseFeeCache.Get(headSummary.Header)
 	)
@@ -494,27 +658,42 @@ func (p *TxPool) wash(
 
 	for _, txObj := range all {
 		if thor.IsOriginBlocked(txObj.Origin()) || p.blocklist.Contains(txObj.Origin()) {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonBlocked})
 			logger.Trace("tx washed out", "id", txObj.ID(), "err", "blocked")
 			continue
 		}
 		delegator := txObj.Delegator()
 		if delegator != nil && (thor.IsOriginBlocked(*delegator) || p.blocklist.Contains(*delegator)) {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonBlocked})
 			logger.Trace("tx washed out", "id", txObj.ID(), "err", "blocked delegator")
 			continue
 		}
 
 		// out of lifetime
 		if !txObj.localSubmitted && now > txObj.timeAdded+int64(p.options.MaxLifetime) {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonLifetime})
 			logger.Trace("tx washed out", "id", txObj.ID(), "err", "out of lifetime")
 			continue
 		}
+		// dependents are dropped along with the tx they depend on, and become executable along with it
+		var depExecutable bool
+		if dep := txObj.DependsOn(); dep != nil {
+			if dropped[*dep] {
+				remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonDepDropped})
+				logger.Trace("tx washed out", "id", txObj.ID(), "err", "dep dropped")
+				continue
+			}
+			depExecutable = executableIDs[*dep]
+		}
+
 		// settled, out of energy or dep broken
-		executable, err := txObj.Executable(chain, newState(), headSummary.Header, p.forkConfig, baseFee)
+		executable, err := txObj.executableAfterDep(chain, newState(), headSummary.Header, p.forkConfig, baseFee, depExecutable)
 		if err != nil {
-			toRemove = append(toRemove, txObj)
+			if packed, ok := packedIn(chain, txObj.ID()); ok && err == errKnownTx {
+				remove(txObj, packed)
+			} else {
+				remove(txObj, TxTransition{Status: TxStatusDropped, Reason: err.Error()})
+			}
 			logger.Trace("tx washed out", "id", txObj.ID(), "err", err)
 			continue
 		}
@@ -524,7 +703,7 @@ func (p *TxPool) wash(
 			nextBlockNum := headSummary.Header.Number() + 1
 			provedWork, err := txObj.ProvedWork(nextBlockNum, chain.GetBlockID)
 			if err != nil {
-				toRemove = append(toRemove, txObj)
+				remove(txObj, TxTransition{Status: TxStatusDropped, Reason: err.Error()})
 				logger.Trace("tx washed out", "id", txObj.ID(), "err", err)
 				continue
 			}
@@ -532,6 +711,7 @@ func (p *TxPool) wash(
 		}
 
 		if executable {
+			executableIDs[txObj.ID()] = true
 			if txObj.localSubmitted {
 				localExecutableObjs = append(localExecutableObjs, txObj)
 			} else {
@@ -541,6 +721,7 @@ func (p *TxPool) wash(
 			if !txObj.localSubmitted {
 				nonExecutableObjs = append(nonExecutableObjs, txObj)
 			}
+			events = p.appendTransit(events, txObj.Transaction, TxTransition{Status: TxStatusNonExecutable})
 		}
 	}
 
@@ -552,56 +733,131 @@ func (p *TxPool) wash(
 	// remove over limit txs, from non-executables to low priced
 	if len(executableObjs) > limit {
 		for _, txObj := range nonExecutableObjs {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonPoolLimit})
 			logger.Debug("non-executable tx washed out due to pool limit", "id", txObj.ID())
 		}
 		for _, txObj := range executableObjs[limit:] {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonPoolLimit})
 			logger.Debug("executable tx washed out due to pool limit", "id", txObj.ID())
 		}
 		executableObjs = executableObjs[:limit]
 	} else if len(executableObjs)+len(nonExecutableObjs) > limit {
 		// executableObjs + nonExecutableObjs over pool limit
 		for _, txObj := range nonExecutableObjs[limit-len(executableObjs):] {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonPoolLimit})
 			logger.Debug("non-executable tx washed out due to pool limit", "id", txObj.ID())
 		}
 	} else if len(nonExecutableObjs) > limit*2/10 {
 		// nonExecutableObjs over pool limit
 		for _, txObj := range nonExecutableObjs[limit*2/10:] {
-			toRemove = append(toRemove, txObj)
+			remove(txObj, TxTransition{Status: TxStatusDropped, Reason: DropReasonNonExecLimit})
 			logger.Debug("non-executable tx washed out due to non-executable limit", "id", txObj.ID())
 		}
 	}
 
-	// Concatenate executables.
+	// cascade drops due to limits down the dependency graph, where removals grow while iterating
+	for i := 0; i < len(toRemove); i++ {
+		if removals[i].Status != TxStatusDropped {
+			continue
+		}
+		for _, dependent := range p.all.GetDependents(toRemove[i].ID()) {
+			if !removed[dependent.ID()] {
+				remove(dependent, TxTransition{Status: TxStatusDropped, Reason: DropReasonDepDropped})
+			}
+		}
+	}
+	isRemoved := func(txObj *TxObject) bool { return removed[txObj.ID()] }
+	executableObjs = slices.DeleteFunc(executableObjs, isRemoved)
+	localExecutableObjs = slices.DeleteFunc(localExecutableObjs, isRemoved)
+
+	// Concatenate executables, and sort them in the order to be packed, with dependents behind their deps.
 	executableObjs = append(executableObjs, localExecutableObjs...)
-	// Sort will be faster (part of it already sorted).
-	sortTxObjsByPriorityGasPriceDesc(executableObjs)
+	p.ordering.Sort(executableObjs)
+	orderByDeps(executableObjs)
+	metricTxPoolDisplacedGauge().SetWithLabel(int64(countDisplaced(executableObjs)), map[string]string{"strategy": p.ordering.Name()})
 
 	executables = make(tx.Transactions, 0, len(executableObjs))
-	var toBroadcast tx.Transactions
+	priorities := make(map[thor.Bytes32]*big.Int, len(executableObjs))
+	executable := true
 
 	for _, obj := range executableObjs {
 		executables = append(executables, obj.Transaction)
+		priorities[obj.ID()] = obj.priorityGasPrice
+		transited := p.journal.record(obj.ID(), TxTransition{Status: TxStatusExecutable})
 		// the tx is not executable previously
 		if !obj.executable {
 			obj.executable = true
 			toUpdateCost = append(toUpdateCost, obj)
-			toBroadcast = append(toBroadcast, obj.Transaction)
-		} else if obj.localSubmitted {
-			// broadcast local submitted even it's already executable
-			toBroadcast = append(toBroadcast, obj.Transaction)
+		} else if !obj.localSubmitted && !transited {
+			// already executable, while local submitted are broadcast anyway
+			continue
 		}
+		obj.announced.Store(true)
+		events = append(events, &TxEvent{Tx: obj.Transaction, Executable: &executable, Status: TxStatusExecutable})
+	}
+	p.priorities.Store(priorities)
+	return executables, 0, 0, nil
+}
+
+// ObservePacked accounts the executables displaced from the block packed by the ordering strategy, which pay
+// more priority fee than some tx packed, but are left out.
+func (p *TxPool) ObservePacked(packed tx.Transactions) {
+	priorities, _ := p.priorities.Load().(map[thor.Bytes32]*big.Int)
+	if len(priorities) == 0 {
+		return
 	}
 
-	p.goes.Go(func() {
-		executable := true
-		for _, tx := range toBroadcast {
-			p.txFeed.Send(&TxEvent{tx, &executable})
+	var (
+		lowest    *big.Int
+		packedIDs = make(map[thor.Bytes32]bool, len(packed))
+	)
+	for _, trx := range packed {
+		packedIDs[trx.ID()] = true
+		if price, ok := priorities[trx.ID()]; ok && (lowest == nil || price.Cmp(lowest) < 0) {
+			lowest = price
 		}
-	})
-	return executables, 0, 0, nil
+	}
+	if lowest == nil {
+		return
+	}
+
+	var displaced int
+	for _, trx := range p.Executables() {
+		if price, ok := priorities[trx.ID()]; ok && !packedIDs[trx.ID()] && price.Cmp(lowest) > 0 {
+			displaced++
+		}
+	}
+	metricPackerDisplacedCounter().AddWithLabel(int64(displaced), map[string]string{"strategy": p.ordering.Name()})
+}
+
+// GetStatus returns recorded state transitions of the tx, from the oldest to the latest.
+// Transitions of txs left the pool are kept for a while.
+func (p *TxPool) GetStatus(id thor.Bytes32) []TxTransition {
+	return p.journal.get(id)
+}
+
+// transit records the transition of the tx, and posts it as a tx event.
+func (p *TxPool) transit(trx *tx.Transaction, t TxTransition) {
+	if events := p.appendTransit(nil, trx, t); len(events) > 0 {
+		p.goes.Go(func() {
+			p.txFeed.Send(events[0])
+		})
+	}
+}
+
+// appendTransit records the transition of the tx, and appends the event of it if recorded.
+func (p *TxPool) appendTransit(events []*TxEvent, trx *tx.Transaction, t TxTransition) []*TxEvent {
+	// txs with bad signature have no id to be looked up
+	id := trx.ID()
+	if id.IsZero() || !p.journal.record(id, t) {
+		return events
+	}
+	ev := &TxEvent{Tx: trx, Status: t.Status, Reason: t.Reason, ReplacedBy: t.ReplacedBy, BlockID: t.BlockID}
+	if t.Status == TxStatusNonExecutable {
+		executable := false
+		ev.Executable = &executable
+	}
+	return append(events, ev)
 }
 
 // Get length of the `all` field
@@ -622,6 +878,27 @@ func (p *TxPool) validateTxBasics(trx *tx.Transaction) error {
 	return nil
 }
 
+// statusOf returns the status of tx by whether it's executable.
+func statusOf(executable bool) TxStatus {
+	if executable {
+		return TxStatusExecutable
+	}
+	return TxStatusNonExecutable
+}
+
+// packedIn returns the transition of the tx packed into a block of the chain, or false if not packed.
+func packedIn(chain *chain.Chain, id thor.Bytes32) (TxTransition, bool) {
+	meta, err := chain.GetTransactionMeta(id)
+	if err != nil {
+		return TxTransition{}, false
+	}
+	blockID, err := chain.GetBlockID(meta.BlockNum)
+	if err != nil {
+		return TxTransition{}, false
+	}
+	return TxTransition{Status: TxStatusPacked, BlockID: &blockID}, true
+}
+
 func isChainSynced(nowTimestamp, blockTimestamp uint64) bool {
 	timeDiff := nowTimestamp - blockTimestamp
 	if blockTimestamp > nowTimestamp {
@@ -289,6 +289,26 @@ func addOneBlock(t *testing.T, pool *TxPool) {
 	}
 }
 
+// addSyncedBlock adds a block with the genesis state as the best block, to make the chain synced.
+func addSyncedBlock(t *testing.T, pool *TxPool) {
+	st := pool.stater.NewState(trie.Root{Hash: pool.repo.GenesisBlock().Header().StateRoot()})
+	stage, _ := st.Stage(trie.Version{Major: 1})
+	root1, _ := stage.Commit()
+
+	var sig [65]byte
+	rand.Read(sig[:])
+
+	b1 := new(block.Builder).
+		ParentID(pool.repo.GenesisBlock().Header().ID()).
+		Timestamp(uint64(time.Now().Unix())).
+		TotalScore(100).
+		GasLimit(10000000).
+		StateRoot(root1).
+		BaseFee(big.NewInt(thor.InitialBaseFee)).
+		Build().WithSignature(sig[:])
+	require.NoError(t, pool.repo.AddBlock(b1, nil, 0, true))
+}
+
 func TestAddWithFullErrorUnsyncedChain(t *testing.T) {
 	// First fill the pool with legacy transactions
 	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
@@ -392,6 +412,18 @@ func TestRemove(t *testing.T) {
 	assert.Nil(t, pool.Get(trx.ID()), "Transaction should not exist in the pool after removal")
 }
 
+func TestGetByHash(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+
+	trx := newTx(tx.TypeLegacy, pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	assert.Nil(t, pool.GetByHash(trx.Hash()), "Transaction should not exist in the pool before adding")
+
+	assert.Nil(t, pool.Add(trx), "Adding transaction should not produce error")
+	assert.Equal(t, trx, pool.GetByHash(trx.Hash()))
+	assert.Nil(t, pool.GetByHash(trx.ID()), "Transaction should not be found by its ID")
+}
+
 func TestRemoveWithError(t *testing.T) {
 	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
 	defer pool.Close()
@@ -443,7 +475,244 @@ func TestSubscribeNewTx(t *testing.T) {
 	assert.Nil(t, pool.Add(tx))
 
 	v := true
-	assert.Equal(t, &TxEvent{tx, &v}, <-txCh)
+	assert.Equal(t, &TxEvent{Tx: tx, Executable: &v, Status: TxStatusAdded}, <-txCh)
+}
+
+func TestTxStatus(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+	addOneBlock(t, pool)
+
+	txCh := make(chan *TxEvent, 10)
+	pool.SubscribeTxEvent(txCh)
+
+	statuses := func(id thor.Bytes32) (statuses []TxStatus) {
+		for _, tr := range pool.GetStatus(id) {
+			statuses = append(statuses, tr.Status)
+		}
+		return
+	}
+
+	// added, and removed
+	trx1 := newTx(tx.TypeLegacy, pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	assert.Nil(t, pool.Add(trx1))
+	assert.True(t, pool.Remove(trx1.Hash(), trx1.ID()))
+	assert.Equal(t, []TxStatus{TxStatusAdded, TxStatusExecutable, TxStatusDropped}, statuses(trx1.ID()))
+	assert.Equal(t, DropReasonRemoved, pool.GetStatus(trx1.ID())[2].Reason)
+
+	// events are sent asynchronously, and may arrive out of order
+	v := true
+	assert.ElementsMatch(t, []*TxEvent{
+		{Tx: trx1, Executable: &v, Status: TxStatusAdded},
+		{Tx: trx1, Status: TxStatusDropped, Reason: DropReasonRemoved},
+	}, []*TxEvent{<-txCh, <-txCh})
+
+	// non-executable, since the tx depended on is not packed
+	trx2 := newTx(tx.TypeLegacy, pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, &thor.Bytes32{1}, tx.Features(0), devAccounts[1])
+	assert.Nil(t, pool.Add(trx2))
+	assert.Equal(t, []TxStatus{TxStatusAdded, TxStatusNonExecutable}, statuses(trx2.ID()))
+
+	// dropped on being rejected
+	trx3 := newTx(tx.TypeLegacy, pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, &thor.Bytes32{2}, tx.Features(0), devAccounts[1])
+	trx4 := newTx(tx.TypeLegacy, pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, &thor.Bytes32{3}, tx.Features(0), devAccounts[1])
+	assert.Nil(t, pool.Add(trx3))
+	err := pool.Add(trx4)
+	assert.True(t, IsTxRejected(err))
+	assert.Equal(t, []TxStatus{TxStatusDropped}, statuses(trx4.ID()))
+	assert.Equal(t, "tx rejected: "+pool.GetStatus(trx4.ID())[0].Reason, err.Error())
+
+	// packed, once removed after being packed into the best chain
+	trx5 := newTx(tx.TypeLegacy, pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[2])
+	assert.Nil(t, pool.Add(trx5))
+	best := pool.repo.BestBlockSummary().Header
+	b2 := new(block.Builder).
+		ParentID(best.ID()).
+		Timestamp(best.Timestamp() + thor.BlockInterval()).
+		TotalScore(best.TotalScore() + 1).
+		GasLimit(best.GasLimit()).
+		Transaction(trx5).
+		Build()
+	require.NoError(t, pool.repo.AddBlock(b2, tx.Receipts{&tx.Receipt{}}, 0, true))
+	assert.True(t, pool.Remove(trx5.Hash(), trx5.ID()))
+
+	transitions := pool.GetStatus(trx5.ID())
+	assert.Equal(t, []TxStatus{TxStatusAdded, TxStatusExecutable, TxStatusPacked}, statuses(trx5.ID()))
+	assert.Equal(t, b2.Header().ID(), *transitions[2].BlockID)
+}
+
+func TestReplace(t *testing.T) {
+	// room for non-executable txs
+	pool := newPool(LIMIT*10, LIMIT_PER_ACCOUNT, &thor.ForkConfig{})
+	defer pool.Close()
+	pool.options.PriorityFeeBump = 10
+	pool.options.MaxFeeBump = 10
+	addSyncedBlock(t, pool)
+
+	to := thor.BytesToAddress([]byte("to"))
+	// not executable until block 10, so not announced to peers
+	builder := func(txType tx.Type, nonce uint64) *tx.Builder {
+		return tx.NewBuilder(txType).
+			ChainTag(pool.repo.ChainTag()).
+			BlockRef(tx.NewBlockRef(10)).
+			Expiration(100).
+			Gas(21000).
+			Nonce(nonce).
+			Clause(tx.NewClause(&to))
+	}
+	dynFeeTx := func(nonce uint64, priorityFee, maxFee int64) *tx.Transaction {
+		return tx.MustSign(builder(tx.TypeDynamicFee, nonce).
+			MaxPriorityFeePerGas(big.NewInt(priorityFee)).
+			MaxFeePerGas(big.NewInt(maxFee)).
+			Build(), devAccounts[0].PrivateKey)
+	}
+	maxFee := int64(thor.InitialBaseFee * 2)
+
+	trx1 := dynFeeTx(1, 100, maxFee)
+	assert.Nil(t, pool.AddLocal(trx1))
+	executables, _, _, err := pool.wash(pool.repo.BestBlockSummary(), false)
+	require.NoError(t, err)
+	pool.executables.Store(executables)
+
+	// not bumped enough
+	assert.Equal(t, "tx rejected: replacement underpriced", pool.AddLocal(dynFeeTx(1, 105, maxFee*11/10)).Error())
+	assert.Equal(t, "tx rejected: replacement underpriced", pool.AddLocal(dynFeeTx(1, 110, maxFee)).Error())
+	assert.NotNil(t, pool.Get(trx1.ID()))
+
+	txCh := make(chan *TxEvent, 10)
+	pool.SubscribeTxEvent(txCh)
+
+	trx2 := dynFeeTx(1, 110, maxFee*11/10)
+	assert.Nil(t, pool.AddLocal(trx2))
+	assert.Nil(t, pool.Get(trx1.ID()))
+	assert.NotNil(t, pool.Get(trx2.ID()))
+	assert.Equal(t, 1, pool.Len())
+	assert.NotContains(t, pool.Executables(), trx1)
+	// the wash run meanwhile doesn't bring the replaced tx back
+	assert.Equal(t, tx.Transactions{trx2}, pool.storeExecutables(tx.Transactions{trx1, trx2}))
+
+	transitions := pool.GetStatus(trx1.ID())
+	replaced := transitions[len(transitions)-1]
+	assert.Equal(t, TxStatusDropped, replaced.Status)
+	assert.Equal(t, DropReasonReplaced, replaced.Reason)
+	assert.Equal(t, trx2.ID(), *replaced.ReplacedBy)
+
+	// skip events sent before, which may arrive late
+	var events []*TxEvent
+	for len(events) < 2 {
+		if ev := <-txCh; ev.Tx == trx2 || ev.ReplacedBy != nil {
+			events = append(events, ev)
+		}
+	}
+	trx2ID := trx2.ID()
+	v := false
+	assert.ElementsMatch(t, []*TxEvent{
+		{Tx: trx2, Executable: &v, Status: TxStatusAdded},
+		{Tx: trx1, Status: TxStatusDropped, Reason: DropReasonReplaced, ReplacedBy: &trx2ID},
+	}, events)
+
+	// legacy txs are replaced by bumping gas price coef
+	legacyTx1 := tx.MustSign(builder(tx.TypeLegacy, 2).GasPriceCoef(10).Build(), devAccounts[1].PrivateKey)
+	legacyTx2 := tx.MustSign(builder(tx.TypeLegacy, 2).GasPriceCoef(10).Gas(22000).Build(), devAccounts[1].PrivateKey)
+	legacyTx3 := tx.MustSign(builder(tx.TypeLegacy, 2).GasPriceCoef(11).Build(), devAccounts[1].PrivateKey)
+	assert.Nil(t, pool.AddLocal(legacyTx1))
+	assert.Equal(t, "tx rejected: replacement underpriced", pool.AddLocal(legacyTx2).Error())
+	assert.Nil(t, pool.AddLocal(legacyTx3))
+	assert.Nil(t, pool.Get(legacyTx1.ID()))
+	assert.NotNil(t, pool.Get(legacyTx3.ID()))
+
+	// executable txs are announced to peers once added, and stay valid if replaced
+	announced := tx.MustSign(builder(tx.TypeLegacy, 3).BlockRef(tx.BlockRef{}).GasPriceCoef(10).Build(), devAccounts[2].PrivateKey)
+	assert.Nil(t, pool.AddLocal(announced))
+	bumped := tx.MustSign(builder(tx.TypeLegacy, 3).BlockRef(tx.BlockRef{}).GasPriceCoef(20).Build(), devAccounts[2].PrivateKey)
+	assert.Equal(t, "tx rejected: tx to replace already announced to peers", pool.AddLocal(bumped).Error())
+	assert.NotNil(t, pool.Get(announced.ID()))
+
+	// so are txs with dependents from peers
+	depended := tx.MustSign(builder(tx.TypeLegacy, 4).GasPriceCoef(10).Build(), devAccounts[3].PrivateKey)
+	assert.Nil(t, pool.AddLocal(depended))
+	dependedID := depended.ID()
+	assert.Nil(t, pool.Add(tx.MustSign(builder(tx.TypeLegacy, 5).DependsOn(&dependedID).Build(), devAccounts[4].PrivateKey)))
+	bumped = tx.MustSign(builder(tx.TypeLegacy, 4).GasPriceCoef(20).Build(), devAccounts[3].PrivateKey)
+	assert.Equal(t, "tx rejected: tx to replace already announced to peers", pool.AddLocal(bumped).Error())
+	assert.NotNil(t, pool.Get(depended.ID()))
+
+	// fee-bumped copies from peers don't replace, but are added aside
+	remoteTx := dynFeeTx(1, 200, maxFee*2)
+	assert.Nil(t, pool.Add(remoteTx))
+	assert.NotNil(t, pool.Get(trx2.ID()))
+	assert.NotNil(t, pool.Get(remoteTx.ID()))
+}
+
+func TestReplaceDelegated(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.ForkConfig{})
+	defer pool.Close()
+	pool.options.PriorityFeeBump = 10
+	pool.options.MaxFeeBump = 10
+	addSyncedBlock(t, pool)
+
+	var features tx.Features
+	features.SetDelegated(true)
+	delegatedTx := func(blockRef uint32, priorityFee, maxFee int64, delegator genesis.DevAccount) *tx.Transaction {
+		return tx.MustSignDelegated(tx.NewBuilder(tx.TypeDynamicFee).
+			ChainTag(pool.repo.ChainTag()).
+			BlockRef(tx.NewBlockRef(blockRef)).
+			Expiration(100).
+			Gas(21000).
+			Nonce(1).
+			MaxPriorityFeePerGas(big.NewInt(priorityFee)).
+			MaxFeePerGas(big.NewInt(maxFee)).
+			Features(features).
+			Build(), devAccounts[0].PrivateKey, delegator.PrivateKey)
+	}
+
+	// not announced until block 10
+	trx1 := delegatedTx(10, 100, thor.InitialBaseFee*2, devAccounts[1])
+	assert.Nil(t, pool.AddLocal(trx1))
+
+	// replaced by the one delegated by another account
+	trx2 := delegatedTx(0, 200, thor.InitialBaseFee*3, devAccounts[2])
+	assert.Nil(t, pool.AddLocal(trx2))
+	assert.Nil(t, pool.Get(trx1.ID()))
+	assert.NotNil(t, pool.Get(trx2.ID()))
+
+	// quota and pending cost of the former delegator are released
+	assert.Zero(t, pool.all.quota[devAccounts[1].Address])
+	assert.Nil(t, pool.all.cost[devAccounts[1].Address])
+	assert.Equal(t, 1, pool.all.quota[devAccounts[2].Address])
+	assert.NotNil(t, pool.all.cost[devAccounts[2].Address])
+}
+
+func TestReplaceLocal(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.ForkConfig{})
+	defer pool.Close()
+	addSyncedBlock(t, pool)
+
+	newTx := func(nonce uint64, priorityFee int64, from genesis.DevAccount) *tx.Transaction {
+		return tx.MustSign(tx.NewBuilder(tx.TypeDynamicFee).
+			ChainTag(pool.repo.ChainTag()).
+			BlockRef(tx.NewBlockRef(10)).
+			Expiration(100).
+			Gas(21000).
+			Nonce(nonce).
+			MaxPriorityFeePerGas(big.NewInt(priorityFee)).
+			MaxFeePerGas(big.NewInt(thor.InitialBaseFee*2)).
+			Build(), from.PrivateKey)
+	}
+
+	trx1 := newTx(1, 100, devAccounts[0])
+	assert.Nil(t, pool.AddLocal(trx1))
+
+	// nonce differs, so it's a replacement only if explicitly told
+	trx2 := newTx(2, 200, devAccounts[0])
+	assert.Equal(t, "tx rejected: tx to replace not found", pool.ReplaceLocal(trx2, thor.Bytes32{1}).Error())
+	assert.Equal(t, "tx rejected: origin mismatch with tx to replace", pool.ReplaceLocal(newTx(2, 200, devAccounts[1]), trx1.ID()).Error())
+	assert.Equal(t, "tx rejected: replacement underpriced", pool.ReplaceLocal(newTx(2, 100, devAccounts[0]), trx1.ID()).Error())
+	legacyTx := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).ChainTag(pool.repo.ChainTag()).Expiration(100).Gas(21000).GasPriceCoef(255).Build(), devAccounts[0].PrivateKey)
+	assert.Equal(t, "tx rejected: tx type mismatch with tx to replace", pool.ReplaceLocal(legacyTx, trx1.ID()).Error())
+
+	assert.Nil(t, pool.ReplaceLocal(trx2, trx1.ID()))
+	assert.Nil(t, pool.Get(trx1.ID()))
+	assert.NotNil(t, pool.Get(trx2.ID()))
 }
 
 func TestSubscribeNewTypedTx(t *testing.T) {
@@ -484,7 +753,7 @@ func TestSubscribeNewTypedTx(t *testing.T) {
 	assert.Nil(t, pool.Add(trx))
 
 	v := true
-	assert.Equal(t, &TxEvent{trx, &v}, <-txCh)
+	assert.Equal(t, &TxEvent{Tx: trx, Executable: &v, Status: TxStatusAdded}, <-txCh)
 }
 
 func TestWashTxs(t *testing.T) {
@@ -533,6 +802,107 @@ func TestWashTxs(t *testing.T) {
 	assert.Equal(t, 1, removedLegacy+removedDynamicFee)
 }
 
+func TestWashWithOrdering(t *testing.T) {
+	fifo, err := NewOrdering(OrderingFIFO)
+	require.NoError(t, err)
+	tchain, err := testchain.NewWithFork(&thor.NoFork, 180)
+	require.NoError(t, err)
+	pool := New(tchain.Repo(), tchain.Stater(), Options{
+		Limit:           LIMIT,
+		LimitPerAccount: LIMIT_PER_ACCOUNT,
+		MaxLifetime:     time.Hour,
+		Ordering:        fifo,
+	}, &thor.NoFork)
+	defer pool.Close()
+
+	newTxWithCoef := func(coef uint8, from genesis.DevAccount) *tx.Transaction {
+		trx := tx.NewBuilder(tx.TypeLegacy).
+			ChainTag(pool.repo.ChainTag()).
+			Expiration(100).
+			Gas(21000).
+			GasPriceCoef(coef).
+			Build()
+		return tx.MustSign(trx, from.PrivateKey)
+	}
+	cheap := newTxWithCoef(0, devAccounts[0])
+	pricy := newTxWithCoef(255, devAccounts[1])
+	assert.NoError(t, pool.Add(cheap))
+	assert.NoError(t, pool.Add(pricy))
+
+	// the earlier added goes first, though paying less
+	txs, _, _, err := pool.wash(pool.repo.BestBlockSummary(), false)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Transactions{cheap, pricy}, txs)
+
+	pool.executables.Store(txs)
+	pool.ObservePacked(tx.Transactions{cheap})
+	pool.ObservePacked(nil)
+}
+
+func TestWashWithDeps(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+
+	chainTag := pool.repo.ChainTag()
+	newDepTx := func(dependsOn *thor.Bytes32, gas uint64, from genesis.DevAccount) *tx.Transaction {
+		return newTx(tx.TypeLegacy, chainTag, nil, gas, tx.BlockRef{}, 100, dependsOn, tx.Features(0), from)
+	}
+	parentID := func(trx *tx.Transaction) *thor.Bytes32 {
+		id := trx.ID()
+		return &id
+	}
+	lastTransition := func(id thor.Bytes32) TxTransition {
+		transitions := pool.GetStatus(id)
+		require.NotEmpty(t, transitions)
+		return transitions[len(transitions)-1]
+	}
+
+	parent := newDepTx(nil, 21000, devAccounts[0])
+	child := newDepTx(parentID(parent), 21000, devAccounts[1])
+	grandchild := newDepTx(parentID(child), 21000, devAccounts[2])
+	orphan := newDepTx(&thor.Bytes32{1}, 21000, devAccounts[3])
+
+	// dependents added ahead of the parent are not executable until the parent is
+	assert.NoError(t, pool.Add(child))
+	assert.NoError(t, pool.Add(grandchild))
+	assert.Equal(t, TxStatusNonExecutable, lastTransition(child.ID()).Status)
+	assert.NoError(t, pool.Add(parent))
+	pool.Fill(tx.Transactions{orphan})
+
+	txs, _, _, err := pool.wash(pool.repo.BestBlockSummary(), false)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Transactions{parent, child, grandchild}, txs)
+	assert.Equal(t, TxStatusNonExecutable, lastTransition(orphan.ID()).Status)
+
+	assert.Equal(t, &TxDeps{
+		DependsOn:     parentID(parent),
+		DepPending:    true,
+		DepExecutable: true,
+		Dependents:    []thor.Bytes32{grandchild.ID()},
+	}, pool.GetDependencies(child.ID()))
+	assert.Equal(t, &TxDeps{DependsOn: &thor.Bytes32{1}}, pool.GetDependencies(orphan.ID()))
+	assert.Nil(t, pool.GetDependencies(thor.Bytes32{1}))
+
+	// dropping the parent cascades down the graph
+	assert.True(t, pool.Remove(parent.Hash(), parent.ID()))
+	assert.Nil(t, pool.Get(child.ID()))
+	assert.Nil(t, pool.Get(grandchild.ID()))
+	assert.Equal(t, DropReasonDepDropped, lastTransition(child.ID()).Reason)
+	assert.Equal(t, DropReasonDepDropped, lastTransition(grandchild.ID()).Reason)
+	assert.NotNil(t, pool.Get(orphan.ID()))
+
+	// and so does the parent washed out
+	tooMuchGas := newDepTx(nil, pool.repo.BestBlockSummary().Header.GasLimit()+1, devAccounts[4])
+	dependent := newDepTx(parentID(tooMuchGas), 21000, devAccounts[5])
+	pool.Fill(tx.Transactions{dependent, tooMuchGas})
+	txs, _, _, err = pool.wash(pool.repo.BestBlockSummary(), false)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	assert.Equal(t, "gas too large", lastTransition(tooMuchGas.ID()).Reason)
+	assert.Equal(t, DropReasonDepDropped, lastTransition(dependent.ID()).Reason)
+	assert.Nil(t, pool.Get(dependent.ID()))
+}
+
 func TestOrderTxsAfterGalacticaFork(t *testing.T) {
 	now := uint64(time.Now().Unix() - time.Now().Unix()%10 - 10)
 	db := muxdb.NewMem()
@@ -593,6 +963,7 @@ func TestOrderTxsAfterGalacticaFork(t *testing.T) {
 		Gas(21000).
 		MaxFeePerGas(big.NewInt(thor.InitialBaseFee * 10)).
 		MaxPriorityFeePerGas(big.NewInt(thor.InitialBaseFee * 10)).
+		Nonce(uint64(poolLimit)).
 		Build()
 	firstTx = tx.MustSign(firstTx, devAccounts[0].PrivateKey)
 
@@ -603,6 +974,7 @@ func TestOrderTxsAfterGalacticaFork(t *testing.T) {
 		Gas(21000).
 		MaxFeePerGas(big.NewInt(thor.InitialBaseFee * 10)).
 		MaxPriorityFeePerGas(common.Big0).
+		Nonce(uint64(poolLimit + 1)).
 		Build()
 	lastTx = tx.MustSign(lastTx, devAccounts[0].PrivateKey)
 
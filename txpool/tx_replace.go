@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// replaceKeyOf returns the key identifying txs which replace each other, made of origin, chain tag, nonce and clauses.
+// The type is also part of the key, since fees of different types are not comparable. The delegator is not, so the
+// replacement of a delegated tx can be co-signed by another delegator.
+func replaceKeyOf(trx *tx.Transaction, origin thor.Address) (thor.Bytes32, error) {
+	clauses, err := rlp.EncodeToBytes(trx.Clauses())
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	var nonce [8]byte
+	binary.BigEndian.PutUint64(nonce[:], trx.Nonce())
+
+	return thor.Blake2b(origin[:], []byte{trx.Type(), trx.ChainTag()}, nonce[:], clauses), nil
+}
+
+// replacedBy returns the tx in the pool to be replaced by the new tx, either the one explicitly given by id, or the one
+// with the same replace key. It returns nil if nothing to replace, or the rejection if the new tx is not qualified.
+// Only locally submitted txs replace others, so that a peer relaying a fee-bumped copy can't evict pending txs.
+// Remote txs are added aside the ones with the same key, as any other tx.
+// Txs already announced to peers are not replaced, since the replacement doesn't invalidate them, and both could be
+// packed by other nodes. The same goes for txs with dependents announced, which are dropped along.
+func (p *TxPool) replacedBy(newTxObj *TxObject, replaces *thor.Bytes32) (*TxObject, error) {
+	var old *TxObject
+	if replaces != nil {
+		if old = p.all.GetByID(*replaces); old == nil {
+			return nil, txRejectedError{"tx to replace not found"}
+		}
+		if old.Origin() != newTxObj.Origin() {
+			return nil, txRejectedError{"origin mismatch with tx to replace"}
+		}
+		if old.Type() != newTxObj.Type() {
+			return nil, txRejectedError{"tx type mismatch with tx to replace"}
+		}
+	} else if !newTxObj.localSubmitted {
+		return nil, nil
+	} else if old = p.all.GetByReplaceKey(newTxObj.replaceKey); old == nil {
+		return nil, nil
+	}
+
+	if p.announced(old) {
+		return nil, txRejectedError{"tx to replace already announced to peers"}
+	}
+	if !p.feeBumped(old, newTxObj) {
+		return nil, txRejectedError{"replacement underpriced"}
+	}
+	return old, nil
+}
+
+// announced returns whether the tx or any of its dependents is known to peers, either broadcast by this node, or
+// received from peers.
+func (p *TxPool) announced(txObj *TxObject) bool {
+	for queue := []*TxObject{txObj}; len(queue) > 0; queue = queue[1:] {
+		if !queue[0].localSubmitted || queue[0].announced.Load() {
+			return true
+		}
+		queue = append(queue, p.all.GetDependents(queue[0].ID())...)
+	}
+	return false
+}
+
+// feeBumped returns whether the new tx pays enough more than the old one to replace it.
+// Each fee must be bumped by the configured percentage at least, and one of them must be strictly higher.
+func (p *TxPool) feeBumped(old, newTxObj *TxObject) bool {
+	if old.Type() == tx.TypeLegacy {
+		oldCoef := new(big.Int).SetUint64(uint64(old.GasPriceCoef()))
+		newCoef := new(big.Int).SetUint64(uint64(newTxObj.GasPriceCoef()))
+		return newCoef.Cmp(oldCoef) > 0 && bumped(oldCoef, newCoef, p.options.PriorityFeeBump)
+	}
+
+	if !bumped(old.MaxPriorityFeePerGas(), newTxObj.MaxPriorityFeePerGas(), p.options.PriorityFeeBump) ||
+		!bumped(old.MaxFeePerGas(), newTxObj.MaxFeePerGas(), p.options.MaxFeeBump) {
+		return false
+	}
+	return newTxObj.MaxPriorityFeePerGas().Cmp(old.MaxPriorityFeePerGas()) > 0 ||
+		newTxObj.MaxFeePerGas().Cmp(old.MaxFeePerGas()) > 0
+}
+
+// bumped returns whether the new value is higher than the old one by the percentage at least.
+func bumped(oldValue, newValue *big.Int, percent int) bool {
+	threshold := new(big.Int).Mul(oldValue, big.NewInt(int64(100+percent)))
+	return threshold.Cmp(new(big.Int).Mul(newValue, big.NewInt(100))) <= 0
+}
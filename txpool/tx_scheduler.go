@@ -0,0 +1,280 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// ScheduledTx is a signed tx held by the scheduler until its release condition is met.
+type ScheduledTx struct {
+	Tx           *tx.Transaction
+	ReleaseBlock uint32 // released once the best block number reaches it
+	ReleaseTime  uint64 // released once the best block timestamp reaches it
+	TimeAdded    uint64
+	Rejection    string // why the pool rejected it on release, empty if not released yet
+}
+
+// due returns whether the tx should be released on the given head.
+func (s *ScheduledTx) due(head *block.Header) bool {
+	return head.Number() >= s.ReleaseBlock && head.Timestamp() >= s.ReleaseTime
+}
+
+// Scheduler holds locally submitted txs and adds them into the pool once the best block reaches their release
+// block number and timestamp. Scheduled txs are persisted, so they survive restarts.
+// Txs rejected by the pool on release are kept with the rejection, until cancelled or expired.
+type Scheduler struct {
+	repo   *chain.Repository
+	pool   *TxPool
+	submit func(*tx.Transaction) error
+	db     *leveldb.DB
+	limit  int
+
+	lock sync.Mutex
+	txs  map[thor.Bytes32]*ScheduledTx
+
+	ctx    context.Context
+	cancel func()
+	goes   co.Goes
+}
+
+// NewScheduler creates the scheduler with txs persisted at the given path, and starts releasing them by submit,
+// which adds them into the pool if nil.
+func NewScheduler(repo *chain.Repository, pool *TxPool, path string, limit int, submit func(*tx.Transaction) error) (*Scheduler, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if submit == nil {
+		submit = pool.AddLocal
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		repo:   repo,
+		pool:   pool,
+		submit: submit,
+		db:     db,
+		limit:  limit,
+		txs:    make(map[thor.Bytes32]*ScheduledTx),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	s.load()
+	s.goes.Go(s.loop)
+	return s, nil
+}
+
+// Stop stops releasing txs and closes the db.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.goes.Wait()
+	if err := s.db.Close(); err != nil {
+		logger.Warn("close tx scheduler db", "err", err)
+	}
+}
+
+// Schedule holds the tx until the best block reaches the release block number and timestamp.
+// At least one of the conditions should be given.
+func (s *Scheduler) Schedule(trx *tx.Transaction, releaseBlock uint32, releaseTime uint64) error {
+	if releaseBlock == 0 && releaseTime == 0 {
+		return badTxError{"no release condition"}
+	}
+	if err := s.pool.validateTxBasics(trx); err != nil {
+		return err
+	}
+	if _, err := trx.Origin(); err != nil {
+		return badTxError{"invalid signature"}
+	}
+	// the earliest block to include the tx is the one after the release block
+	if trx.IsExpired(releaseBlock + 1) {
+		return txRejectedError{"expires before release"}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.txs[trx.ID()]; ok {
+		return txRejectedError{"already scheduled"}
+	}
+	if len(s.txs) >= s.limit {
+		return txRejectedError{"scheduler is full"}
+	}
+
+	stx := &ScheduledTx{
+		Tx:           trx,
+		ReleaseBlock: releaseBlock,
+		ReleaseTime:  releaseTime,
+		TimeAdded:    uint64(time.Now().Unix()),
+	}
+	data, err := rlp.EncodeToBytes(stx)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put(trx.ID().Bytes(), data, nil); err != nil {
+		return err
+	}
+	s.txs[trx.ID()] = stx
+	return nil
+}
+
+// List returns all scheduled txs, in the order they're going to be released.
+func (s *Scheduler) List() []*ScheduledTx {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	list := make([]*ScheduledTx, 0, len(s.txs))
+	for _, stx := range s.txs {
+		list = append(list, stx)
+	}
+	slices.SortFunc(list, func(a, b *ScheduledTx) int {
+		return cmp.Or(
+			cmp.Compare(a.ReleaseBlock, b.ReleaseBlock),
+			cmp.Compare(a.ReleaseTime, b.ReleaseTime),
+			cmp.Compare(a.TimeAdded, b.TimeAdded),
+		)
+	})
+	return list
+}
+
+// Cancel removes the scheduled tx of the given id, either pending or rejected, and returns it.
+// It returns nil if not scheduled.
+func (s *Scheduler) Cancel(id thor.Bytes32) (*ScheduledTx, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stx, ok := s.txs[id]
+	if !ok {
+		return nil, nil
+	}
+	if err := s.db.Delete(id.Bytes(), nil); err != nil {
+		return nil, err
+	}
+	delete(s.txs, id)
+	return stx, nil
+}
+
+// Len returns the count of scheduled txs.
+func (s *Scheduler) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.txs)
+}
+
+// load reads persisted txs, and removes the ones can't be decoded.
+func (s *Scheduler) load() {
+	var batch leveldb.Batch
+
+	it := s.db.NewIterator(util.BytesPrefix(nil), nil)
+	defer it.Release()
+
+	for it.Next() {
+		var stx ScheduledTx
+		if err := rlp.DecodeBytes(it.Value(), &stx); err != nil {
+			logger.Warn("decode scheduled tx", "err", err)
+			batch.Delete(it.Key())
+		} else {
+			s.txs[stx.Tx.ID()] = &stx
+		}
+	}
+
+	if err := s.db.Write(&batch, nil); err != nil {
+		logger.Warn("remove broken scheduled txs", "err", err)
+	}
+}
+
+func (s *Scheduler) loop() {
+	ticker := s.repo.NewTicker()
+	for {
+		s.release(s.repo.BestBlockSummary().Header)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// release adds txs due on the given head into the pool, and removes them from the scheduler.
+// Txs rejected by the pool are kept with the rejection, and removed once expired.
+func (s *Scheduler) release(head *block.Header) {
+	var due []*ScheduledTx
+
+	s.lock.Lock()
+	for id, stx := range s.txs {
+		if stx.Rejection != "" {
+			if stx.Tx.IsExpired(head.Number()) {
+				s.remove(id)
+			}
+			continue
+		}
+		if stx.due(head) {
+			due = append(due, stx)
+		}
+	}
+	s.lock.Unlock()
+
+	// the pool validates txs against the head, which takes time, so the lock is not held meanwhile
+	for _, stx := range due {
+		id := stx.Tx.ID()
+		err := s.submit(stx.Tx)
+
+		s.lock.Lock()
+		if s.txs[id] != stx {
+			// cancelled meanwhile
+			s.lock.Unlock()
+			continue
+		}
+		if err != nil {
+			logger.Info("scheduled tx rejected by pool", "id", id, "err", err)
+			s.reject(stx, err.Error())
+		} else {
+			logger.Debug("scheduled tx released", "id", id)
+			s.remove(id)
+		}
+		s.lock.Unlock()
+	}
+}
+
+// reject records the rejection of the scheduled tx. The lock should be held.
+func (s *Scheduler) reject(stx *ScheduledTx, rejection string) {
+	rejected := *stx
+	rejected.Rejection = rejection
+	s.txs[stx.Tx.ID()] = &rejected
+
+	data, err := rlp.EncodeToBytes(&rejected)
+	if err != nil {
+		logger.Warn("encode rejected tx", "err", err)
+		return
+	}
+	if err := s.db.Put(stx.Tx.ID().Bytes(), data, nil); err != nil {
+		logger.Warn("persist rejected tx", "err", err)
+	}
+}
+
+// remove removes the scheduled tx of the given id. The lock should be held.
+func (s *Scheduler) remove(id thor.Bytes32) {
+	if err := s.db.Delete(id.Bytes(), nil); err != nil {
+		logger.Warn("delete scheduled tx", "err", err)
+	}
+	delete(s.txs, id)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestScheduler(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT, &thor.NoFork)
+	defer pool.Close()
+
+	path := t.TempDir()
+	scheduler, err := NewScheduler(pool.repo, pool, path, 2, nil)
+	require.NoError(t, err)
+
+	chainTag := pool.repo.ChainTag()
+	genesisTime := pool.repo.GenesisBlock().Header().Timestamp()
+	trx1 := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	trx2 := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[1])
+	trx3 := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[2])
+	badChainTag := newTx(tx.TypeLegacy, chainTag+1, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[3])
+
+	assert.EqualError(t, scheduler.Schedule(trx1, 0, 0), "bad tx: no release condition")
+	assert.EqualError(t, scheduler.Schedule(badChainTag, 10, 0), "bad tx: chain tag mismatch")
+	assert.EqualError(t, scheduler.Schedule(trx1, 100, 0), "tx rejected: expires before release")
+
+	require.NoError(t, scheduler.Schedule(trx1, 10, 0))
+	require.NoError(t, scheduler.Schedule(trx2, 0, genesisTime+100))
+	assert.EqualError(t, scheduler.Schedule(trx1, 20, 0), "tx rejected: already scheduled")
+	assert.EqualError(t, scheduler.Schedule(trx3, 10, 0), "tx rejected: scheduler is full")
+
+	list := scheduler.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, trx2.ID(), list[0].Tx.ID())
+	assert.Equal(t, trx1.ID(), list[1].Tx.ID())
+
+	// scheduled txs survive restarts
+	scheduler.Stop()
+	scheduler, err = NewScheduler(pool.repo, pool, path, 2, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, scheduler.Len())
+
+	cancelled, err := scheduler.Cancel(trx2.ID())
+	require.NoError(t, err)
+	assert.Equal(t, trx2.ID(), cancelled.Tx.ID())
+	cancelled, err = scheduler.Cancel(trx2.ID())
+	require.NoError(t, err)
+	assert.Nil(t, cancelled)
+
+	headAt := func(num uint32) *block.Header {
+		var parentID thor.Bytes32
+		binary.BigEndian.PutUint32(parentID[:], num-1)
+		return new(block.Builder).ParentID(parentID).Timestamp(genesisTime + uint64(num)*thor.BlockInterval()).Build().Header()
+	}
+
+	scheduler.release(headAt(9))
+	assert.Equal(t, 1, scheduler.Len())
+	assert.Nil(t, pool.Get(trx1.ID()))
+
+	scheduler.release(headAt(10))
+	assert.Equal(t, 0, scheduler.Len())
+	assert.NotNil(t, pool.Get(trx1.ID()))
+
+	// rejected by the pool, since the account quota is used up
+	for range LIMIT_PER_ACCOUNT {
+		require.NoError(t, pool.AddLocal(newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[4])))
+	}
+	overQuota := newTx(tx.TypeLegacy, chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[4])
+	require.NoError(t, scheduler.Schedule(overQuota, 20, 0))
+	scheduler.release(headAt(20))
+	assert.Nil(t, pool.Get(overQuota.ID()))
+
+	// kept with the rejection, across restarts
+	scheduler.Stop()
+	scheduler, err = NewScheduler(pool.repo, pool, path, 2, nil)
+	require.NoError(t, err)
+	defer scheduler.Stop()
+	list = scheduler.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, overQuota.ID(), list[0].Tx.ID())
+	assert.Equal(t, "tx rejected: account quota exceeded", list[0].Rejection)
+
+	// not released again, and removed once expired
+	scheduler.release(headAt(21))
+	assert.Equal(t, 1, scheduler.Len())
+	scheduler.release(headAt(101))
+	assert.Equal(t, 0, scheduler.Len())
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	// count of txs kept in the status journal
+	statusJournalLimit = 16384
+	// count of transitions kept for each tx
+	maxTransitionsPerTx = 16
+)
+
+// TxStatus is a state of tx in its lifecycle through the pool.
+type TxStatus string
+
+const (
+	TxStatusAdded         TxStatus = "added"
+	TxStatusExecutable    TxStatus = "executable"
+	TxStatusNonExecutable TxStatus = "non-executable"
+	TxStatusDropped       TxStatus = "dropped"
+	TxStatusPacked        TxStatus = "packed"
+)
+
+// Reasons of txs dropped by the pool itself. Txs may also be dropped with the error of being not executable,
+// e.g. "expired", or the rejection of being added, e.g. "account quota exceeded".
+const (
+	DropReasonBlocked      = "blocked"
+	DropReasonLifetime     = "out of lifetime"
+	DropReasonPoolLimit    = "pool limit"
+	DropReasonNonExecLimit = "non-executable limit"
+	DropReasonRemoved      = "removed"
+	DropReasonReplaced     = "replaced"
+)
+
+// TxTransition is a state transition of tx.
+type TxTransition struct {
+	Status     TxStatus
+	Reason     string        // reason of being dropped
+	ReplacedBy *thor.Bytes32 // tx replaced by, if dropped due to replacement
+	BlockID    *thor.Bytes32 // block packed into
+	Timestamp  int64         // unix time of the transition
+}
+
+// statusJournal records recent state transitions of txs, which outlives txs in the pool.
+type statusJournal struct {
+	lock sync.Mutex
+	lru  *simplelru.LRU // tx id => []TxTransition
+}
+
+func newStatusJournal(limit int) *statusJournal {
+	lru, _ := simplelru.NewLRU(limit, nil)
+	return &statusJournal{lru: lru}
+}
+
+// record appends the transition of the tx. It returns false if the tx is already executable or non-executable
+// as the transition tells, which is not recorded.
+func (j *statusJournal) record(id thor.Bytes32, t TxTransition) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var transitions []TxTransition
+	if v, ok := j.lru.Get(id); ok {
+		transitions = v.([]TxTransition)
+	}
+	if n := len(transitions); n > 0 && transitions[n-1].Status == t.Status &&
+		(t.Status == TxStatusExecutable || t.Status == TxStatusNonExecutable) {
+		return false
+	}
+	t.Timestamp = time.Now().Unix()
+	if len(transitions) >= maxTransitionsPerTx {
+		transitions = transitions[1:]
+	}
+	// copy on write, since slices returned by get are shared
+	j.lru.Add(id, append(transitions[:len(transitions):len(transitions)], t))
+	return true
+}
+
+// get returns recorded transitions of the tx, from the oldest to the latest.
+func (j *statusJournal) get(id thor.Bytes32) []TxTransition {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if v, ok := j.lru.Get(id); ok {
+		return v.([]TxTransition)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestStatusJournal(t *testing.T) {
+	j := newStatusJournal(2)
+	id := thor.Bytes32{1}
+
+	assert.Nil(t, j.get(id))
+	assert.True(t, j.record(id, TxTransition{Status: TxStatusAdded}))
+	assert.True(t, j.record(id, TxTransition{Status: TxStatusExecutable}))
+	// repeated executable status is not recorded
+	assert.False(t, j.record(id, TxTransition{Status: TxStatusExecutable}))
+	assert.True(t, j.record(id, TxTransition{Status: TxStatusNonExecutable}))
+	assert.True(t, j.record(id, TxTransition{Status: TxStatusDropped, Reason: "expired"}))
+
+	transitions := j.get(id)
+	assert.Len(t, transitions, 4)
+	assert.Equal(t, TxStatusDropped, transitions[3].Status)
+	assert.Equal(t, "expired", transitions[3].Reason)
+	assert.NotZero(t, transitions[3].Timestamp)
+
+	// transitions returned are not affected by later records
+	j.record(id, TxTransition{Status: TxStatusAdded})
+	assert.Len(t, transitions, 4)
+	assert.Len(t, j.get(id), 5)
+
+	// the oldest transitions are evicted
+	for range maxTransitionsPerTx {
+		j.record(id, TxTransition{Status: TxStatusAdded})
+	}
+	transitions = j.get(id)
+	assert.Len(t, transitions, maxTransitionsPerTx)
+	assert.Equal(t, TxStatusAdded, transitions[0].Status)
+
+	// the least recent tx is evicted
+	j.record(thor.Bytes32{2}, TxTransition{Status: TxStatusAdded})
+	j.record(thor.Bytes32{3}, TxTransition{Status: TxStatusAdded})
+	assert.Nil(t, j.get(id))
+	assert.NotNil(t, j.get(thor.Bytes32{2}))
+	assert.NotNil(t, j.get(thor.Bytes32{3}))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package witness
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Execute verifies the block statelessly. The witness is checked against the state root of the parent
+// header, and then the block is executed on the witness only, which fails if any state data absent from
+// the witness is touched. The block's state root and receipts root are checked as a full node does.
+//
+// The repository is consulted for chain history (e.g. block hashes and proposer seeds), never for state.
+func Execute(
+	repo *chain.Repository,
+	forkConfig *thor.ForkConfig,
+	parent *chain.BlockSummary,
+	blk *block.Block,
+	conflicts uint32,
+	w *Witness,
+) (tx.Receipts, error) {
+	if blk.Header().ParentID() != parent.Header.ID() {
+		return nil, errors.New("parent mismatch")
+	}
+	if err := w.Verify(parent.Header.StateRoot()); err != nil {
+		return nil, errors.Wrap(err, "verify witness")
+	}
+
+	db := muxdb.NewMem()
+	defer db.Close()
+	if err := w.load(db); err != nil {
+		return nil, errors.Wrap(err, "load witness")
+	}
+
+	_, receipts, err := consensus.New(repo, state.NewStater(db), forkConfig).
+		Process(parent, blk, blk.Header().Timestamp(), conflicts)
+	if err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
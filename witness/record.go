@@ -0,0 +1,166 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package witness
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// recorder collects trie nodes and codes read. It implements muxdb.Recorder.
+type recorder struct {
+	tries map[string]map[string]*Node // trie name => path => node
+	codes map[string][]byte           // code hash => code
+	mu    sync.Mutex
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		tries: make(map[string]map[string]*Node),
+		codes: make(map[string][]byte),
+	}
+}
+
+func (r *recorder) RecordNode(name string, path []byte, ver trie.Version, blob []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	nodes := r.tries[name]
+	if nodes == nil {
+		nodes = make(map[string]*Node)
+		r.tries[name] = nodes
+	}
+	if _, ok := nodes[string(path)]; !ok {
+		nodes[string(path)] = &Node{
+			Path:  bytes.Clone(path),
+			Major: ver.Major,
+			Minor: ver.Minor,
+			Blob:  bytes.Clone(blob),
+		}
+	}
+}
+
+func (r *recorder) RecordEntry(store string, key, val []byte) {
+	if store != state.CodeStoreName {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.codes[string(key)] = bytes.Clone(val)
+}
+
+// witness builds the witness in canonical order.
+func (r *recorder) witness() *Witness {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.build()
+}
+
+// take builds the witness, and clears data recorded for the next recording.
+func (r *recorder) take() *Witness {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w := r.build()
+	r.tries = make(map[string]map[string]*Node)
+	r.codes = make(map[string][]byte)
+	return w
+}
+
+// build must be called with lock held.
+func (r *recorder) build() *Witness {
+	var w Witness
+	for name, nodes := range r.tries {
+		t := &Trie{Name: name}
+		for _, n := range nodes {
+			t.Nodes = append(t.Nodes, n)
+		}
+		sort.Slice(t.Nodes, func(i, j int) bool {
+			return bytes.Compare(t.Nodes[i].Path, t.Nodes[j].Path) < 0
+		})
+		w.Tries = append(w.Tries, t)
+	}
+	sort.Slice(w.Tries, func(i, j int) bool {
+		return w.Tries[i].Name < w.Tries[j].Name
+	})
+
+	hashes := make([]string, 0, len(r.codes))
+	for h := range r.codes {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+	for _, h := range hashes {
+		w.Codes = append(w.Codes, r.codes[h])
+	}
+	return &w
+}
+
+// Recording records witnesses of blocks executed on its stater, which reads the state through a recording view.
+// Blocks are expected to be executed one at a time, and the witness of each is taken right after the execution.
+type Recording struct {
+	rec    *recorder
+	stater *state.Stater
+}
+
+// NewRecording creates a recording on the stater.
+func NewRecording(stater *state.Stater) *Recording {
+	rec := newRecorder()
+	return &Recording{
+		rec:    rec,
+		stater: stater.NewRecordingView(rec),
+	}
+}
+
+// Stater returns the stater to execute blocks on.
+func (r *Recording) Stater() *state.Stater {
+	return r.stater
+}
+
+// Take returns the witness recorded since the last take, which is of the block just executed.
+func (r *Recording) Take() *Witness {
+	return r.rec.take()
+}
+
+// Record re-executes the block on its parent state, and returns the witness of the execution.
+// The parent state must be available in db.
+func Record(db *muxdb.MuxDB, repo *chain.Repository, forkConfig *thor.ForkConfig, id thor.Bytes32) (*Witness, error) {
+	summary, err := repo.GetBlockSummary(id)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := repo.GetBlock(id)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Header().Number() == 0 {
+		return nil, errors.New("genesis block has no witness")
+	}
+	parent, err := repo.GetBlockSummary(blk.Header().ParentID())
+	if err != nil {
+		return nil, err
+	}
+
+	rec := newRecorder()
+	view := db.NewRecordingView(rec)
+	defer view.Close()
+
+	if _, _, err := consensus.New(repo, state.NewStater(view), forkConfig).
+		Process(parent, blk, blk.Header().Timestamp(), summary.Conflicts); err != nil {
+		return nil, errors.Wrap(err, "execute block")
+	}
+	return rec.witness(), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package witness
+
+import (
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// StoreName is the name of the store keeps witnesses.
+const StoreName = "witness"
+
+// Store keeps encoded witnesses keyed by block ID.
+type Store struct {
+	db         *muxdb.MuxDB
+	repo       *chain.Repository
+	forkConfig *thor.ForkConfig
+	store      kv.Store
+	recording  *Recording
+}
+
+// NewStore creates a witness store. Witnesses are recorded while executing blocks on the recording of the stater.
+func NewStore(db *muxdb.MuxDB, stater *state.Stater, repo *chain.Repository, forkConfig *thor.ForkConfig) *Store {
+	return &Store{
+		db:         db,
+		repo:       repo,
+		forkConfig: forkConfig,
+		store:      db.NewStore(StoreName),
+		recording:  NewRecording(stater),
+	}
+}
+
+// Recording returns the recording on the stater, to record witnesses while executing blocks.
+func (s *Store) Recording() *Recording {
+	return s.recording
+}
+
+// Put saves the witness of the block.
+func (s *Store) Put(id thor.Bytes32, w *Witness) error {
+	data, err := w.Encode()
+	if err != nil {
+		return err
+	}
+	return s.store.Put(id.Bytes(), data)
+}
+
+// Save re-executes the block to record its witness, and saves it. It's an explicit operation for blocks
+// not recorded while executing, which requires the parent state.
+func (s *Store) Save(id thor.Bytes32) error {
+	w, err := Record(s.db, s.repo, s.forkConfig, id)
+	if err != nil {
+		return err
+	}
+	return s.Put(id, w)
+}
+
+// GetRaw returns the encoded witness of the block, saved by Put or Save.
+func (s *Store) GetRaw(id thor.Bytes32) ([]byte, error) {
+	return s.store.Get(id.Bytes())
+}
+
+// IsNotFound returns whether the error indicates the witness is not saved.
+func (s *Store) IsNotFound(err error) bool {
+	return s.store.IsNotFound(err)
+}
+
+// Get returns the witness of the block.
+func (s *Store) Get(id thor.Bytes32) (*Witness, error) {
+	data, err := s.GetRaw(id)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(data)
+}
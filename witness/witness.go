@@ -0,0 +1,142 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package witness records state data touched by block execution, and re-executes blocks with it,
+// so that blocks can be verified without the full state.
+package witness
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// Witness holds parent state data read while a block is executed, i.e. trie nodes and contract codes.
+type Witness struct {
+	Tries []*Trie
+	Codes [][]byte
+}
+
+// Trie holds nodes read from a state trie.
+type Trie struct {
+	Name  string
+	Nodes []*Node
+}
+
+// Node is a trie node as stored in the database.
+type Node struct {
+	Path  []byte
+	Major uint32
+	Minor uint32
+	Blob  []byte
+}
+
+// Encode encodes the witness in the compact form.
+func (w *Witness) Encode() ([]byte, error) {
+	data, err := rlp.EncodeToBytes(w)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+// Decode decodes the witness from the compact form.
+func Decode(data []byte) (*Witness, error) {
+	raw, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	var w Witness
+	if err := rlp.DecodeBytes(raw, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Verify checks that the witness is a partial view of the state with the given root, i.e. nodes of
+// the account trie hash up to the root, nodes of storage tries hash up to storage roots of accounts
+// in the witness, and no redundant node is included.
+func (w *Witness) Verify(stateRoot thor.Bytes32) error {
+	tries := make(map[string]map[string][]byte, len(w.Tries))
+	for _, t := range w.Tries {
+		if _, ok := tries[t.Name]; ok {
+			return errors.Errorf("duplicated trie %x", t.Name)
+		}
+		nodes := make(map[string][]byte, len(t.Nodes))
+		for _, n := range t.Nodes {
+			if _, ok := nodes[string(n.Path)]; ok {
+				return errors.Errorf("trie %x: duplicated node %x", t.Name, n.Path)
+			}
+			nodes[string(n.Path)] = n.Blob
+		}
+		tries[t.Name] = nodes
+	}
+
+	// storage trie name => storage root
+	storageRoots := make(map[string]thor.Bytes32)
+	if err := trie.VerifyNodes(stateRoot, tries[state.AccountTrieName], func(val, meta []byte) error {
+		var (
+			acc state.Account
+			am  state.AccountMetadata
+		)
+		if err := rlp.DecodeBytes(val, &acc); err != nil {
+			return errors.Wrap(err, "decode account")
+		}
+		if len(meta) > 0 {
+			if err := rlp.DecodeBytes(meta, &am); err != nil {
+				return errors.Wrap(err, "decode account metadata")
+			}
+		}
+		if len(am.StorageID) > 0 {
+			storageRoots[state.StorageTrieName(am.StorageID)] = thor.BytesToBytes32(acc.StorageRoot)
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "account trie")
+	}
+
+	for name, nodes := range tries {
+		if name == state.AccountTrieName {
+			continue
+		}
+		if !strings.HasPrefix(name, state.StorageTrieNamePrefix) {
+			return errors.Errorf("unexpected trie %x", name)
+		}
+		root, ok := storageRoots[name]
+		if !ok {
+			return errors.Errorf("storage trie %x: account not included", name)
+		}
+		if err := trie.VerifyNodes(root, nodes, nil); err != nil {
+			return errors.Wrapf(err, "storage trie %x", name)
+		}
+	}
+	return nil
+}
+
+// load puts data of the witness into db.
+func (w *Witness) load(db *muxdb.MuxDB) error {
+	for _, t := range w.Tries {
+		for _, n := range t.Nodes {
+			if err := db.PutTrieNode(t.Name, n.Path, trie.Version{Major: n.Major, Minor: n.Minor}, n.Blob); err != nil {
+				return err
+			}
+		}
+	}
+	codeStore := db.NewStore(state.CodeStoreName)
+	for _, code := range w.Codes {
+		// keyed by the computed hash, so that codes are never forged
+		if err := codeStore.Put(thor.Keccak256(code).Bytes(), code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package witness
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestRecordAndExecute(t *testing.T) {
+	tchain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	var (
+		repo = tchain.Repo()
+		to   = thor.BytesToAddress([]byte("to"))
+	)
+	method, ok := builtin.Energy.ABI.MethodByName("transfer")
+	require.True(t, ok)
+	data, err := method.EncodeInput(to, big.NewInt(1))
+	require.NoError(t, err)
+
+	require.NoError(t, tchain.MintClauses(genesis.DevAccounts()[0], []*tx.Clause{
+		tx.NewClause(&to).WithValue(big.NewInt(1)),
+		tx.NewClause(&builtin.Energy.Address).WithData(data),
+	}))
+
+	blk, err := tchain.BestBlock()
+	require.NoError(t, err)
+	parent, err := repo.GetBlockSummary(blk.Header().ParentID())
+	require.NoError(t, err)
+
+	store := NewStore(tchain.Database(), tchain.Stater(), repo, tchain.GetForkConfig())
+	require.NoError(t, store.Save(blk.Header().ID()))
+	w, err := store.Get(blk.Header().ID())
+	require.NoError(t, err)
+
+	// storage of the energy contract and its code are touched
+	assert.True(t, len(w.Tries) > 1)
+	assert.Equal(t, state.AccountTrieName, w.Tries[0].Name)
+	assert.NotEmpty(t, w.Codes)
+
+	// round trip
+	data, err = w.Encode()
+	require.NoError(t, err)
+	w2, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, w, w2)
+
+	receipts, err := Execute(repo, tchain.GetForkConfig(), parent, blk, 0, w)
+	require.NoError(t, err)
+	assert.Len(t, receipts, 1)
+	assert.False(t, receipts[0].Reverted)
+
+	t.Run("tampered node", func(t *testing.T) {
+		w, _ := Decode(data)
+		n := w.Tries[0].Nodes[len(w.Tries[0].Nodes)-1]
+		n.Blob = bytes.Clone(n.Blob)
+		n.Blob[len(n.Blob)-1]++
+		_, err := Execute(repo, tchain.GetForkConfig(), parent, blk, 0, w)
+		assert.ErrorContains(t, err, "verify witness")
+	})
+
+	t.Run("missing node", func(t *testing.T) {
+		w, _ := Decode(data)
+		w.Tries[0].Nodes = w.Tries[0].Nodes[:len(w.Tries[0].Nodes)-1]
+		_, err := Execute(repo, tchain.GetForkConfig(), parent, blk, 0, w)
+		assert.Error(t, err)
+	})
+
+	t.Run("recorded while executing", func(t *testing.T) {
+		recording := store.Recording()
+		cons := consensus.New(repo, recording.Stater(), tchain.GetForkConfig())
+		_, _, err := cons.Process(parent, blk, blk.Header().Timestamp(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, w, recording.Take())
+		// cleared once taken
+		assert.Empty(t, recording.Take().Tries)
+	})
+
+	t.Run("wrong parent", func(t *testing.T) {
+		_, err := Execute(repo, tchain.GetForkConfig(), parent, tchain.GenesisBlock(), 0, w)
+		assert.Error(t, err)
+	})
+}